@@ -4,14 +4,14 @@
 
 package arrary
 
-type Arrary interface {
+type Arrary[T any] interface {
 	Size() int
 	Empty() bool
 	Clean()
-	Get(int) interface{}
-	Set(int, interface{})
-	Front() interface{}
-	Back() interface{}
-	PushBack(interface{})
-	PopBack() interface{}
+	Get(int) T
+	Set(int, T)
+	Front() T
+	Back() T
+	PushBack(T)
+	PopBack() T
 }
@@ -0,0 +1,71 @@
+// Copyright (c) 2025 Tian ChunXing. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package blockslices
+
+import (
+	"bytes"
+	"io"
+
+	"github.com/mrtcx/plusdata/codec"
+)
+
+// SetCodec 设置编解码元素的codec，int、int64、uint64、float64、string、[]byte可以不设置
+func (b *blockSlice[T]) SetCodec(c codec.Codec[T]) {
+	b.codec = c
+}
+
+// Encode 按顺序把元素逐个编码写入w
+func (b *blockSlice[T]) Encode(w io.Writer) error {
+	c, err := codec.Resolve(b.codec)
+	if err != nil {
+		return err
+	}
+	enc := codec.NewEncoder(w, codec.KindArrary, b.Size())
+	for i := 0; i < b.Size(); i++ {
+		codec.Write(enc, c, b.Get(i))
+	}
+	return enc.Close()
+}
+
+// Decode 清空后从r中解码，出错时容器为空
+func (b *blockSlice[T]) Decode(r io.Reader) error {
+	b.Clean()
+	if err := b.decode(r); err != nil {
+		b.Clean()
+		return err
+	}
+	return nil
+}
+
+func (b *blockSlice[T]) decode(r io.Reader) error {
+	c, err := codec.Resolve(b.codec)
+	if err != nil {
+		return err
+	}
+	dec, n, err := codec.NewDecoder(r, codec.KindArrary)
+	if err != nil {
+		return err
+	}
+	for i := 0; i < n; i++ {
+		v, err := codec.Read(dec, c)
+		if err != nil {
+			return err
+		}
+		b.PushBack(v)
+	}
+	return dec.Close()
+}
+
+func (b *blockSlice[T]) MarshalBinary() ([]byte, error) {
+	var buf bytes.Buffer
+	if err := b.Encode(&buf); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func (b *blockSlice[T]) UnmarshalBinary(data []byte) error {
+	return b.Decode(bytes.NewReader(data))
+}
@@ -4,75 +4,86 @@
 
 package blockslices
 
-import "github.com/mrtcx/plusdata/arrary"
+import (
+	"github.com/mrtcx/plusdata/arrary"
+	"github.com/mrtcx/plusdata/codec"
+	"github.com/mrtcx/plusdata/internal/blocksize"
+)
 
-var _ arrary.Arrary = (*blockSlice)(nil)
+var _ arrary.Arrary[int] = (*blockSlice[int])(nil)
 
-const (
-	_initBlockCap = 4
-	_block        = 1024 * 32 / 8 / 2 //L1-cache-32kb, interface{} - 8 * 2
-)
+const _initBlockCap = blocksize.MinCap
 
-type blockSlice struct {
+type blockSlice[T any] struct {
 	size   int
-	blocks [][]interface{}
+	shift  uint //块内元素个数为1<<shift
+	mask   int
+	blocks [][]T
+	codec  codec.Codec[T]
 }
 
-func New() *blockSlice {
-	return &blockSlice{}
+func New[T any]() *blockSlice[T] {
+	shift := blocksize.Shift[T]()
+	return &blockSlice[T]{
+		shift: shift,
+		mask:  1<<shift - 1,
+	}
 }
 
-func (b *blockSlice) Clean() {
+func (b *blockSlice[T]) Clean() {
 	b.size, b.blocks = 0, nil
 }
 
-func (b *blockSlice) Size() int {
+func (b *blockSlice[T]) Size() int {
 	return b.size
 }
 
-func (b *blockSlice) Empty() bool {
+func (b *blockSlice[T]) Empty() bool {
 	return b.size == 0
 }
 
-func (b *blockSlice) Front() interface{} {
+func (b *blockSlice[T]) Front() T {
 	if b.size == 0 {
-		return nil
+		var zero T
+		return zero
 	}
 	return b.blocks[0][0]
 }
 
-func (b *blockSlice) Back() interface{} {
+func (b *blockSlice[T]) Back() T {
 	if b.size == 0 {
-		return nil
+		var zero T
+		return zero
 	}
 	idx := b.size - 1
-	return b.blocks[idx/_block][idx%_block]
+	return b.blocks[idx>>b.shift][idx&b.mask]
 }
 
-func (b *blockSlice) Get(index int) interface{} {
-	return b.blocks[index/_block][index%_block]
+func (b *blockSlice[T]) Get(index int) T {
+	return b.blocks[index>>b.shift][index&b.mask]
 }
 
-func (b *blockSlice) Set(index int, val interface{}) {
-	b.blocks[index/_block][index%_block] = val
+func (b *blockSlice[T]) Set(index int, val T) {
+	b.blocks[index>>b.shift][index&b.mask] = val
 }
 
-func (b *blockSlice) PushBack(value interface{}) {
-	blockIdx := b.size / _block
+func (b *blockSlice[T]) PushBack(value T) {
+	blockIdx := b.size >> b.shift
 	if blockIdx == len(b.blocks) {
-		b.blocks = append(b.blocks, make([]interface{}, 0, _initBlockCap))
+		b.blocks = append(b.blocks, make([]T, 0, _initBlockCap))
 	}
 	b.blocks[blockIdx] = append(b.blocks[blockIdx], value)
 	b.size++
 }
 
-func (b *blockSlice) PopBack() interface{} {
+func (b *blockSlice[T]) PopBack() T {
 	if b.size == 0 {
-		return nil
+		var zero T
+		return zero
 	}
 	index := b.size - 1
-	blockIdx := index / _block
-	posIdx := index % _block
+	blockIdx := index >> b.shift
+	posIdx := index & b.mask
 	pv := b.blocks[blockIdx][posIdx]
 	b.blocks[blockIdx] = b.blocks[blockIdx][:posIdx]
 	if posIdx == 0 {
@@ -85,18 +96,18 @@ func (b *blockSlice) PopBack() interface{} {
 	return pv
 }
 
-func (b *blockSlice) shrink1() {
+func (b *blockSlice[T]) shrink1() {
 	if cap(b.blocks)/4 > len(b.blocks) {
-		newblock := make([][]interface{}, len(b.blocks))
+		newblock := make([][]T, len(b.blocks))
 		copy(newblock, b.blocks)
 		b.blocks = newblock
 	}
 }
 
-func (b *blockSlice) shrink2() {
+func (b *blockSlice[T]) shrink2() {
 	block := b.blocks[len(b.blocks)-1]
 	if cap(block)/4 > len(block) {
-		newblock := make([]interface{}, len(block))
+		newblock := make([]T, len(block))
 		copy(newblock, block)
 		b.blocks[len(b.blocks)-1] = newblock
 	}
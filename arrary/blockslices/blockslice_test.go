@@ -9,15 +9,18 @@ import (
 	"testing"
 
 	"github.com/mrtcx/plusdata/internal/assert"
+	"github.com/mrtcx/plusdata/internal/blocksize"
 )
 
+var _block = 1 << blocksize.Shift[int]()
+
 func TestFront(t *testing.T) {
 	testNums := []int{1, 2, 3, 4, _block, _block + 1, 2 * _block, 2*_block + 1}
 	for _, v := range testNums {
 		testnum := v
 		t.Run(fmt.Sprintf("[num:%d]", testnum), func(t *testing.T) {
-			q := New()
-			assert.Equal(t, q.Front(), nil)
+			q := New[int]()
+			assert.Equal(t, q.Front(), 0)
 			for i := 0; i < testnum; i++ {
 				q.PushBack(i)
 			}
@@ -25,7 +28,7 @@ func TestFront(t *testing.T) {
 			for i := 0; i < testnum; i++ {
 				q.PopBack()
 			}
-			assert.Equal(t, q.Front(), nil)
+			assert.Equal(t, q.Front(), 0)
 		})
 	}
 }
@@ -35,8 +38,8 @@ func TestBack(t *testing.T) {
 	for _, v := range testNums {
 		testnum := v
 		t.Run(fmt.Sprintf("[num:%d]", testnum), func(t *testing.T) {
-			q := New()
-			assert.Equal(t, q.Back(), nil)
+			q := New[int]()
+			assert.Equal(t, q.Back(), 0)
 			for i := 0; i < testnum; i++ {
 				q.PushBack(i)
 			}
@@ -44,7 +47,7 @@ func TestBack(t *testing.T) {
 			for i := 0; i < testnum; i++ {
 				q.PopBack()
 			}
-			assert.Equal(t, q.Back(), nil)
+			assert.Equal(t, q.Back(), 0)
 		})
 	}
 }
@@ -54,7 +57,7 @@ func TestPushBack(t *testing.T) {
 	for _, v := range testNums {
 		testnum := v
 		t.Run(fmt.Sprintf("[num:%d]", testnum), func(t *testing.T) {
-			q := New()
+			q := New[int]()
 			for i := 0; i < testnum; i++ {
 				q.PushBack(i)
 				assert.Equal(t, q.Back(), i)
@@ -71,7 +74,7 @@ func TestPopBack(t *testing.T) {
 	for _, v := range testNums {
 		testnum := v
 		t.Run(fmt.Sprintf("[num:%d]", testnum), func(t *testing.T) {
-			q := New()
+			q := New[int]()
 			for i := 0; i < testnum; i++ {
 				q.PushBack(i)
 			}
@@ -82,8 +85,8 @@ func TestPopBack(t *testing.T) {
 				q.PopBack()
 				assert.Equal(t, checkBlockCap(t, q), true)
 			}
-			assert.Equal(t, q.Front(), nil)
-			assert.Equal(t, q.Back(), nil)
+			assert.Equal(t, q.Front(), 0)
+			assert.Equal(t, q.Back(), 0)
 			assert.Equal(t, q.Size(), 0)
 		})
 	}
@@ -94,7 +97,7 @@ func TestGet(t *testing.T) {
 	for _, v := range testNums {
 		testnum := v
 		t.Run(fmt.Sprintf("[num:%d]", testnum), func(t *testing.T) {
-			q := New()
+			q := New[int]()
 			for i := 0; i < testnum; i++ {
 				q.PushBack(i)
 			}
@@ -114,7 +117,7 @@ func TestSet(t *testing.T) {
 	for _, v := range testNums {
 		testnum := v
 		t.Run(fmt.Sprintf("[num:%d]", testnum), func(t *testing.T) {
-			q := New()
+			q := New[int]()
 			for i := 0; i < testnum; i++ {
 				q.PushBack(i)
 			}
@@ -129,18 +132,29 @@ func TestSet(t *testing.T) {
 }
 
 func TestClean(t *testing.T) {
-	q := New()
+	q := New[int]()
 	q.PushBack(1)
 	q.Clean()
 	assert.Equal(t, q.Size(), 0)
 	assert.Equal(t, q.Empty(), true)
-	assert.Equal(t, q.Front(), nil)
-	assert.Equal(t, q.Back(), nil)
-	assert.Equal(t, q.PopBack(), nil)
+	assert.Equal(t, q.Front(), 0)
+	assert.Equal(t, q.Back(), 0)
+	assert.Equal(t, q.PopBack(), 0)
 	assert.Equal(t, q.blocks, nil)
 }
 
-func checkBlockCap(t *testing.T, arr *blockSlice) bool {
+func TestInterfaceValue(t *testing.T) {
+	arr := New[interface{}]()
+	arr.PushBack(1)
+	arr.PushBack("2")
+	assert.Equal(t, arr.Front(), 1)
+	assert.Equal(t, arr.Back(), "2")
+	arr.PopBack()
+	arr.PopBack()
+	assert.Equal(t, arr.Back(), nil)
+}
+
+func checkBlockCap(t *testing.T, arr *blockSlice[int]) bool {
 	if cap(arr.blocks)/4 > len(arr.blocks) {
 		t.Logf("fail:%d %d", len(arr.blocks)*4, cap(arr.blocks))
 		return false
@@ -164,7 +178,7 @@ func BenchmarkPushBack(b *testing.B) {
 		b.Run(fmt.Sprintf("slice[%s]", name), func(b *testing.B) {
 			b.ResetTimer()
 			for i := 0; i < b.N; i++ {
-				var arr []interface{}
+				var arr []int
 				for j := 0; j < size; j++ {
 					arr = append(arr, i)
 				}
@@ -173,7 +187,7 @@ func BenchmarkPushBack(b *testing.B) {
 		b.Run(fmt.Sprintf("blockslice[%s]", name), func(b *testing.B) {
 			b.ResetTimer()
 			for i := 0; i < b.N; i++ {
-				arr := New()
+				arr := New[int]()
 				for j := 0; j < size; j++ {
 					arr.PushBack(j)
 				}
@@ -189,7 +203,7 @@ func BenchmarkGet(b *testing.B) {
 		size := v
 		name := testNames[i]
 		b.Run(fmt.Sprintf("slice[%s]", name), func(b *testing.B) {
-			var arr []interface{}
+			var arr []int
 			for i := 0; i < size; i++ {
 				arr = append(arr, i)
 			}
@@ -201,7 +215,7 @@ func BenchmarkGet(b *testing.B) {
 			}
 		})
 		b.Run(fmt.Sprintf("blockslice[%s]", name), func(b *testing.B) {
-			var arr = New()
+			var arr = New[int]()
 			for i := 0; i < size; i++ {
 				arr.PushBack(i)
 			}
@@ -222,7 +236,7 @@ func BenchmarkPopBack(b *testing.B) {
 		size := v
 		name := testNames[i]
 		b.Run(fmt.Sprintf("[%s]", name), func(b *testing.B) {
-			arr := New()
+			arr := New[int]()
 			for i := 1; i <= size; i++ {
 				arr.PushBack(i)
 			}
@@ -0,0 +1,71 @@
+// Copyright (c) 2025 Tian ChunXing. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package scaleslice
+
+import (
+	"bytes"
+	"io"
+
+	"github.com/mrtcx/plusdata/codec"
+)
+
+// SetCodec 设置编解码元素的codec，int、int64、uint64、float64、string、[]byte可以不设置
+func (s *scaleslice[T]) SetCodec(c codec.Codec[T]) {
+	s.codec = c
+}
+
+// Encode 按顺序把元素逐个编码写入w
+func (s *scaleslice[T]) Encode(w io.Writer) error {
+	c, err := codec.Resolve(s.codec)
+	if err != nil {
+		return err
+	}
+	enc := codec.NewEncoder(w, codec.KindArrary, s.Size())
+	for i := 0; i < s.Size(); i++ {
+		codec.Write(enc, c, s.slice[i])
+	}
+	return enc.Close()
+}
+
+// Decode 清空后从r中解码，出错时容器为空
+func (s *scaleslice[T]) Decode(r io.Reader) error {
+	s.Clean()
+	if err := s.decode(r); err != nil {
+		s.Clean()
+		return err
+	}
+	return nil
+}
+
+func (s *scaleslice[T]) decode(r io.Reader) error {
+	c, err := codec.Resolve(s.codec)
+	if err != nil {
+		return err
+	}
+	dec, n, err := codec.NewDecoder(r, codec.KindArrary)
+	if err != nil {
+		return err
+	}
+	for i := 0; i < n; i++ {
+		v, err := codec.Read(dec, c)
+		if err != nil {
+			return err
+		}
+		s.slice = append(s.slice, v)
+	}
+	return dec.Close()
+}
+
+func (s *scaleslice[T]) MarshalBinary() ([]byte, error) {
+	var buf bytes.Buffer
+	if err := s.Encode(&buf); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func (s *scaleslice[T]) UnmarshalBinary(data []byte) error {
+	return s.Decode(bytes.NewReader(data))
+}
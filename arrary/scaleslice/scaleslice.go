@@ -4,64 +4,71 @@
 
 package scaleslice
 
-import "github.com/mrtcx/plusdata/arrary"
+import (
+	"github.com/mrtcx/plusdata/arrary"
+	"github.com/mrtcx/plusdata/codec"
+)
 
-var _ arrary.Arrary = (*scaleslice)(nil)
+var _ arrary.Arrary[int] = (*scaleslice[int])(nil)
 
-type scaleslice struct {
-	slice []interface{}
+type scaleslice[T any] struct {
+	slice []T
+	codec codec.Codec[T]
 }
 
-func New() *scaleslice {
-	return &scaleslice{}
+func New[T any]() *scaleslice[T] {
+	return &scaleslice[T]{}
 }
 
-func (s *scaleslice) Clean() {
+func (s *scaleslice[T]) Clean() {
 	s.slice = nil
 }
 
-func (s *scaleslice) Size() int {
+func (s *scaleslice[T]) Size() int {
 	return len(s.slice)
 }
 
-func (s *scaleslice) Empty() bool {
+func (s *scaleslice[T]) Empty() bool {
 	return len(s.slice) == 0
 }
 
-func (s *scaleslice) Front() interface{} {
+func (s *scaleslice[T]) Front() T {
 	if len(s.slice) == 0 {
-		return nil
+		var zero T
+		return zero
 	}
 	return s.slice[0]
 }
 
-func (s *scaleslice) Back() interface{} {
+func (s *scaleslice[T]) Back() T {
 	if len(s.slice) == 0 {
-		return nil
+		var zero T
+		return zero
 	}
 	return s.slice[len(s.slice)-1]
 }
 
-func (s *scaleslice) Get(index int) interface{} {
+func (s *scaleslice[T]) Get(index int) T {
 	return s.slice[index]
 }
 
-func (s *scaleslice) Set(index int, val interface{}) {
+func (s *scaleslice[T]) Set(index int, val T) {
 	s.slice[index] = val
 }
 
-func (s *scaleslice) PushBack(value interface{}) {
+func (s *scaleslice[T]) PushBack(value T) {
 	s.slice = append(s.slice, value)
 }
 
-func (s *scaleslice) PopBack() interface{} {
+func (s *scaleslice[T]) PopBack() T {
 	if len(s.slice) == 0 {
-		return nil
+		var zero T
+		return zero
 	}
 	pv := s.slice[len(s.slice)-1]
 	s.slice = s.slice[0 : len(s.slice)-1]
 	if cap(s.slice)/8 > len(s.slice) {
-		newblock := make([]interface{}, len(s.slice))
+		newblock := make([]T, len(s.slice))
 		copy(newblock, s.slice)
 		s.slice = newblock
 	}
@@ -16,8 +16,8 @@ func TestFront(t *testing.T) {
 	for _, v := range testNums {
 		testnum := v
 		t.Run(fmt.Sprintf("[num:%d]", testnum), func(t *testing.T) {
-			q := New()
-			assert.Equal(t, q.Front(), nil)
+			q := New[int]()
+			assert.Equal(t, q.Front(), 0)
 			for i := 0; i < testnum; i++ {
 				q.PushBack(i)
 			}
@@ -25,7 +25,7 @@ func TestFront(t *testing.T) {
 			for i := 0; i < testnum; i++ {
 				q.PopBack()
 			}
-			assert.Equal(t, q.Front(), nil)
+			assert.Equal(t, q.Front(), 0)
 		})
 	}
 }
@@ -35,8 +35,8 @@ func TestBack(t *testing.T) {
 	for _, v := range testNums {
 		testnum := v
 		t.Run(fmt.Sprintf("[num:%d]", testnum), func(t *testing.T) {
-			q := New()
-			assert.Equal(t, q.Back(), nil)
+			q := New[int]()
+			assert.Equal(t, q.Back(), 0)
 			for i := 0; i < testnum; i++ {
 				q.PushBack(i)
 			}
@@ -44,7 +44,7 @@ func TestBack(t *testing.T) {
 			for i := 0; i < testnum; i++ {
 				q.PopBack()
 			}
-			assert.Equal(t, q.Back(), nil)
+			assert.Equal(t, q.Back(), 0)
 		})
 	}
 }
@@ -54,7 +54,7 @@ func TestPushBack(t *testing.T) {
 	for _, v := range testNums {
 		testnum := v
 		t.Run(fmt.Sprintf("[num:%d]", testnum), func(t *testing.T) {
-			q := New()
+			q := New[int]()
 			for i := 0; i < testnum; i++ {
 				q.PushBack(i)
 				assert.Equal(t, q.Back(), i)
@@ -71,7 +71,7 @@ func TestPopBack(t *testing.T) {
 	for _, v := range testNums {
 		testnum := v
 		t.Run(fmt.Sprintf("[num:%d]", testnum), func(t *testing.T) {
-			q := New()
+			q := New[int]()
 			for i := 0; i < testnum; i++ {
 				q.PushBack(i)
 			}
@@ -82,8 +82,8 @@ func TestPopBack(t *testing.T) {
 				q.PopBack()
 				assert.Equal(t, checkCap(t, q), true)
 			}
-			assert.Equal(t, q.Front(), nil)
-			assert.Equal(t, q.Back(), nil)
+			assert.Equal(t, q.Front(), 0)
+			assert.Equal(t, q.Back(), 0)
 			assert.Equal(t, q.Size(), 0)
 		})
 	}
@@ -94,7 +94,7 @@ func TestGet(t *testing.T) {
 	for _, v := range testNums {
 		testnum := v
 		t.Run(fmt.Sprintf("[num:%d]", testnum), func(t *testing.T) {
-			q := New()
+			q := New[int]()
 			for i := 0; i < testnum; i++ {
 				q.PushBack(i)
 			}
@@ -114,7 +114,7 @@ func TestSet(t *testing.T) {
 	for _, v := range testNums {
 		testnum := v
 		t.Run(fmt.Sprintf("[num:%d]", testnum), func(t *testing.T) {
-			q := New()
+			q := New[int]()
 			for i := 0; i < testnum; i++ {
 				q.PushBack(i)
 			}
@@ -129,17 +129,17 @@ func TestSet(t *testing.T) {
 }
 
 func TestClean(t *testing.T) {
-	q := New()
+	q := New[int]()
 	q.PushBack(1)
 	q.Clean()
 	assert.Equal(t, q.Size(), 0)
 	assert.Equal(t, q.Empty(), true)
-	assert.Equal(t, q.Front(), nil)
-	assert.Equal(t, q.Back(), nil)
-	assert.Equal(t, q.PopBack(), nil)
+	assert.Equal(t, q.Front(), 0)
+	assert.Equal(t, q.Back(), 0)
+	assert.Equal(t, q.PopBack(), 0)
 	assert.Equal(t, len(q.slice), 0)
 }
-func checkCap(t *testing.T, arr *scaleslice) bool {
+func checkCap(t *testing.T, arr *scaleslice[int]) bool {
 	if cap(arr.slice)/8 > len(arr.slice) {
 		t.Logf("fail:%d %d", len(arr.slice)*4, cap(arr.slice))
 		return false
@@ -156,7 +156,7 @@ func BenchmarkPushBack(b *testing.B) {
 		b.Run(fmt.Sprintf("slice[%s]", name), func(b *testing.B) {
 			b.ResetTimer()
 			for i := 0; i < b.N; i++ {
-				var arr []interface{}
+				var arr []int
 				for j := 0; j < size; j++ {
 					arr = append(arr, i)
 				}
@@ -165,7 +165,7 @@ func BenchmarkPushBack(b *testing.B) {
 		b.Run(fmt.Sprintf("blockslice[%s]", name), func(b *testing.B) {
 			b.ResetTimer()
 			for i := 0; i < b.N; i++ {
-				arr := New()
+				arr := New[int]()
 				for j := 0; j < size; j++ {
 					arr.PushBack(j)
 				}
@@ -181,7 +181,7 @@ func BenchmarkGet(b *testing.B) {
 		size := v
 		name := testNames[i]
 		b.Run(fmt.Sprintf("slice[%s]", name), func(b *testing.B) {
-			var arr []interface{}
+			var arr []int
 			for i := 0; i < size; i++ {
 				arr = append(arr, i)
 			}
@@ -193,7 +193,7 @@ func BenchmarkGet(b *testing.B) {
 			}
 		})
 		b.Run(fmt.Sprintf("blockslice[%s]", name), func(b *testing.B) {
-			var arr = New()
+			var arr = New[int]()
 			for i := 0; i < size; i++ {
 				arr.PushBack(i)
 			}
@@ -214,7 +214,7 @@ func BenchmarkPopBack(b *testing.B) {
 		size := v
 		name := testNames[i]
 		b.Run(fmt.Sprintf("[%s]", name), func(b *testing.B) {
-			arr := New()
+			arr := New[int]()
 			for i := 1; i <= size; i++ {
 				arr.PushBack(i)
 			}
@@ -0,0 +1,125 @@
+// Copyright (c) 2025 Tian ChunXing. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package codec
+
+import (
+	"encoding/binary"
+	"math"
+)
+
+// Codec key和value的二进制编解码，用于磁盘b+树的页、预写日志和快照
+type Codec[T any] interface {
+	Append(buf []byte, v T) []byte // 把v编码后追加到buf
+	Decode(buf []byte) (T, int)    // 从buf头部解码，返回读取的字节数，数据不完整时返回n<=0
+}
+
+// FixedSizer 定长的编码，返回编码后的字节数
+type FixedSizer interface {
+	Size() int
+}
+
+// IntCodec int按8字节小端编码
+type IntCodec struct{}
+
+func (IntCodec) Append(buf []byte, v int) []byte {
+	return binary.LittleEndian.AppendUint64(buf, uint64(v))
+}
+
+func (IntCodec) Decode(buf []byte) (int, int) {
+	if len(buf) < 8 {
+		return 0, 0
+	}
+	return int(binary.LittleEndian.Uint64(buf)), 8
+}
+
+func (IntCodec) Size() int {
+	return 8
+}
+
+// Int64Codec int64按8字节小端编码
+type Int64Codec struct{}
+
+func (Int64Codec) Append(buf []byte, v int64) []byte {
+	return binary.LittleEndian.AppendUint64(buf, uint64(v))
+}
+
+func (Int64Codec) Decode(buf []byte) (int64, int) {
+	if len(buf) < 8 {
+		return 0, 0
+	}
+	return int64(binary.LittleEndian.Uint64(buf)), 8
+}
+
+func (Int64Codec) Size() int {
+	return 8
+}
+
+// Uint64Codec uint64按8字节小端编码
+type Uint64Codec struct{}
+
+func (Uint64Codec) Append(buf []byte, v uint64) []byte {
+	return binary.LittleEndian.AppendUint64(buf, v)
+}
+
+func (Uint64Codec) Decode(buf []byte) (uint64, int) {
+	if len(buf) < 8 {
+		return 0, 0
+	}
+	return binary.LittleEndian.Uint64(buf), 8
+}
+
+func (Uint64Codec) Size() int {
+	return 8
+}
+
+// Float64Codec float64按IEEE 754的8字节小端编码
+type Float64Codec struct{}
+
+func (Float64Codec) Append(buf []byte, v float64) []byte {
+	return binary.LittleEndian.AppendUint64(buf, math.Float64bits(v))
+}
+
+func (Float64Codec) Decode(buf []byte) (float64, int) {
+	if len(buf) < 8 {
+		return 0, 0
+	}
+	return math.Float64frombits(binary.LittleEndian.Uint64(buf)), 8
+}
+
+func (Float64Codec) Size() int {
+	return 8
+}
+
+// StringCodec 变长编码，uvarint长度+内容
+type StringCodec struct{}
+
+func (StringCodec) Append(buf []byte, v string) []byte {
+	buf = binary.AppendUvarint(buf, uint64(len(v)))
+	return append(buf, v...)
+}
+
+func (StringCodec) Decode(buf []byte) (string, int) {
+	l, n := binary.Uvarint(buf)
+	if n <= 0 || uint64(len(buf)-n) < l {
+		return "", 0
+	}
+	return string(buf[n : n+int(l)]), n + int(l)
+}
+
+// BytesCodec 变长编码，uvarint长度+内容，解码时会拷贝
+type BytesCodec struct{}
+
+func (BytesCodec) Append(buf []byte, v []byte) []byte {
+	buf = binary.AppendUvarint(buf, uint64(len(v)))
+	return append(buf, v...)
+}
+
+func (BytesCodec) Decode(buf []byte) ([]byte, int) {
+	l, n := binary.Uvarint(buf)
+	if n <= 0 || uint64(len(buf)-n) < l {
+		return nil, 0
+	}
+	return append([]byte(nil), buf[n:n+int(l)]...), n + int(l)
+}
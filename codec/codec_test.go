@@ -0,0 +1,37 @@
+// Copyright (c) 2025 Tian ChunXing. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package codec
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/mrtcx/plusdata/internal/assert"
+)
+
+func TestCodec(t *testing.T) {
+	buf := IntCodec{}.Append(nil, -42)
+	buf = Float64Codec{}.Append(buf, 3.5)
+	buf = StringCodec{}.Append(buf, "hello")
+	buf = BytesCodec{}.Append(buf, []byte{1, 2, 3})
+
+	i, n := IntCodec{}.Decode(buf)
+	assert.Equal(t, i, -42)
+	buf = buf[n:]
+	f, n := Float64Codec{}.Decode(buf)
+	assert.Equal(t, f, 3.5)
+	buf = buf[n:]
+	s, n := StringCodec{}.Decode(buf)
+	assert.Equal(t, s, "hello")
+	buf = buf[n:]
+	b, n := BytesCodec{}.Decode(buf)
+	assert.Equal(t, fmt.Sprint(b), "[1 2 3]")
+	assert.Equal(t, n, len(buf))
+
+	_, n = StringCodec{}.Decode([]byte{5, 'a'})
+	assert.Equal(t, n <= 0, true)
+	_, n = IntCodec{}.Decode([]byte{1, 2})
+	assert.Equal(t, n <= 0, true)
+}
@@ -0,0 +1,234 @@
+// Copyright (c) 2025 Tian ChunXing. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package codec
+
+import (
+	"bufio"
+	"encoding/binary"
+	"errors"
+	"hash"
+	"hash/crc32"
+	"io"
+)
+
+var (
+	ErrNoCodec  = errors.New("codec: no codec for element type, use SetCodec")
+	ErrFormat   = errors.New("codec: invalid header or element")
+	ErrChecksum = errors.New("codec: checksum mismatch")
+)
+
+// Kind 容器的种类，记录在头部，同一种类的容器之间可以互相解码(如红黑树编码后解码到b+树)
+type Kind byte
+
+const (
+	KindArrary Kind = iota + 1
+	KindDeque
+	KindHeap
+	KindList
+	KindTree
+)
+
+const (
+	_magic   = "PLUSDATA"
+	_version = 1
+)
+
+var crcTable = crc32.MakeTable(crc32.Castagnoli)
+
+// Encoder 容器的流式编码，元素逐个编码后直接写入w，不需要在内存中生成完整的编码
+//
+//	magic | version | kind | n(uvarint) | (len(uvarint) | 元素) * n | crc32c(uint32)
+//
+// 树的每个元素编码为key和value两项
+type Encoder struct {
+	w   *bufio.Writer
+	crc hash.Hash32
+	buf []byte
+	err error
+}
+
+func NewEncoder(w io.Writer, kind Kind, n int) *Encoder {
+	e := &Encoder{crc: crc32.New(crcTable)}
+	e.w = bufio.NewWriter(io.MultiWriter(w, e.crc))
+	header := append([]byte(_magic), _version, byte(kind))
+	header = binary.AppendUvarint(header, uint64(n))
+	_, e.err = e.w.Write(header)
+	return e
+}
+
+// Write 编码一项，出错后记录在Encoder中，Close时返回
+func Write[T any](e *Encoder, c Codec[T], v T) {
+	if e.err != nil {
+		return
+	}
+	e.buf = c.Append(e.buf[:0], v)
+	var l [binary.MaxVarintLen64]byte
+	if _, e.err = e.w.Write(l[:binary.PutUvarint(l[:], uint64(len(e.buf)))]); e.err != nil {
+		return
+	}
+	_, e.err = e.w.Write(e.buf)
+}
+
+// Close 写入校验和，不会关闭w
+func (e *Encoder) Close() error {
+	if e.err != nil {
+		return e.err
+	}
+	if e.err = e.w.Flush(); e.err != nil {
+		return e.err
+	}
+	_, e.err = e.w.Write(binary.LittleEndian.AppendUint32(nil, e.crc.Sum32()))
+	if e.err == nil {
+		e.err = e.w.Flush()
+	}
+	return e.err
+}
+
+type Decoder struct {
+	r   *bufio.Reader
+	crc hash.Hash32
+	buf []byte
+}
+
+// NewDecoder 读取并校验头部，返回容器的元素个数
+// Decoder内部有缓冲，可能从r中多读取数据
+func NewDecoder(r io.Reader, kind Kind) (*Decoder, int, error) {
+	d := &Decoder{r: bufio.NewReader(r), crc: crc32.New(crcTable)}
+	header := make([]byte, len(_magic)+2)
+	if err := d.read(header); err != nil {
+		return nil, 0, err
+	}
+	if string(header[:len(_magic)]) != _magic || header[len(_magic)] != _version || Kind(header[len(_magic)+1]) != kind {
+		return nil, 0, ErrFormat
+	}
+	n, err := d.uvarint()
+	if err != nil || n > uint64(^uint(0)>>1) {
+		return nil, 0, ErrFormat
+	}
+	return d, int(n), nil
+}
+
+func (d *Decoder) read(buf []byte) error {
+	if _, err := io.ReadFull(d.r, buf); err == io.EOF || err == io.ErrUnexpectedEOF {
+		return ErrFormat
+	} else if err != nil {
+		return err
+	}
+	d.crc.Write(buf)
+	return nil
+}
+
+func (d *Decoder) uvarint() (uint64, error) {
+	var l [binary.MaxVarintLen64]byte
+	for i := range l {
+		if err := d.read(l[i : i+1]); err != nil {
+			return 0, err
+		}
+		if l[i] < 0x80 {
+			v, n := binary.Uvarint(l[:i+1])
+			if n <= 0 {
+				return 0, ErrFormat
+			}
+			return v, nil
+		}
+	}
+	return 0, ErrFormat
+}
+
+// Read 解码一项
+func Read[T any](d *Decoder, c Codec[T]) (T, error) {
+	var zero T
+	l, err := d.uvarint()
+	if err != nil {
+		return zero, err
+	}
+	// 长度可能被破坏，不能直接按长度分配，逐步扩大
+	if l > 1<<20 && l > uint64(cap(d.buf)) {
+		d.buf = d.buf[:0]
+		if _, err := io.CopyN(sliceWriter{&d.buf}, d.r, int64(l)); err != nil {
+			return zero, ErrFormat
+		}
+		d.crc.Write(d.buf)
+	} else {
+		if uint64(cap(d.buf)) < l {
+			d.buf = make([]byte, l)
+		}
+		d.buf = d.buf[:l]
+		if err := d.read(d.buf); err != nil {
+			return zero, err
+		}
+	}
+	// 长度为0的项也按解码失败处理(n<=0)
+	v, n := c.Decode(d.buf)
+	if n <= 0 || n != len(d.buf) {
+		return zero, ErrFormat
+	}
+	return v, nil
+}
+
+type sliceWriter struct {
+	buf *[]byte
+}
+
+func (w sliceWriter) Write(p []byte) (int, error) {
+	*w.buf = append(*w.buf, p...)
+	return len(p), nil
+}
+
+// Close 读取并比较校验和
+func (d *Decoder) Close() error {
+	sum := d.crc.Sum32()
+	var buf [4]byte
+	if _, err := io.ReadFull(d.r, buf[:]); err != nil {
+		return ErrFormat
+	}
+	if binary.LittleEndian.Uint32(buf[:]) != sum {
+		return ErrChecksum
+	}
+	return nil
+}
+
+// Resolve c不为nil时返回c，否则返回T的内置codec
+func Resolve[T any](c Codec[T]) (Codec[T], error) {
+	if c != nil {
+		return c, nil
+	}
+	var zero T
+	var ret interface{}
+	switch any(zero).(type) {
+	case int:
+		ret = IntCodec{}
+	case int64:
+		ret = Int64Codec{}
+	case uint64:
+		ret = Uint64Codec{}
+	case float64:
+		ret = Float64Codec{}
+	case string:
+		ret = StringCodec{}
+	case []byte:
+		ret = BytesCodec{}
+	default:
+		return nil, ErrNoCodec
+	}
+	return ret.(Codec[T]), nil
+}
+
+// Any 把Codec[T]转换为Codec[interface{}]，用于元素为interface{}的堆和链表，编码时元素必须是T类型
+func Any[T any](c Codec[T]) Codec[interface{}] {
+	return anyCodec[T]{c: c}
+}
+
+type anyCodec[T any] struct {
+	c Codec[T]
+}
+
+func (a anyCodec[T]) Append(buf []byte, v interface{}) []byte {
+	return a.c.Append(buf, v.(T))
+}
+
+func (a anyCodec[T]) Decode(buf []byte) (interface{}, int) {
+	return a.c.Decode(buf)
+}
@@ -0,0 +1,103 @@
+// Copyright (c) 2025 Tian ChunXing. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package codec
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/mrtcx/plusdata/internal/assert"
+)
+
+func encodeStrings(t *testing.T, vals []string) []byte {
+	var buf bytes.Buffer
+	enc := NewEncoder(&buf, KindArrary, len(vals))
+	for _, v := range vals {
+		Write[string](enc, StringCodec{}, v)
+	}
+	assert.Equal(t, enc.Close(), nil)
+	return buf.Bytes()
+}
+
+func decodeStrings(data []byte, kind Kind) ([]string, error) {
+	dec, n, err := NewDecoder(bytes.NewReader(data), kind)
+	if err != nil {
+		return nil, err
+	}
+	vals := []string{}
+	for i := 0; i < n; i++ {
+		v, err := Read[string](dec, StringCodec{})
+		if err != nil {
+			return nil, err
+		}
+		vals = append(vals, v)
+	}
+	return vals, dec.Close()
+}
+
+func TestStream(t *testing.T) {
+	vals := []string{"", "a", strings.Repeat("b", 1000), "中文"}
+	data := encodeStrings(t, vals)
+	got, err := decodeStrings(data, KindArrary)
+	assert.Equal(t, err, nil)
+	assert.Equal(t, strings.Join(got, ","), strings.Join(vals, ","))
+
+	_, err = decodeStrings(data, KindTree)
+	assert.Equal(t, err, ErrFormat)
+
+	// 任意位置截断都返回错误
+	for i := 0; i < len(data); i++ {
+		_, err := decodeStrings(data[:i], KindArrary)
+		assert.NotEqual(t, err, nil)
+	}
+	// 修改元素内容时校验失败
+	bad := append([]byte(nil), data...)
+	bad[len(bad)-6] ^= 1
+	_, err = decodeStrings(bad, KindArrary)
+	assert.Equal(t, err, ErrChecksum)
+}
+
+func TestResolve(t *testing.T) {
+	c, err := Resolve[int](nil)
+	assert.Equal(t, err, nil)
+	assert.Equal(t, c, Codec[int](IntCodec{}))
+	_, err = Resolve[[]byte](nil)
+	assert.Equal(t, err, nil)
+	_, err = Resolve[interface{}](nil)
+	assert.Equal(t, err, ErrNoCodec)
+	_, err = Resolve[struct{}](nil)
+	assert.Equal(t, err, ErrNoCodec)
+
+	ac := Any[string](StringCodec{})
+	buf := ac.Append(nil, "x")
+	v, n := ac.Decode(buf)
+	assert.Equal(t, v, "x")
+	assert.Equal(t, n, len(buf))
+}
+
+// 长度为0的项不是合法的编码，解码时返回ErrFormat
+func TestStreamEmptyRecord(t *testing.T) {
+	var buf bytes.Buffer
+	enc := NewEncoder(&buf, KindArrary, 1)
+	Write[[]byte](enc, rawCodec{}, nil)
+	assert.Equal(t, enc.Close(), nil)
+	dec, n, err := NewDecoder(bytes.NewReader(buf.Bytes()), KindArrary)
+	assert.Equal(t, err, nil)
+	assert.Equal(t, n, 1)
+	_, err = Read[int](dec, IntCodec{})
+	assert.Equal(t, err, ErrFormat)
+}
+
+// 原样写入的codec，用于构造长度为0的项
+type rawCodec struct{}
+
+func (rawCodec) Append(buf []byte, v []byte) []byte {
+	return append(buf, v...)
+}
+
+func (rawCodec) Decode(buf []byte) ([]byte, int) {
+	return buf, len(buf)
+}
@@ -0,0 +1,71 @@
+// Copyright (c) 2025 Tian ChunXing. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package circularblocks
+
+import (
+	"bytes"
+	"io"
+
+	"github.com/mrtcx/plusdata/codec"
+)
+
+// SetCodec 设置编解码元素的codec，int、int64、uint64、float64、string、[]byte可以不设置
+func (d *circularBlocks[T]) SetCodec(c codec.Codec[T]) {
+	d.codec = c
+}
+
+// Encode 按顺序把元素逐个编码写入w
+func (d *circularBlocks[T]) Encode(w io.Writer) error {
+	c, err := codec.Resolve(d.codec)
+	if err != nil {
+		return err
+	}
+	enc := codec.NewEncoder(w, codec.KindDeque, d.Size())
+	for i := 0; i < d.Size(); i++ {
+		codec.Write(enc, c, d.Get(i))
+	}
+	return enc.Close()
+}
+
+// Decode 清空后从r中解码，出错时容器为空
+func (d *circularBlocks[T]) Decode(r io.Reader) error {
+	d.Clean()
+	if err := d.decode(r); err != nil {
+		d.Clean()
+		return err
+	}
+	return nil
+}
+
+func (d *circularBlocks[T]) decode(r io.Reader) error {
+	c, err := codec.Resolve(d.codec)
+	if err != nil {
+		return err
+	}
+	dec, n, err := codec.NewDecoder(r, codec.KindDeque)
+	if err != nil {
+		return err
+	}
+	for i := 0; i < n; i++ {
+		v, err := codec.Read(dec, c)
+		if err != nil {
+			return err
+		}
+		d.PushBack(v)
+	}
+	return dec.Close()
+}
+
+func (d *circularBlocks[T]) MarshalBinary() ([]byte, error) {
+	var buf bytes.Buffer
+	if err := d.Encode(&buf); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func (d *circularBlocks[T]) UnmarshalBinary(data []byte) error {
+	return d.Decode(bytes.NewReader(data))
+}
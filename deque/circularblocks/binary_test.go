@@ -0,0 +1,78 @@
+// Copyright (c) 2025 Tian ChunXing. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package circularblocks
+
+import (
+	"bytes"
+	"fmt"
+	"testing"
+
+	"github.com/mrtcx/plusdata/codec"
+	"github.com/mrtcx/plusdata/internal/assert"
+)
+
+func TestMarshalBinary(t *testing.T) {
+	for _, num := range []int{0, 1, _block, 2*_block + 1} {
+		tnum := num
+		t.Run(fmt.Sprintf("[num:%d]", tnum), func(t *testing.T) {
+			q := New[int]()
+			for i := 0; i < tnum; i++ {
+				if i%2 == 0 {
+					q.PushBack(i)
+				} else {
+					q.PushFront(i)
+				}
+			}
+			data, err := q.MarshalBinary()
+			assert.Equal(t, err, nil)
+			q2 := New[int]()
+			q2.PushBack(-1)
+			assert.Equal(t, q2.UnmarshalBinary(data), nil)
+			assert.Equal(t, q2.Size(), tnum)
+			for i := 0; i < tnum; i++ {
+				assert.Equal(t, q2.Get(i), q.Get(i))
+			}
+			if tnum > 0 {
+				assert.NotEqual(t, q2.UnmarshalBinary(data[:len(data)-1]), nil)
+				assert.Equal(t, q2.Size(), 0)
+			}
+		})
+	}
+}
+
+func TestEncodeCodec(t *testing.T) {
+	q := New[point]()
+	q.PushBack(point{1, 2})
+	q.PushBack(point{3, 4})
+	var buf bytes.Buffer
+	assert.Equal(t, q.Encode(&buf), codec.ErrNoCodec)
+
+	pc := pointCodec{}
+	q.SetCodec(pc)
+	buf.Reset()
+	assert.Equal(t, q.Encode(&buf), nil)
+	q2 := New[point]()
+	q2.SetCodec(pc)
+	assert.Equal(t, q2.Decode(&buf), nil)
+	assert.Equal(t, q2.Size(), 2)
+	assert.Equal(t, q2.Get(1).y, 4)
+}
+
+type point struct{ x, y int }
+
+type pointCodec struct{}
+
+func (pointCodec) Append(buf []byte, p point) []byte {
+	return codec.IntCodec{}.Append(codec.IntCodec{}.Append(buf, p.x), p.y)
+}
+
+func (pointCodec) Decode(buf []byte) (p point, n int) {
+	if len(buf) < 16 {
+		return p, 0
+	}
+	p.x, _ = codec.IntCodec{}.Decode(buf)
+	p.y, _ = codec.IntCodec{}.Decode(buf[8:])
+	return p, 16
+}
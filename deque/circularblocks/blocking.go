@@ -0,0 +1,176 @@
+// Copyright (c) 2025 Tian ChunXing. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package circularblocks
+
+import (
+	"context"
+	"errors"
+	"sync"
+)
+
+var ErrClosed = errors.New("circularblocks: deque is closed")
+
+// BlockingDeque 有界的阻塞队列，在尾部放入、头部取出，可以在多个协程间使用
+// 队列满时PushBack阻塞，队列空时PopFront阻塞，阻塞可以通过ctx取消
+type BlockingDeque[T any] struct {
+	mu       sync.Mutex
+	d        *circularBlocks[T]
+	capacity int
+	closed   bool
+	// 等待者取到的通知channel，状态变化时关闭以唤醒所有等待者，没有等待者时为nil
+	notFull  chan struct{}
+	notEmpty chan struct{}
+}
+
+// NewBlockingDeque capacity<=0时不限制容量，PushBack不会阻塞
+func NewBlockingDeque[T any](capacity int) *BlockingDeque[T] {
+	return &BlockingDeque[T]{d: New[T](), capacity: capacity}
+}
+
+func (b *BlockingDeque[T]) Size() int {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.d.Size()
+}
+
+func (b *BlockingDeque[T]) Capacity() int {
+	return b.capacity
+}
+
+// Close 关闭后PushBack返回ErrClosed，PopFront继续取出剩余的元素，取完后返回ErrClosed
+// 阻塞中的调用都会被唤醒，重复调用Close没有影响
+func (b *BlockingDeque[T]) Close() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.closed = true
+	broadcast(&b.notFull)
+	broadcast(&b.notEmpty)
+}
+
+func (b *BlockingDeque[T]) Closed() bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.closed
+}
+
+// PushBack 队列满时阻塞到有空位、ctx结束或队列关闭
+func (b *BlockingDeque[T]) PushBack(ctx context.Context, val T) error {
+	for {
+		b.mu.Lock()
+		if b.closed {
+			b.mu.Unlock()
+			return ErrClosed
+		}
+		if !b.full() {
+			b.push(val)
+			b.mu.Unlock()
+			return nil
+		}
+		ch := wait(&b.notFull)
+		b.mu.Unlock()
+		select {
+		case <-ch:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+// TryPush 不阻塞，队列满或已关闭时返回false
+func (b *BlockingDeque[T]) TryPush(val T) bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.closed || b.full() {
+		return false
+	}
+	b.push(val)
+	return true
+}
+
+// PopFront 队列空时阻塞到有元素、ctx结束或队列关闭
+func (b *BlockingDeque[T]) PopFront(ctx context.Context) (T, error) {
+	vals, err := b.popN(ctx, 1)
+	if err != nil {
+		var zero T
+		return zero, err
+	}
+	return vals[0], nil
+}
+
+// TryPop 不阻塞，队列空时返回false，关闭后仍可以取出剩余的元素
+func (b *BlockingDeque[T]) TryPop() (T, bool) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.d.Empty() {
+		var zero T
+		return zero, false
+	}
+	return b.pop(1)[0], true
+}
+
+// PopN 阻塞到至少有一个元素，然后取出最多n个元素，不会等待凑满n个
+func (b *BlockingDeque[T]) PopN(ctx context.Context, n int) ([]T, error) {
+	if n <= 0 {
+		return nil, nil
+	}
+	return b.popN(ctx, n)
+}
+
+func (b *BlockingDeque[T]) popN(ctx context.Context, n int) ([]T, error) {
+	for {
+		b.mu.Lock()
+		if !b.d.Empty() {
+			vals := b.pop(n)
+			b.mu.Unlock()
+			return vals, nil
+		}
+		if b.closed {
+			b.mu.Unlock()
+			return nil, ErrClosed
+		}
+		ch := wait(&b.notEmpty)
+		b.mu.Unlock()
+		select {
+		case <-ch:
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+}
+
+func (b *BlockingDeque[T]) full() bool {
+	return b.capacity > 0 && b.d.Size() >= b.capacity
+}
+
+func (b *BlockingDeque[T]) push(val T) {
+	b.d.PushBack(val)
+	broadcast(&b.notEmpty)
+}
+
+func (b *BlockingDeque[T]) pop(n int) []T {
+	if n > b.d.Size() {
+		n = b.d.Size()
+	}
+	vals := make([]T, n)
+	for i := range vals {
+		vals[i] = b.d.PopFront()
+	}
+	broadcast(&b.notFull)
+	return vals
+}
+
+func wait(ch *chan struct{}) chan struct{} {
+	if *ch == nil {
+		*ch = make(chan struct{})
+	}
+	return *ch
+}
+
+func broadcast(ch *chan struct{}) {
+	if *ch != nil {
+		close(*ch)
+		*ch = nil
+	}
+}
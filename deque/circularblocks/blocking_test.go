@@ -0,0 +1,137 @@
+// Copyright (c) 2025 Tian ChunXing. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package circularblocks
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/mrtcx/plusdata/internal/assert"
+)
+
+func TestBlockingTry(t *testing.T) {
+	b := NewBlockingDeque[int](3)
+	assert.Equal(t, b.Capacity(), 3)
+	_, ok := b.TryPop()
+	assert.Equal(t, ok, false)
+	for i := 0; i < 3; i++ {
+		assert.Equal(t, b.TryPush(i), true)
+	}
+	assert.Equal(t, b.TryPush(3), false)
+	assert.Equal(t, b.Size(), 3)
+	v, ok := b.TryPop()
+	assert.Equal(t, ok, true)
+	assert.Equal(t, v, 0)
+	assert.Equal(t, b.TryPush(3), true)
+
+	vals, err := b.PopN(context.Background(), 2)
+	assert.Equal(t, err, nil)
+	assert.Equal(t, len(vals), 2)
+	assert.Equal(t, vals[0], 1)
+	vals, err = b.PopN(context.Background(), 10)
+	assert.Equal(t, err, nil)
+	assert.Equal(t, len(vals), 1)
+	assert.Equal(t, vals[0], 3)
+}
+
+func TestBlockingCancel(t *testing.T) {
+	b := NewBlockingDeque[int](1)
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	_, err := b.PopFront(ctx)
+	assert.Equal(t, err, context.DeadlineExceeded)
+
+	assert.Equal(t, b.PushBack(context.Background(), 1), nil)
+	ctx, cancel = context.WithCancel(context.Background())
+	go func() {
+		time.Sleep(10 * time.Millisecond)
+		cancel()
+	}()
+	assert.Equal(t, b.PushBack(ctx, 2), context.Canceled)
+	assert.Equal(t, b.Size(), 1)
+}
+
+func TestBlockingClose(t *testing.T) {
+	b := NewBlockingDeque[int](0)
+	done := make(chan error)
+	go func() {
+		_, err := b.PopFront(context.Background())
+		done <- err
+	}()
+	time.Sleep(10 * time.Millisecond)
+	b.Close()
+	assert.Equal(t, <-done, ErrClosed)
+
+	// 关闭后取完剩余元素再返回ErrClosed
+	b = NewBlockingDeque[int](0)
+	for i := 0; i < 5; i++ {
+		b.PushBack(context.Background(), i)
+	}
+	b.Close()
+	b.Close()
+	assert.Equal(t, b.Closed(), true)
+	assert.Equal(t, b.PushBack(context.Background(), 5), ErrClosed)
+	assert.Equal(t, b.TryPush(5), false)
+	for i := 0; i < 5; i++ {
+		v, err := b.PopFront(context.Background())
+		assert.Equal(t, err, nil)
+		assert.Equal(t, v, i)
+	}
+	_, err := b.PopFront(context.Background())
+	assert.Equal(t, err, ErrClosed)
+	_, err = b.PopN(context.Background(), 3)
+	assert.Equal(t, err, ErrClosed)
+}
+
+func TestBlockingProducerConsumer(t *testing.T) {
+	b := NewBlockingDeque[int](8)
+	const producers, consumers, num = 4, 4, 5000
+	var wg sync.WaitGroup
+	for p := 0; p < producers; p++ {
+		wg.Add(1)
+		go func(p int) {
+			defer wg.Done()
+			for i := 0; i < num; i++ {
+				assert.Equal(t, b.PushBack(context.Background(), p*num+i), nil)
+			}
+		}(p)
+	}
+	var mu sync.Mutex
+	seen := make([]bool, producers*num)
+	var cwg sync.WaitGroup
+	for c := 0; c < consumers; c++ {
+		cwg.Add(1)
+		go func(c int) {
+			defer cwg.Done()
+			last := make([]int, producers) // 同一个生产者的元素按顺序取出
+			for i := range last {
+				last[i] = -1
+			}
+			for {
+				vals, err := b.PopN(context.Background(), c+1)
+				if err == ErrClosed {
+					return
+				}
+				assert.Greater(t, b.Capacity()+1, b.Size())
+				mu.Lock()
+				for _, v := range vals {
+					assert.Equal(t, seen[v], false)
+					seen[v] = true
+					assert.Greater(t, v, last[v/num])
+					last[v/num] = v
+				}
+				mu.Unlock()
+			}
+		}(c)
+	}
+	wg.Wait()
+	b.Close()
+	cwg.Wait()
+	for _, ok := range seen {
+		assert.Equal(t, ok, true)
+	}
+}
@@ -5,89 +5,98 @@
 package circularblocks
 
 import (
+	"math"
+
+	"github.com/mrtcx/plusdata/codec"
 	"github.com/mrtcx/plusdata/deque"
+	"github.com/mrtcx/plusdata/internal/blocksize"
 	"github.com/mrtcx/plusdata/internal/circularbuffer"
 )
 
-var _ deque.Deque = (*circularBlocks)(nil)
+var _ deque.Deque[int] = (*circularBlocks[int])(nil)
 
-const (
-	_initBlockCap = 4
-	_block        = 1024 * 32 / 8 / 2 //L1-cache-32kb, interface{} - 8 * 2
-)
+const _initBlockCap = blocksize.MinCap
 
-type circularBlocks struct {
-	bbs  *circularbuffer.Buffer
-	size int
+type circularBlocks[T any] struct {
+	bbs   *circularbuffer.Buffer[*circularbuffer.Buffer[T]]
+	size  int
+	block int //块内元素个数, 为1<<shift
+	shift uint
+	codec codec.Codec[T]
 }
 
-func New() *circularBlocks {
-	return &circularBlocks{
-		bbs: circularbuffer.New(1),
+func New[T any]() *circularBlocks[T] {
+	shift := blocksize.Shift[T]()
+	return &circularBlocks[T]{
+		bbs:   circularbuffer.New[*circularbuffer.Buffer[T]](1),
+		block: 1 << shift,
+		shift: shift,
 	}
 }
 
-func (d *circularBlocks) Clean() {
-	d.bbs, d.size = circularbuffer.New(1), 0
+func (d *circularBlocks[T]) Clean() {
+	d.bbs, d.size = circularbuffer.New[*circularbuffer.Buffer[T]](1), 0
 }
 
-func (d *circularBlocks) Size() int {
+func (d *circularBlocks[T]) Size() int {
 	return d.size
 }
 
-func (d *circularBlocks) Empty() bool {
+func (d *circularBlocks[T]) Empty() bool {
 	return d.size == 0
 }
 
-func (d *circularBlocks) Front() interface{} {
+func (d *circularBlocks[T]) Front() T {
 	if d.size == 0 {
-		return nil
+		var zero T
+		return zero
 	}
-	return d.bbs.Front().(*circularbuffer.Buffer).Front()
+	return d.bbs.Front().Front()
 }
 
-func (d circularBlocks) Back() interface{} {
+func (d circularBlocks[T]) Back() T {
 	if d.size == 0 {
-		return nil
+		var zero T
+		return zero
 	}
-	return d.bbs.Back().(*circularbuffer.Buffer).Back()
+	return d.bbs.Back().Back()
 }
 
-func (d circularBlocks) Get(index int) interface{} {
-	frontblock := d.bbs.Front().(*circularbuffer.Buffer)
+func (d circularBlocks[T]) Get(index int) T {
+	frontblock := d.bbs.Front()
 	if index < frontblock.Size() {
 		return frontblock.Get(index)
 	}
 	index -= frontblock.Size()
-	return d.bbs.Get(1 + index/_block).(*circularbuffer.Buffer).Get(index % _block)
+	return d.bbs.Get(1 + index>>d.shift).Get(index & (d.block - 1))
 }
 
-func (d circularBlocks) Set(index int, val interface{}) {
-	frontblock := d.bbs.Front().(*circularbuffer.Buffer)
+func (d circularBlocks[T]) Set(index int, val T) {
+	frontblock := d.bbs.Front()
 	if index < frontblock.Size() {
 		frontblock.Set(index, val)
 		return
 	}
 	index -= frontblock.Size()
-	d.bbs.Get(1+index/_block).(*circularbuffer.Buffer).Set(index%_block, val)
+	d.bbs.Get(1+index>>d.shift).Set(index&(d.block-1), val)
 }
 
-func (d *circularBlocks) PushFront(val interface{}) {
-	var block *circularbuffer.Buffer
+func (d *circularBlocks[T]) PushFront(val T) {
+	var block *circularbuffer.Buffer[T]
 	if d.size == 0 {
-		d.bbs.PushFront(circularbuffer.New(_initBlockCap))
+		d.bbs.PushFront(circularbuffer.New[T](_initBlockCap))
 	}
-	block = d.bbs.Front().(*circularbuffer.Buffer)
+	block = d.bbs.Front()
 	if block.IsFull() {
-		if block.Size() == _block {
-			block = circularbuffer.New(_initBlockCap)
+		if block.Size() == d.block {
+			block = circularbuffer.New[T](_initBlockCap)
 			block.PushFront(val)
 			if d.bbs.IsFull() {
-				d.expandBlock(d.bbs)
+				expandBlock(d.bbs, math.MaxInt) //块索引只存指针, 不受块大小限制
 			}
 			d.bbs.PushFront(block)
 		} else {
-			d.expandBlock(block)
+			expandBlock(block, d.block)
 			block.PushFront(val)
 		}
 	} else {
@@ -96,22 +105,22 @@ func (d *circularBlocks) PushFront(val interface{}) {
 	d.size++
 }
 
-func (d *circularBlocks) PushBack(val interface{}) {
-	var cirbuf *circularbuffer.Buffer
+func (d *circularBlocks[T]) PushBack(val T) {
+	var cirbuf *circularbuffer.Buffer[T]
 	if d.size == 0 {
-		d.bbs.PushBack(circularbuffer.New(_initBlockCap))
+		d.bbs.PushBack(circularbuffer.New[T](_initBlockCap))
 	}
-	cirbuf = d.bbs.Back().(*circularbuffer.Buffer)
+	cirbuf = d.bbs.Back()
 	if cirbuf.IsFull() {
-		if cirbuf.Size() == _block {
-			cirbuf = circularbuffer.New(_initBlockCap)
+		if cirbuf.Size() == d.block {
+			cirbuf = circularbuffer.New[T](_initBlockCap)
 			cirbuf.PushBack(val)
 			if d.bbs.IsFull() {
-				d.expandBlock(d.bbs)
+				expandBlock(d.bbs, math.MaxInt) //块索引只存指针, 不受块大小限制
 			}
 			d.bbs.PushBack(cirbuf)
 		} else {
-			d.expandBlock(cirbuf)
+			expandBlock(cirbuf, d.block)
 			cirbuf.PushBack(val)
 		}
 	} else {
@@ -120,51 +129,53 @@ func (d *circularBlocks) PushBack(val interface{}) {
 	d.size++
 }
 
-func (d *circularBlocks) PopFront() interface{} {
+func (d *circularBlocks[T]) PopFront() T {
 	if d.size == 0 {
-		return nil
+		var zero T
+		return zero
 	}
-	frontblock := d.bbs.Front().(*circularbuffer.Buffer)
+	frontblock := d.bbs.Front()
 	val := frontblock.PopFront()
 	if frontblock.IsEmpty() {
 		d.bbs.PopFront()
-		d.shrinkBlock(d.bbs, 1)
+		shrinkBlock(d.bbs, 1)
 	} else {
-		d.shrinkBlock(frontblock, _initBlockCap)
+		shrinkBlock(frontblock, _initBlockCap)
 	}
 	d.size--
 	return val
 }
 
-func (d *circularBlocks) PopBack() interface{} {
+func (d *circularBlocks[T]) PopBack() T {
 	if d.size == 0 {
-		return nil
+		var zero T
+		return zero
 	}
-	backblock := d.bbs.Back().(*circularbuffer.Buffer)
+	backblock := d.bbs.Back()
 	val := backblock.PopBack()
 	if backblock.IsEmpty() {
 		d.bbs.PopBack()
-		d.shrinkBlock(d.bbs, 1)
+		shrinkBlock(d.bbs, 1)
 	} else {
-		d.shrinkBlock(backblock, _initBlockCap)
+		shrinkBlock(backblock, _initBlockCap)
 	}
 	d.size--
 	return val
 }
 
-func (d *circularBlocks) expandBlock(block *circularbuffer.Buffer) {
+func expandBlock[E any](block *circularbuffer.Buffer[E], maxCap int) {
 	addSize := block.Size()
 	if block.Size() >= 1024 {
 		addSize = block.Size() / 2
 	}
-	if addSize+block.Capacity() > _block {
-		block.ResetCapacity(_block)
+	if addSize+block.Capacity() > maxCap {
+		block.ResetCapacity(maxCap)
 		return
 	}
 	block.ResetCapacity(block.Capacity() + addSize)
 }
 
-func (d *circularBlocks) shrinkBlock(block *circularbuffer.Buffer, minCap int) {
+func shrinkBlock[E any](block *circularbuffer.Buffer[E], minCap int) {
 	if block.Capacity() > minCap && block.Size() < block.Capacity()/4 {
 		block.ResetCapacity(block.Capacity() / 4)
 	}
@@ -9,16 +9,18 @@ import (
 	"testing"
 
 	"github.com/mrtcx/plusdata/internal/assert"
-	"github.com/mrtcx/plusdata/internal/circularbuffer"
+	"github.com/mrtcx/plusdata/internal/blocksize"
 )
 
+var _block = 1 << blocksize.Shift[int]()
+
 func TestFront(t *testing.T) {
 	testNums := []int{1, 2, 3, 4, _block, _block + 1, 2 * _block, 2*_block + 1}
 	for _, v := range testNums {
 		testnum := v
 		t.Run(fmt.Sprintf("[num:%d]", testnum), func(t *testing.T) {
-			q := New()
-			assert.Equal(t, q.Front(), nil)
+			q := New[int]()
+			assert.Equal(t, q.Front(), 0)
 			for i := 0; i < testnum; i++ {
 				q.PushBack(i)
 			}
@@ -26,7 +28,7 @@ func TestFront(t *testing.T) {
 			for i := 0; i < testnum; i++ {
 				q.PopBack()
 			}
-			assert.Equal(t, q.Front(), nil)
+			assert.Equal(t, q.Front(), 0)
 			for i := 0; i < testnum; i++ {
 				q.PushFront(i)
 			}
@@ -34,7 +36,7 @@ func TestFront(t *testing.T) {
 			for i := 0; i < testnum; i++ {
 				q.PopFront()
 			}
-			assert.Equal(t, q.Front(), nil)
+			assert.Equal(t, q.Front(), 0)
 		})
 	}
 }
@@ -44,8 +46,8 @@ func TestBack(t *testing.T) {
 	for _, v := range testNums {
 		testnum := v
 		t.Run(fmt.Sprintf("[num:%d]", testnum), func(t *testing.T) {
-			q := New()
-			assert.Equal(t, q.Front(), nil)
+			q := New[int]()
+			assert.Equal(t, q.Front(), 0)
 			for i := 0; i < testnum; i++ {
 				q.PushBack(i)
 			}
@@ -53,7 +55,7 @@ func TestBack(t *testing.T) {
 			for i := 0; i < testnum; i++ {
 				q.PopBack()
 			}
-			assert.Equal(t, q.Back(), nil)
+			assert.Equal(t, q.Back(), 0)
 			for i := 0; i < testnum; i++ {
 				q.PushFront(i)
 			}
@@ -61,7 +63,7 @@ func TestBack(t *testing.T) {
 			for i := 0; i < testnum; i++ {
 				q.PopFront()
 			}
-			assert.Equal(t, q.Back(), nil)
+			assert.Equal(t, q.Back(), 0)
 		})
 	}
 }
@@ -71,7 +73,7 @@ func TestGet(t *testing.T) {
 	for _, v := range testNums {
 		testnum := v
 		t.Run(fmt.Sprintf("[num:%d]", testnum), func(t *testing.T) {
-			q := New()
+			q := New[int]()
 			for i := 0; i < testnum; i++ {
 				q.PushBack(i)
 			}
@@ -101,7 +103,7 @@ func TestSet(t *testing.T) {
 	for _, v := range testNums {
 		testnum := v
 		t.Run(fmt.Sprintf("[num:%d]", testnum), func(t *testing.T) {
-			q := New()
+			q := New[int]()
 			for i := 0; i < testnum; i++ {
 				q.PushBack(i)
 			}
@@ -130,7 +132,7 @@ func TestPushPop(t *testing.T) {
 	for _, v := range testNum {
 		num := v
 		t.Run(fmt.Sprintf("[num:%d]", num), func(t *testing.T) {
-			q := New()
+			q := New[int]()
 			testPushFrontPopFront(t, q, num)
 			testPushBackPopBack(t, q, num)
 			testPushFrontPopBack(t, q, num)
@@ -140,18 +142,18 @@ func TestPushPop(t *testing.T) {
 }
 
 func TestClean(t *testing.T) {
-	q := New()
+	q := New[int]()
 	q.PushBack(1)
 	q.Clean()
 	assert.Equal(t, q.Size(), 0)
 	assert.Equal(t, q.Empty(), true)
-	assert.Equal(t, q.Front(), nil)
-	assert.Equal(t, q.Back(), nil)
-	assert.Equal(t, q.PopBack(), nil)
-	assert.Equal(t, q.PopFront(), nil)
+	assert.Equal(t, q.Front(), 0)
+	assert.Equal(t, q.Back(), 0)
+	assert.Equal(t, q.PopBack(), 0)
+	assert.Equal(t, q.PopFront(), 0)
 }
 
-func testPushFrontPopFront(t *testing.T, q *circularBlocks, num int) {
+func testPushFrontPopFront(t *testing.T, q *circularBlocks[int], num int) {
 	pushFull := num
 	for i := 1; i <= pushFull; i++ {
 		q.PushFront(i)
@@ -169,12 +171,12 @@ func testPushFrontPopFront(t *testing.T, q *circularBlocks, num int) {
 		assert.Equal(t, checkBlockCap(t, q), true)
 	}
 	assert.Equal(t, q.Size(), 0)
-	if q.PopBack() != nil || q.PopFront() != nil || q.Back() != nil || q.Front() != nil {
-		t.Errorf("expected nil")
+	if q.PopBack() != 0 || q.PopFront() != 0 || q.Back() != 0 || q.Front() != 0 {
+		t.Errorf("expected zero value")
 	}
 }
 
-func testPushBackPopBack(t *testing.T, q *circularBlocks, num int) {
+func testPushBackPopBack(t *testing.T, q *circularBlocks[int], num int) {
 	pushFull := num
 	for i := 1; i <= pushFull; i++ {
 		q.PushBack(i)
@@ -192,12 +194,12 @@ func testPushBackPopBack(t *testing.T, q *circularBlocks, num int) {
 		assert.Equal(t, q.Size(), i-1)
 		assert.Equal(t, checkBlockCap(t, q), true)
 	}
-	if q.PopBack() != nil || q.PopFront() != nil || q.Front() != nil || q.Back() != nil {
-		t.Errorf("expected nil")
+	if q.PopBack() != 0 || q.PopFront() != 0 || q.Front() != 0 || q.Back() != 0 {
+		t.Errorf("expected zero value")
 	}
 }
 
-func testPushFrontPopBack(t *testing.T, q *circularBlocks, num int) {
+func testPushFrontPopBack(t *testing.T, q *circularBlocks[int], num int) {
 	for times := 1; times <= 2; times++ {
 		pushFull := num
 		for i := 1; i <= pushFull; i++ {
@@ -208,13 +210,13 @@ func testPushFrontPopBack(t *testing.T, q *circularBlocks, num int) {
 			assert.Equal(t, q.PopBack(), i)
 			assert.Equal(t, checkBlockCap(t, q), true)
 		}
-		if q.PopBack() != nil || q.PopFront() != nil || q.Front() != nil || q.Back() != nil {
-			t.Errorf("expected nil")
+		if q.PopBack() != 0 || q.PopFront() != 0 || q.Front() != 0 || q.Back() != 0 {
+			t.Errorf("expected zero value")
 		}
 	}
 }
 
-func testPushBackPopFront(t *testing.T, q *circularBlocks, num int) {
+func testPushBackPopFront(t *testing.T, q *circularBlocks[int], num int) {
 	for times := 1; times <= 2; times++ {
 		pushFull := num
 		for i := 1; i <= pushFull; i++ {
@@ -225,18 +227,52 @@ func testPushBackPopFront(t *testing.T, q *circularBlocks, num int) {
 			assert.Equal(t, q.PopFront(), i)
 			assert.Equal(t, checkBlockCap(t, q), true)
 		}
-		if q.PopBack() != nil || q.PopFront() != nil || q.Front() != nil || q.Back() != nil {
-			t.Errorf("expected nil")
+		if q.PopBack() != 0 || q.PopFront() != 0 || q.Front() != 0 || q.Back() != 0 {
+			t.Errorf("expected zero value")
 		}
 	}
 }
 
-func checkBlockCap(t *testing.T, b *circularBlocks) bool {
+func TestInterfaceValue(t *testing.T) {
+	q := New[interface{}]()
+	q.PushBack(1)
+	q.PushFront("0")
+	assert.Equal(t, q.Front(), "0")
+	assert.Equal(t, q.Back(), 1)
+	q.PopBack()
+	q.PopFront()
+	assert.Equal(t, q.Front(), nil)
+}
+
+func TestLargeElement(t *testing.T) {
+	type large struct {
+		v int
+		_ [1020]byte
+	}
+	q := New[large]()
+	assert.Equal(t, q.block, 16)
+	num := 100 * q.block
+	for i := 1; i <= num; i++ {
+		q.PushBack(large{v: i})
+		q.PushFront(large{v: -i})
+	}
+	for i := 0; i < num; i++ {
+		assert.Equal(t, q.Get(i).v, i-num)
+		assert.Equal(t, q.Get(num+i).v, i+1)
+	}
+	for i := num; i >= 1; i-- {
+		assert.Equal(t, q.PopBack().v, i)
+		assert.Equal(t, q.PopFront().v, -i)
+	}
+	assert.Equal(t, q.Size(), 0)
+}
+
+func checkBlockCap(t *testing.T, b *circularBlocks[int]) bool {
 	if b.bbs.Capacity() > 1 && b.bbs.Capacity()/4 > b.bbs.Size() {
 		return false
 	}
 	for i := 0; i < b.bbs.Size(); i++ {
-		bu := b.bbs.Get(i).(*circularbuffer.Buffer)
+		bu := b.bbs.Get(i)
 		if bu.Capacity() > _initBlockCap && bu.Capacity()/4 > bu.Size() {
 			return false
 		}
@@ -253,7 +289,7 @@ func BenchmarkPushBack(b *testing.B) {
 		b.Run(fmt.Sprintf("slice[%s]", name), func(b *testing.B) {
 			b.ResetTimer()
 			for i := 0; i < b.N; i++ {
-				var arr []interface{}
+				var arr []int
 				for j := 0; j < size; j++ {
 					arr = append(arr, i)
 				}
@@ -262,7 +298,7 @@ func BenchmarkPushBack(b *testing.B) {
 		b.Run(fmt.Sprintf("circularblocks[%s]", name), func(b *testing.B) {
 			b.ResetTimer()
 			for i := 0; i < b.N; i++ {
-				arr := New()
+				arr := New[int]()
 				for j := 0; j < size; j++ {
 					arr.PushBack(j)
 				}
@@ -281,7 +317,7 @@ func BenchmarkPushFront(b *testing.B) {
 		b.Run(fmt.Sprintf("circularblocks[%s]", name), func(b *testing.B) {
 			b.ResetTimer()
 			for i := 0; i < b.N; i++ {
-				arr := New()
+				arr := New[int]()
 				for j := 0; j < size; j++ {
 					arr.PushFront(j)
 				}
@@ -297,7 +333,7 @@ func BenchmarkPopBack(b *testing.B) {
 		size := v
 		name := testNames[i]
 		b.Run(fmt.Sprintf("[%s]", name), func(b *testing.B) {
-			arr := New()
+			arr := New[int]()
 			for i := 1; i <= size; i++ {
 				arr.PushBack(i)
 			}
@@ -318,7 +354,7 @@ func BenchmarkPopFront(b *testing.B) {
 		size := v
 		name := testNames[i]
 		b.Run(fmt.Sprintf("[%s]", name), func(b *testing.B) {
-			arr := New()
+			arr := New[int]()
 			for i := 1; i <= size; i++ {
 				arr.PushBack(i)
 			}
@@ -339,7 +375,7 @@ func BenchmarkGet(b *testing.B) {
 		size := v
 		name := testNames[i]
 		b.Run(fmt.Sprintf("slice[%s]", name), func(b *testing.B) {
-			var arr []interface{}
+			var arr []int
 			for i := 0; i < size; i++ {
 				arr = append(arr, i)
 			}
@@ -351,7 +387,7 @@ func BenchmarkGet(b *testing.B) {
 			}
 		})
 		b.Run(fmt.Sprintf("circularblocks[%s]", name), func(b *testing.B) {
-			var arr = New()
+			var arr = New[int]()
 			for i := 0; i < size; i++ {
 				arr.PushBack(i)
 			}
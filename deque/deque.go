@@ -4,16 +4,16 @@
 
 package deque
 
-type Deque interface {
+type Deque[T any] interface {
 	Size() int
 	Empty() bool
 	Clean()
-	Get(int) interface{}
-	Set(int, interface{})
-	Front() interface{}
-	Back() interface{}
-	PushBack(interface{})
-	PopBack() interface{}
-	PushFront(value interface{})
-	PopFront() interface{}
+	Get(int) T
+	Set(int, T)
+	Front() T
+	Back() T
+	PushBack(T)
+	PopBack() T
+	PushFront(value T)
+	PopFront() T
 }
@@ -10,15 +10,15 @@ import (
 )
 
 func main() {
-	var arr arrary.Arrary = blockslices.New()
-	arr.PushBack(1)       //[1]
-	arr.PushBack(2)       //[1,2]
-	arr.Size()            //2
-	_ = arr.Front().(int) //1
-	_ = arr.Back().(int)  //2
+	var arr arrary.Arrary[int] = blockslices.New[int]()
+	arr.PushBack(1) //[1]
+	arr.PushBack(2) //[1,2]
+	arr.Size()      //2
+	_ = arr.Front() //1
+	_ = arr.Back()  //2
 
 	for i := 0; i < arr.Size(); i++ {
-		_ = arr.Get(i).(int) //i
+		_ = arr.Get(i) //i
 	}
 	for i := 0; i < arr.Size(); i++ {
 		arr.Set(i, i*-1)
@@ -27,8 +27,8 @@ func main() {
 	arr.PopBack() //[-1]
 	arr.PopBack() //[]
 	arr.Size()    //0
-	arr.Front()   //nil
-	arr.Back()    //nil
+	arr.Front()   //0
+	arr.Back()    //0
 
 	arr.PushBack(1) //[1]
 	arr.Clean()     //[]
@@ -10,17 +10,17 @@ import (
 )
 
 func main() {
-	var q deque.Deque = circularblocks.New()
-	q.PushBack(3)       //[3]
-	q.PushBack(4)       //[3,4]
-	q.PushFront(2)      //[2,3,4]
-	q.PushFront(1)      //[1,2,3,4]
-	q.Size()            //4
-	_ = q.Front().(int) //1
-	_ = q.Back().(int)  //4
+	var q deque.Deque[int] = circularblocks.New[int]()
+	q.PushBack(3)  //[3]
+	q.PushBack(4)  //[3,4]
+	q.PushFront(2) //[2,3,4]
+	q.PushFront(1) //[1,2,3,4]
+	q.Size()       //4
+	_ = q.Front()  //1
+	_ = q.Back()   //4
 
 	for i := 0; i < q.Size(); i++ {
-		_ = q.Get(i).(int) //i
+		_ = q.Get(i) //i
 	}
 	for i := 0; i < q.Size(); i++ {
 		q.Set(i, i*-1)
@@ -31,8 +31,8 @@ func main() {
 	q.PopBack()  //[-2]
 	q.PopFront() //[]
 	q.Size()     //0
-	q.Front()    //nil
-	q.Back()     //nil
+	q.Front()    //0
+	q.Back()     //0
 
 	q.PushBack(1) //[1]
 	q.Clean()     //[]
@@ -27,15 +27,15 @@ func main() {
 	}
 
 	//排序树堆
-	heap = treeheap.New(rbtree.New(tree.IntComparator)) //使用红黑树
+	heap = treeheap.New(rbtree.New[interface{}, interface{}](tree.IntComparator)) //使用红黑树
 	heap.Push(4)
 	heap.Push(2)
 	heap.Push(2)
 	heap.Push(3)
 	heap.Push(1)
-	heap.Size() //4
+	heap.Size() //5
 	for !heap.Empty() {
-		heap.Top() //1, 2, 3, 4, 排序树堆内元素会去重
+		heap.Top() //1, 2, 2, 3, 4, 相等的元素按放入的顺序取出
 		heap.Pop()
 	}
 
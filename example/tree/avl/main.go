@@ -12,7 +12,7 @@ import (
 )
 
 func main() {
-	var tree tree.Tree = avltree.New(tree.IntComparator)
+	var tree tree.Tree[int, int] = avltree.New[int, int](tree.OrderedComparator[int])
 	//添加
 	tree.Insert(1, 1)
 	tree.Insert(2, 2)
@@ -45,6 +45,15 @@ func main() {
 		fmt.Println(key, value)
 		e = e.Prev()
 	}
+	//区间遍历[1, 3)，最后一个参数可以指定边界：Closed、OpenClosed、Open
+	tree.AscendRange(1, 3, func(key, value int) bool {
+		fmt.Println(key, value) //1, 2
+		return true
+	})
+	tree.DescendRange(1, 3, func(key, value int) bool {
+		fmt.Println(key, value) //2, 1
+		return true
+	})
 	//区间
 	e = tree.Find(2)
 	for l, r := e.Prev(), e.Next(); l != nil && r != nil; l, r = l.Prev(), r.Next() {
@@ -7,25 +7,69 @@ package arraryheap
 import (
 	"github.com/mrtcx/plusdata/arrary"
 	"github.com/mrtcx/plusdata/arrary/blockslices"
+	"github.com/mrtcx/plusdata/codec"
 	"github.com/mrtcx/plusdata/heap"
 )
 
 var _ heap.Heap = (*ArraryHeap)(nil)
 
 type ArraryHeap struct {
-	container arrary.Arrary
-	cmp       heap.Less
+	container arrary.Arrary[interface{}]
+	// handles[i]为container[i]的handle，第一次PushHandle时才创建，元素移动时同步移动
+	handles arrary.Arrary[*Handle]
+	// 稳定堆中seqs[i]为container[i]放入的序号，相等的元素序号小的先取出
+	seqs  arrary.Arrary[uint64]
+	seq   uint64
+	cmp   heap.Less
+	codec codec.Codec[interface{}]
+}
+
+// Handle 指向堆中的一个元素，用于Update、Remove、Fix，元素被Pop或Remove后失效
+type Handle struct {
+	index int
 }
 
 func New(cmp heap.Less) *ArraryHeap {
 	return &ArraryHeap{
-		container: blockslices.New(),
+		container: blockslices.New[interface{}](),
 		cmp:       cmp,
 	}
 }
 
+// NewStable 稳定的数组堆，相等的元素按放入的顺序(FIFO)取出，每个元素多记录一个序号
+func NewStable(cmp heap.Less) *ArraryHeap {
+	h := New(cmp)
+	h.seqs = blockslices.New[uint64]()
+	return h
+}
+
+// FromArrary 直接使用arr作为底层存储，自底向上建堆，O(N)，之后arr归堆所有，不能再在外部修改
+func FromArrary(arr arrary.Arrary[interface{}], cmp heap.Less) *ArraryHeap {
+	h := &ArraryHeap{container: arr, cmp: cmp}
+	h.heapify()
+	return h
+}
+
+// FromSlice 把values复制到分块切片后建堆，O(N)
+func FromSlice(values []interface{}, cmp heap.Less) *ArraryHeap {
+	arr := blockslices.New[interface{}]()
+	for _, v := range values {
+		arr.PushBack(v)
+	}
+	return FromArrary(arr, cmp)
+}
+
 func (h *ArraryHeap) Clean() {
 	h.container.Clean()
+	h.handles = nil
+	if h.seqs != nil {
+		h.seqs.Clean()
+	}
+}
+
+// Stable 是否为NewStable创建的稳定堆
+func (h *ArraryHeap) Stable() bool {
+	return h.seqs != nil
 }
 
 func (h *ArraryHeap) Size() int {
@@ -44,62 +88,227 @@ func (h *ArraryHeap) Top() interface{} {
 }
 
 func (h *ArraryHeap) Push(val interface{}) {
-	h.container.PushBack(val)
-	h.downToTop()
+	h.pushBack(val, nil)
+	h.downToTop(h.Size() - 1)
+}
+
+// PushHandle 与Push相同，返回指向该元素的handle
+// Push保持heap.Heap接口的签名，需要handle时使用PushHandle
+func (h *ArraryHeap) PushHandle(val interface{}) *Handle {
+	if h.handles == nil {
+		h.handles = blockslices.New[*Handle]()
+		for i := 0; i < h.container.Size(); i++ {
+			h.handles.PushBack(nil)
+		}
+	}
+	handle := &Handle{index: h.Size()}
+	h.pushBack(val, handle)
+	h.downToTop(handle.index)
+	return handle
 }
 
 func (h *ArraryHeap) Pop() interface{} {
 	if h.container.Empty() {
 		return nil
 	}
-	head := h.container.Get(0)
-	tail := h.container.PopBack()
-	if !h.container.Empty() {
-		h.container.Set(0, tail)
-		h.topToDown()
+	return h.remove(0)
+}
+
+// Value handle指向的元素，handle失效时返回nil
+func (h *ArraryHeap) Value(handle *Handle) interface{} {
+	if !h.valid(handle) {
+		return nil
+	}
+	return h.container.Get(handle.index)
+}
+
+// Update 修改handle指向的元素并调整位置，用于Dijkstra等需要减小key的场景，handle失效时不做任何操作
+// 稳定堆中相当于删除后重新放入，排在相等的元素之后
+func (h *ArraryHeap) Update(handle *Handle, val interface{}) {
+	if !h.valid(handle) {
+		return
+	}
+	h.container.Set(handle.index, val)
+	if h.seqs != nil {
+		h.seqs.Set(handle.index, h.nextSeq())
+	}
+	h.fix(handle.index)
+}
+
+// Fix 元素(如指针指向的结构体)在堆外被修改后，调整其位置
+func (h *ArraryHeap) Fix(handle *Handle) {
+	if !h.valid(handle) {
+		return
+	}
+	h.fix(handle.index)
+}
+
+// Remove 删除handle指向的元素并返回，handle失效时返回nil
+func (h *ArraryHeap) Remove(handle *Handle) interface{} {
+	if !h.valid(handle) {
+		return nil
+	}
+	return h.remove(handle.index)
+}
+
+// Sorted 按从堆顶到堆底的顺序返回全部元素，O(NlogN)
+// 在底层存储上原地堆排序，排好序的数组仍然是一个堆，之后可以继续使用
+func (h *ArraryHeap) Sorted() []interface{} {
+	h.sort()
+	values := make([]interface{}, h.container.Size())
+	for i := range values {
+		values[i] = h.container.Get(i)
+	}
+	return values
+}
+
+// Drain 在底层存储上原地堆排序后返回，元素按从堆顶到堆底的顺序排列，O(NlogN)
+// 之后堆为空，handle全部失效
+func (h *ArraryHeap) Drain() arrary.Arrary[interface{}] {
+	h.sort()
+	arr := h.container
+	if h.handles != nil {
+		for i := 0; i < h.handles.Size(); i++ {
+			if handle := h.handles.Get(i); handle != nil {
+				handle.index = -1
+			}
+		}
+	}
+	h.container, h.handles = blockslices.New[interface{}](), nil
+	if h.seqs != nil {
+		h.seqs.Clean()
 	}
-	return head
+	return arr
 }
 
-func (h *ArraryHeap) topToDown() {
-	parent := 0
+// 被Pop、Remove或Clean后，handle的下标处不再是它自己
+func (h *ArraryHeap) valid(handle *Handle) bool {
+	return handle != nil && h.handles != nil && handle.index >= 0 && handle.index < h.handles.Size() &&
+		h.handles.Get(handle.index) == handle
+}
+
+func (h *ArraryHeap) pushBack(val interface{}, handle *Handle) {
+	h.container.PushBack(val)
+	if h.handles != nil {
+		h.handles.PushBack(handle)
+	}
+	if h.seqs != nil {
+		h.seqs.PushBack(h.nextSeq())
+	}
+}
+
+func (h *ArraryHeap) nextSeq() uint64 {
+	h.seq++
+	return h.seq
+}
+
+// 用末尾元素替换index处的元素
+func (h *ArraryHeap) remove(index int) interface{} {
+	last := h.container.Size() - 1
+	h.swap(index, last)
+	val := h.container.PopBack()
+	if h.handles != nil {
+		if handle := h.handles.PopBack(); handle != nil {
+			handle.index = -1
+		}
+	}
+	if h.seqs != nil {
+		h.seqs.PopBack()
+	}
+	if index < last {
+		h.fix(index)
+	}
+	return val
+}
+
+func (h *ArraryHeap) swap(i, j int) {
+	if i == j {
+		return
+	}
+	vi, vj := h.container.Get(i), h.container.Get(j)
+	h.container.Set(i, vj)
+	h.container.Set(j, vi)
+	if h.handles != nil {
+		hi, hj := h.handles.Get(i), h.handles.Get(j)
+		h.handles.Set(i, hj)
+		h.handles.Set(j, hi)
+		if hi != nil {
+			hi.index = j
+		}
+		if hj != nil {
+			hj.index = i
+		}
+	}
+	if h.seqs != nil {
+		si, sj := h.seqs.Get(i), h.seqs.Get(j)
+		h.seqs.Set(i, sj)
+		h.seqs.Set(j, si)
+	}
+}
+
+// i处的元素是否应该排在j之前，稳定堆中相等的元素比较序号
+func (h *ArraryHeap) less(i, j int) bool {
+	vi, vj := h.container.Get(i), h.container.Get(j)
+	if h.cmp(vi, vj) {
+		return true
+	}
+	if h.seqs == nil || h.cmp(vj, vi) {
+		return false
+	}
+	return h.seqs.Get(i) < h.seqs.Get(j)
+}
+
+func (h *ArraryHeap) fix(index int) {
+	if !h.downToTop(index) {
+		h.topToDown(index, h.container.Size())
+	}
+}
+
+// 自底向上，从最后一个非叶子节点开始依次下沉
+func (h *ArraryHeap) heapify() {
 	size := h.container.Size()
+	for i := size/2 - 1; i >= 0; i-- {
+		h.topToDown(i, size)
+	}
+}
+
+// 原地堆排序: 依次把堆顶换到末尾，得到从堆底到堆顶的顺序，再反转
+func (h *ArraryHeap) sort() {
+	for end := h.container.Size() - 1; end > 0; end-- {
+		h.swap(0, end)
+		h.topToDown(0, end)
+	}
+	for i, j := 0, h.container.Size()-1; i < j; i, j = i+1, j-1 {
+		h.swap(i, j)
+	}
+}
+
+// 在前size个元素中下沉
+func (h *ArraryHeap) topToDown(parent, size int) {
 	for parent*2+1 < size {
-		lchild := parent*2 + 1
-		rchild := parent*2 + 2
-		parentVal := h.container.Get(parent)
-		lchildval := h.container.Get(lchild)
-		if rchild >= size || h.cmp(lchildval, h.container.Get(rchild)) {
-			if h.cmp(lchildval, parentVal) {
-				h.container.Set(lchild, parentVal)
-				h.container.Set(parent, lchildval)
-				parent = lchild
-				continue
-			}
-		} else {
-			rchildval := h.container.Get(rchild)
-			if h.cmp(rchildval, parentVal) {
-				h.container.Set(rchild, parentVal)
-				h.container.Set(parent, rchildval)
-				parent = rchild
-				continue
-			}
+		child := parent*2 + 1
+		if rchild := child + 1; rchild < size && h.less(rchild, child) {
+			child = rchild
 		}
-		break
+		if !h.less(child, parent) {
+			break
+		}
+		h.swap(parent, child)
+		parent = child
 	}
 }
-func (h *ArraryHeap) downToTop() {
-	child := h.Size() - 1
+
+// 返回元素是否向上移动过
+func (h *ArraryHeap) downToTop(child int) bool {
+	moved := false
 	for child > 0 {
 		parent := (child - 1) / 2
-		childval := h.container.Get(child)
-		parentval := h.container.Get(parent)
-		if h.cmp(childval, parentval) {
-			h.container.Set(parent, childval)
-			h.container.Set(child, parentval)
-			child = parent
-			continue
+		if !h.less(child, parent) {
+			break
 		}
-		break
+		h.swap(parent, child)
+		child = parent
+		moved = true
 	}
+	return moved
 }
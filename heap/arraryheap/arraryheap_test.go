@@ -6,8 +6,11 @@ package arraryheap
 
 import (
 	"fmt"
+	"math/rand"
+	"sort"
 	"testing"
 
+	"github.com/mrtcx/plusdata/arrary/blockslices"
 	"github.com/mrtcx/plusdata/heap"
 	"github.com/mrtcx/plusdata/internal/assert"
 )
@@ -81,6 +84,199 @@ func testPushPop(t *testing.T, q *ArraryHeap, num int) {
 	}
 }
 
+func TestHandle(t *testing.T) {
+	q := New(testLess)
+	handles := []*Handle{}
+	for i := 0; i < 10; i++ {
+		handles = append(handles, q.PushHandle(i*10))
+	}
+	q.Update(handles[9], -1)
+	assert.Equal(t, q.Top(), -1)
+	assert.Equal(t, q.Value(handles[9]), -1)
+	q.Update(handles[9], 1000)
+	assert.Equal(t, q.Top(), 0)
+	assert.Equal(t, q.Remove(handles[0]), 0)
+	assert.Equal(t, q.Top(), 10)
+	assert.Equal(t, q.Size(), 9)
+
+	// 失效的handle不做任何操作
+	assert.Equal(t, q.Remove(handles[0]), nil)
+	assert.Equal(t, q.Value(handles[0]), nil)
+	q.Update(handles[0], -5)
+	assert.Equal(t, q.Top(), 10)
+	assert.Equal(t, q.Pop(), 10)
+	assert.Equal(t, q.Remove(handles[1]), nil)
+	q.Clean()
+	q.Push(1)
+	q.Push(2)
+	assert.Equal(t, q.Value(handles[2]), nil)
+	assert.Equal(t, q.Remove(nil), nil)
+}
+
+func TestHandleFix(t *testing.T) {
+	type task struct{ priority int }
+	q := New(func(a, b interface{}) bool { return a.(*task).priority < b.(*task).priority })
+	tasks := []*task{{3}, {1}, {2}}
+	handles := []*Handle{}
+	for _, v := range tasks {
+		handles = append(handles, q.PushHandle(v))
+	}
+	tasks[0].priority = 0
+	q.Fix(handles[0])
+	assert.Equal(t, q.Top(), tasks[0])
+	tasks[0].priority = 5
+	q.Fix(handles[0])
+	assert.Equal(t, q.Pop(), tasks[1])
+	assert.Equal(t, q.Pop(), tasks[2])
+	assert.Equal(t, q.Pop(), tasks[0])
+}
+
+// 随机操作后与排序的模型比较
+func TestHandleRandom(t *testing.T) {
+	q := New(testLess)
+	model := map[*Handle]int{}
+	for i := 0; i < 20000; i++ {
+		switch rand.Intn(5) {
+		case 0, 1:
+			model[q.PushHandle(rand.Intn(1000))] = 0
+		case 2:
+			q.Push(rand.Intn(1000))
+		case 3:
+			for handle := range model {
+				q.Update(handle, rand.Intn(1000))
+				break
+			}
+		default:
+			for handle := range model {
+				q.Remove(handle)
+				delete(model, handle)
+				break
+			}
+		}
+	}
+	for handle := range model {
+		model[handle] = q.Value(handle).(int)
+	}
+	got := []int{}
+	for !q.Empty() {
+		got = append(got, q.Pop().(int))
+	}
+	assert.Equal(t, sort.IntsAreSorted(got), true)
+	for handle, v := range model {
+		assert.Equal(t, q.Value(handle), nil)
+		idx := sort.SearchInts(got, v)
+		assert.Equal(t, got[idx], v)
+	}
+}
+
+func TestFromArrary(t *testing.T) {
+	testNum := []int{0, 1, 2, 3, 4, 1000, 100000}
+	for _, v := range testNum {
+		num := v
+		t.Run(fmt.Sprintf("[num:%d]", num), func(t *testing.T) {
+			arr := blockslices.New[interface{}]()
+			values := []interface{}{}
+			for _, v := range rand.Perm(num) {
+				arr.PushBack(v)
+				values = append(values, v)
+			}
+			for _, q := range []*ArraryHeap{FromArrary(arr, testLess), FromSlice(values, testLess)} {
+				assert.Equal(t, q.Size(), num)
+				for i := 0; i < num; i++ {
+					assert.Equal(t, q.Pop(), i)
+				}
+				assert.Equal(t, q.Empty(), true)
+			}
+		})
+	}
+}
+
+func TestSorted(t *testing.T) {
+	q := New(testLess)
+	handles := []*Handle{}
+	for _, v := range rand.Perm(1000) {
+		handles = append(handles, q.PushHandle(v))
+		q.Push(v)
+	}
+	sorted := q.Sorted()
+	assert.Equal(t, len(sorted), 2000)
+	for i, v := range sorted {
+		assert.Equal(t, v, i/2)
+	}
+	// 排序后仍然是堆，handle仍然有效
+	assert.Equal(t, q.Size(), 2000)
+	q.Update(handles[0], -1)
+	assert.Equal(t, q.Pop(), -1)
+	assert.Equal(t, q.Pop(), 0)
+
+	arr := q.Drain()
+	assert.Equal(t, q.Empty(), true)
+	assert.Equal(t, q.Value(handles[1]), nil)
+	assert.Equal(t, arr.Size(), 1998)
+	for i := 1; i < arr.Size(); i++ {
+		assert.Equal(t, arr.Get(i-1).(int) <= arr.Get(i).(int), true)
+	}
+	q.Push(3)
+	assert.Equal(t, q.Top(), 3)
+	assert.Equal(t, len(New(testLess).Sorted()), 0)
+}
+
+func TestStable(t *testing.T) {
+	type task struct{ priority, id int }
+	q := NewStable(func(a, b interface{}) bool { return a.(task).priority < b.(task).priority })
+	assert.Equal(t, q.Stable(), true)
+	assert.Equal(t, New(testLess).Stable(), false)
+	handles := []*Handle{}
+	for i := 0; i < 1000; i++ {
+		handles = append(handles, q.PushHandle(task{rand.Intn(10), i}))
+	}
+	// Update后排在相等的元素之后
+	q.Update(handles[0], task{0, 1000})
+	q.Remove(handles[1])
+	sorted := q.Sorted()
+	assert.Equal(t, len(sorted), 999)
+	prev := task{-1, -1}
+	for _, v := range sorted {
+		v := v.(task)
+		if v.priority == prev.priority {
+			assert.Greater(t, v.id, prev.id)
+		} else {
+			assert.Greater(t, v.priority, prev.priority)
+		}
+		prev = v
+		assert.Equal(t, q.Pop(), v)
+	}
+	q.Push(task{1, 1})
+	q.Clean()
+	assert.Equal(t, q.Empty(), true)
+}
+
+func BenchmarkFromSlice(b *testing.B) {
+	testsizes := []int{100000, 1000000}
+	testNames := []string{"10w", "100w"}
+	for i, v := range testsizes {
+		size := v
+		name := testNames[i]
+		values := make([]interface{}, size)
+		for j, v := range rand.Perm(size) {
+			values[j] = v
+		}
+		b.Run(fmt.Sprintf("push[%s]", name), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				h := New(heap.IntLess)
+				for _, v := range values {
+					h.Push(v)
+				}
+			}
+		})
+		b.Run(fmt.Sprintf("fromslice[%s]", name), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				FromSlice(values, heap.IntLess)
+			}
+		})
+	}
+}
+
 func BenchmarkPush(b *testing.B) {
 	testsizes := []int{100000, 500000, 1000000, 2000000}
 	testNames := []string{"10w", "50w", "100w", "200w"}
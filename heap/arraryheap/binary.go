@@ -0,0 +1,100 @@
+// Copyright (c) 2025 Tian ChunXing. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package arraryheap
+
+import (
+	"bytes"
+	"io"
+	"sort"
+
+	"github.com/mrtcx/plusdata/codec"
+)
+
+// SetCodec 设置编解码元素的codec，元素为interface{}，需要通过codec.Any转换，如codec.Any[int](codec.IntCodec{})
+func (h *ArraryHeap) SetCodec(c codec.Codec[interface{}]) {
+	h.codec = c
+}
+
+// Encode 按数组中的顺序把元素逐个编码写入w，解码时依次Push，元素已经满足堆的顺序，每次Push只需要比较一次
+func (h *ArraryHeap) Encode(w io.Writer) error {
+	if h.codec == nil {
+		return codec.ErrNoCodec
+	}
+	enc := codec.NewEncoder(w, codec.KindHeap, h.Size())
+	if h.seqs != nil {
+		// 稳定堆按取出的顺序编码，解码时依次Push后相等元素的顺序不变
+		for _, v := range h.stableOrder() {
+			codec.Write(enc, h.codec, v)
+		}
+		return enc.Close()
+	}
+	for i := 0; i < h.container.Size(); i++ {
+		codec.Write(enc, h.codec, h.container.Get(i))
+	}
+	return enc.Close()
+}
+
+// 复制元素和序号后排序，返回按取出顺序排列的元素，不修改堆
+func (h *ArraryHeap) stableOrder() []interface{} {
+	type entry struct {
+		value interface{}
+		seq   uint64
+	}
+	entries := make([]entry, h.container.Size())
+	for i := range entries {
+		entries[i] = entry{h.container.Get(i), h.seqs.Get(i)}
+	}
+	sort.Slice(entries, func(i, j int) bool {
+		if h.cmp(entries[i].value, entries[j].value) {
+			return true
+		}
+		return !h.cmp(entries[j].value, entries[i].value) && entries[i].seq < entries[j].seq
+	})
+	values := make([]interface{}, len(entries))
+	for i, e := range entries {
+		values[i] = e.value
+	}
+	return values
+}
+
+// Decode 清空后从r中解码，出错时容器为空
+func (h *ArraryHeap) Decode(r io.Reader) error {
+	h.Clean()
+	if err := h.decode(r); err != nil {
+		h.Clean()
+		return err
+	}
+	return nil
+}
+
+func (h *ArraryHeap) decode(r io.Reader) error {
+	if h.codec == nil {
+		return codec.ErrNoCodec
+	}
+	dec, n, err := codec.NewDecoder(r, codec.KindHeap)
+	if err != nil {
+		return err
+	}
+	for i := 0; i < n; i++ {
+		v, err := codec.Read(dec, h.codec)
+		if err != nil {
+			return err
+		}
+		h.Push(v)
+	}
+	return dec.Close()
+}
+
+func (h *ArraryHeap) MarshalBinary() ([]byte, error) {
+	var buf bytes.Buffer
+	if err := h.Encode(&buf); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func (h *ArraryHeap) UnmarshalBinary(data []byte) error {
+	return h.Decode(bytes.NewReader(data))
+}
@@ -0,0 +1,65 @@
+// Copyright (c) 2025 Tian ChunXing. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package arraryheap
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/mrtcx/plusdata/codec"
+	"github.com/mrtcx/plusdata/internal/assert"
+)
+
+func TestMarshalBinary(t *testing.T) {
+	h := New(testLess)
+	_, err := h.MarshalBinary()
+	assert.Equal(t, err, codec.ErrNoCodec)
+
+	h.SetCodec(codec.Any[int](codec.IntCodec{}))
+	for i := 0; i < 1000; i++ {
+		h.Push(rand.Intn(100))
+	}
+	data, err := h.MarshalBinary()
+	assert.Equal(t, err, nil)
+	h2 := New(testLess)
+	h2.SetCodec(codec.Any[int](codec.IntCodec{}))
+	assert.Equal(t, h2.UnmarshalBinary(data), nil)
+	assert.Equal(t, h2.Size(), 1000)
+	for !h.Empty() {
+		assert.Equal(t, h2.Pop(), h.Pop())
+	}
+	assert.NotEqual(t, h2.UnmarshalBinary(data[:len(data)/2]), nil)
+	assert.Equal(t, h2.Size(), 0)
+}
+
+// 稳定堆编解码后相等元素的顺序不变，元素为priority*10000+id，按priority比较
+func TestMarshalStable(t *testing.T) {
+	less := func(a, b interface{}) bool { return a.(int)/10000 < b.(int)/10000 }
+	h := NewStable(less)
+	h.SetCodec(codec.Any[int](codec.IntCodec{}))
+	for i := 0; i < 1000; i++ {
+		h.Push(rand.Intn(10)*10000 + i)
+	}
+	// 编码不修改堆的底层数组
+	layout := make([]interface{}, h.container.Size())
+	for i := range layout {
+		layout[i] = h.container.Get(i)
+	}
+	data, err := h.MarshalBinary()
+	assert.Equal(t, err, nil)
+	for i := range layout {
+		assert.Equal(t, h.container.Get(i), layout[i])
+	}
+	h2 := NewStable(less)
+	h2.SetCodec(codec.Any[int](codec.IntCodec{}))
+	assert.Equal(t, h2.UnmarshalBinary(data), nil)
+	prev := -1
+	for !h.Empty() {
+		v := h.Pop()
+		assert.Equal(t, h2.Pop(), v)
+		assert.Greater(t, v.(int), prev)
+		prev = v.(int)
+	}
+}
@@ -0,0 +1,256 @@
+// Copyright (c) 2025 Tian ChunXing. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package fibheap
+
+import (
+	"github.com/mrtcx/plusdata/heap"
+)
+
+var _ heap.Heap = (*FibHeap)(nil)
+
+// FibHeap 斐波那契堆，堆内元素不去重，Push、Meld、DecreaseKey均摊O(1)，Pop均摊O(logN)
+type FibHeap struct {
+	min  *Handle //根链表中最小的节点
+	size int
+	cmp  heap.Less
+	// consolidate中复用的缓存
+	roots   []*Handle
+	degrees []*Handle
+}
+
+// Handle 堆中的一个节点，用于DecreaseKey、Remove，元素被Pop或Remove后失效
+// 兄弟节点通过left、right组成循环双链表，child指向任意一个子节点
+type Handle struct {
+	val     interface{}
+	parent  *Handle
+	child   *Handle
+	left    *Handle
+	right   *Handle
+	degree  int
+	mark    bool //成为子节点后是否失去过子节点
+	removed bool
+}
+
+// Value handle指向的元素
+func (n *Handle) Value() interface{} {
+	return n.val
+}
+
+func New(cmp heap.Less) *FibHeap {
+	return &FibHeap{cmp: cmp}
+}
+
+// Clean 清空后之前的handle不能再使用
+func (h *FibHeap) Clean() {
+	h.min, h.size = nil, 0
+}
+
+func (h *FibHeap) Size() int {
+	return h.size
+}
+
+func (h *FibHeap) Empty() bool {
+	return h.size == 0
+}
+
+func (h *FibHeap) Top() interface{} {
+	if h.min == nil {
+		return nil
+	}
+	return h.min.val
+}
+
+func (h *FibHeap) Push(val interface{}) {
+	h.PushHandle(val)
+}
+
+// PushHandle 与Push相同，返回指向该元素的handle
+func (h *FibHeap) PushHandle(val interface{}) *Handle {
+	n := &Handle{val: val}
+	n.left, n.right = n, n
+	h.addRoot(n)
+	h.size++
+	return n
+}
+
+func (h *FibHeap) Pop() interface{} {
+	if h.min == nil {
+		return nil
+	}
+	top := h.min
+	// 子节点全部移到根链表
+	for h.min.child != nil {
+		c := h.min.child
+		h.min.child = remove(c)
+		c.parent, c.mark = nil, false
+		splice(h.min, c)
+	}
+	if top.right == top {
+		h.min = nil
+	} else {
+		h.min = top.right
+		remove(top)
+		h.consolidate()
+	}
+	h.size--
+	top.removed = true
+	top.left, top.right = top, top
+	return top.val
+}
+
+// Meld 把other中的元素全部合并到h中，other变为空，other的handle在h中仍然有效
+// 两个堆的比较函数需要相同
+func (h *FibHeap) Meld(other *FibHeap) {
+	if other == h || other.min == nil {
+		return
+	}
+	if h.min == nil {
+		h.min = other.min
+	} else {
+		splice(h.min, other.min)
+		if h.cmp(other.min.val, h.min.val) {
+			h.min = other.min
+		}
+	}
+	h.size += other.size
+	other.Clean()
+}
+
+// DecreaseKey 把handle指向的元素改为更小(或相等)的val，val更大或handle失效时返回false
+func (h *FibHeap) DecreaseKey(n *Handle, val interface{}) bool {
+	if n == nil || n.removed || h.cmp(n.val, val) {
+		return false
+	}
+	n.val = val
+	if p := n.parent; p != nil && h.cmp(val, p.val) {
+		h.cut(n)
+		h.cascadingCut(p)
+	}
+	if h.cmp(val, h.min.val) {
+		h.min = n
+	}
+	return true
+}
+
+// Remove 删除handle指向的元素并返回，handle失效时返回nil
+// 相当于把元素减小到最小后Pop
+func (h *FibHeap) Remove(n *Handle) interface{} {
+	if n == nil || n.removed {
+		return nil
+	}
+	if p := n.parent; p != nil {
+		h.cut(n)
+		h.cascadingCut(p)
+	}
+	h.min = n
+	return h.Pop()
+}
+
+func (h *FibHeap) addRoot(n *Handle) {
+	if h.min == nil {
+		h.min = n
+		return
+	}
+	splice(h.min, n)
+	if h.cmp(n.val, h.min.val) {
+		h.min = n
+	}
+}
+
+// 把n从父节点的子节点链表移到根链表
+func (h *FibHeap) cut(n *Handle) {
+	p := n.parent
+	if p.child == n {
+		p.child = remove(n)
+	} else {
+		remove(n)
+	}
+	p.degree--
+	n.parent, n.mark = nil, false
+	splice(h.min, n)
+}
+
+// 节点第二次失去子节点时也被剪下
+func (h *FibHeap) cascadingCut(n *Handle) {
+	for n.parent != nil {
+		if !n.mark {
+			n.mark = true
+			return
+		}
+		p := n.parent
+		h.cut(n)
+		n = p
+	}
+}
+
+// 合并度数相同的根，直到根的度数各不相同
+func (h *FibHeap) consolidate() {
+	roots := h.roots[:0]
+	for n := h.min; ; n = n.right {
+		roots = append(roots, n)
+		if n.right == h.min {
+			break
+		}
+	}
+	degrees := h.degrees[:0]
+	for _, n := range roots {
+		remove(n)
+		for {
+			for n.degree >= len(degrees) {
+				degrees = append(degrees, nil)
+			}
+			other := degrees[n.degree]
+			if other == nil {
+				break
+			}
+			degrees[n.degree] = nil
+			if h.cmp(other.val, n.val) {
+				n, other = other, n
+			}
+			h.link(n, other)
+		}
+		degrees[n.degree] = n
+	}
+	h.min = nil
+	for i, n := range degrees {
+		if n != nil {
+			h.addRoot(n)
+			degrees[i] = nil
+		}
+	}
+	for i := range roots {
+		roots[i] = nil
+	}
+	h.roots, h.degrees = roots, degrees
+}
+
+// child成为parent的子节点
+func (h *FibHeap) link(parent, child *Handle) {
+	child.parent, child.mark = parent, false
+	if parent.child == nil {
+		parent.child = child
+	} else {
+		splice(parent.child, child)
+	}
+	parent.degree++
+}
+
+// 把n所在的循环链表整体插入到a的右边，n可以是单个节点
+func splice(a, n *Handle) {
+	an, nl := a.right, n.left
+	a.right, n.left = n, a
+	nl.right, an.left = an, nl
+}
+
+// 把n从所在的循环链表中删除，返回链表中剩余的任意节点，链表为空时返回nil
+func remove(n *Handle) *Handle {
+	if n.right == n {
+		return nil
+	}
+	next := n.right
+	n.left.right, n.right.left = n.right, n.left
+	n.left, n.right = n, n
+	return next
+}
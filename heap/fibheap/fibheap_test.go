@@ -0,0 +1,139 @@
+// Copyright (c) 2025 Tian ChunXing. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package fibheap
+
+import (
+	"fmt"
+	"math/rand"
+	"sort"
+	"testing"
+
+	"github.com/mrtcx/plusdata/heap"
+	"github.com/mrtcx/plusdata/internal/assert"
+)
+
+func TestPushPop(t *testing.T) {
+	testNum := []int{1, 2, 3, 4, 1000, 100000}
+	for _, v := range testNum {
+		num := v
+		t.Run(fmt.Sprintf("[num:%d]", num), func(t *testing.T) {
+			q := New(heap.IntLess)
+			for _, v := range rand.Perm(num) {
+				q.Push(v)
+				q.Push(v)
+			}
+			assert.Equal(t, q.Size(), num*2)
+			for i := 0; i < num*2; i++ {
+				assert.Equal(t, q.Top(), i/2)
+				assert.Equal(t, q.Pop(), i/2)
+			}
+			assert.Equal(t, q.Empty(), true)
+			assert.Equal(t, q.Top(), nil)
+			assert.Equal(t, q.Pop(), nil)
+		})
+	}
+}
+
+func TestMeld(t *testing.T) {
+	a, b := New(heap.IntLess), New(heap.IntLess)
+	a.Meld(b)
+	assert.Equal(t, a.Empty(), true)
+	handles := []*Handle{}
+	for i := 0; i < 100; i++ {
+		a.Push(i * 2)
+		handles = append(handles, b.PushHandle(i*2+1))
+	}
+	a.Pop()
+	a.Meld(b)
+	a.Meld(a)
+	assert.Equal(t, b.Empty(), true)
+	assert.Equal(t, b.Top(), nil)
+	assert.Equal(t, a.Size(), 199)
+	// b的handle合并后在a中仍然有效
+	assert.Equal(t, a.DecreaseKey(handles[50], -1), true)
+	assert.Equal(t, a.Top(), -1)
+	assert.Equal(t, a.Remove(handles[50]), -1)
+	for i := 1; i < 200; i++ {
+		if i != 101 {
+			assert.Equal(t, a.Pop(), i)
+		}
+	}
+	assert.Equal(t, a.Empty(), true)
+}
+
+func TestDecreaseKey(t *testing.T) {
+	q := New(heap.IntLess)
+	handles := []*Handle{}
+	for i := 0; i < 100; i++ {
+		handles = append(handles, q.PushHandle(i+100))
+	}
+	q.Pop()
+	assert.Equal(t, q.DecreaseKey(handles[0], 1), false)
+	assert.Equal(t, q.DecreaseKey(handles[50], 200), false)
+	assert.Equal(t, q.DecreaseKey(nil, 1), false)
+	for i := 99; i >= 1; i-- {
+		assert.Equal(t, q.DecreaseKey(handles[i], i), true)
+		assert.Equal(t, handles[i].Value(), i)
+		assert.Equal(t, q.Top(), i)
+	}
+	assert.Equal(t, q.Remove(handles[0]), nil)
+	assert.Equal(t, q.Remove(handles[30]), 30)
+	assert.Equal(t, q.Remove(handles[30]), nil)
+	for i := 1; i < 100; i++ {
+		if i != 30 {
+			assert.Equal(t, q.Pop(), i)
+		}
+	}
+	assert.Equal(t, q.Size(), 0)
+}
+
+// 随机操作后与模型比较
+func TestRandom(t *testing.T) {
+	q := New(heap.IntLess)
+	model := map[*Handle]int{}
+	for i := 0; i < 50000; i++ {
+		switch rand.Intn(7) {
+		case 0, 1, 2:
+			v := rand.Intn(100000)
+			model[q.PushHandle(v)] = v
+		case 3:
+			for n, v := range model {
+				v -= rand.Intn(1000)
+				assert.Equal(t, q.DecreaseKey(n, v), true)
+				model[n] = v
+				break
+			}
+		case 4:
+			for n, v := range model {
+				assert.Equal(t, q.Remove(n), v)
+				delete(model, n)
+				break
+			}
+		default:
+			if q.Empty() {
+				continue
+			}
+			min := q.Top().(int)
+			for _, v := range model {
+				assert.Equal(t, v >= min, true)
+			}
+			assert.Equal(t, q.Pop(), min)
+			for n := range model {
+				if n.removed {
+					delete(model, n)
+				}
+			}
+		}
+		assert.Equal(t, q.Size(), len(model))
+	}
+	values := []int{}
+	for _, v := range model {
+		values = append(values, v)
+	}
+	sort.Ints(values)
+	for _, v := range values {
+		assert.Equal(t, q.Pop(), v)
+	}
+}
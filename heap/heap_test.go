@@ -0,0 +1,210 @@
+// Copyright (c) 2025 Tian ChunXing. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package heap_test
+
+import (
+	"fmt"
+	"math/rand"
+	"testing"
+
+	"github.com/mrtcx/plusdata/heap"
+	"github.com/mrtcx/plusdata/heap/arraryheap"
+	"github.com/mrtcx/plusdata/heap/fibheap"
+	"github.com/mrtcx/plusdata/heap/pairingheap"
+	"github.com/mrtcx/plusdata/internal/assert"
+)
+
+var testHeaps = []struct {
+	name string
+	new  func() heap.Heap
+}{
+	{"arraryheap", func() heap.Heap { return arraryheap.New(heap.IntLess) }},
+	{"pairingheap", func() heap.Heap { return pairingheap.New(heap.IntLess) }},
+	{"fibheap", func() heap.Heap { return fibheap.New(heap.IntLess) }},
+}
+
+func BenchmarkPush(b *testing.B) {
+	testsizes := []int{100000, 1000000}
+	testNames := []string{"10w", "100w"}
+	for i, v := range testsizes {
+		size := v
+		name := testNames[i]
+		nums := rand.Perm(size)
+		for _, th := range testHeaps {
+			th := th
+			b.Run(fmt.Sprintf("%s[%s]", th.name, name), func(b *testing.B) {
+				for i := 0; i < b.N; i++ {
+					h := th.new()
+					for _, v := range nums {
+						h.Push(v)
+					}
+				}
+			})
+		}
+	}
+}
+
+func BenchmarkPushPop(b *testing.B) {
+	testsizes := []int{100000, 1000000}
+	testNames := []string{"10w", "100w"}
+	for i, v := range testsizes {
+		size := v
+		name := testNames[i]
+		nums := rand.Perm(size)
+		for _, th := range testHeaps {
+			th := th
+			b.Run(fmt.Sprintf("%s[%s]", th.name, name), func(b *testing.B) {
+				for i := 0; i < b.N; i++ {
+					h := th.new()
+					for _, v := range nums {
+						h.Push(v)
+					}
+					for !h.Empty() {
+						h.Pop()
+					}
+				}
+			})
+		}
+	}
+}
+
+// 两个各有size个元素的堆合并，数组堆只能逐个Push
+func BenchmarkMeld(b *testing.B) {
+	testsizes := []int{100000, 1000000}
+	testNames := []string{"10w", "100w"}
+	for i, v := range testsizes {
+		size := v
+		name := testNames[i]
+		b.Run(fmt.Sprintf("arraryheap[%s]", name), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				b.StopTimer()
+				x, y := arraryheap.New(heap.IntLess), arraryheap.New(heap.IntLess)
+				for j := 0; j < size; j++ {
+					x.Push(j)
+					y.Push(j)
+				}
+				b.StartTimer()
+				for !y.Empty() {
+					x.Push(y.Pop())
+				}
+			}
+		})
+		b.Run(fmt.Sprintf("pairingheap[%s]", name), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				b.StopTimer()
+				x, y := pairingheap.New(heap.IntLess), pairingheap.New(heap.IntLess)
+				for j := 0; j < size; j++ {
+					x.Push(j)
+					y.Push(j)
+				}
+				b.StartTimer()
+				x.Meld(y)
+			}
+		})
+		b.Run(fmt.Sprintf("fibheap[%s]", name), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				b.StopTimer()
+				x, y := fibheap.New(heap.IntLess), fibheap.New(heap.IntLess)
+				for j := 0; j < size; j++ {
+					x.Push(j)
+					y.Push(j)
+				}
+				b.StartTimer()
+				x.Meld(y)
+			}
+		})
+	}
+}
+
+// 模拟Dijkstra: 先放入size个元素，再交替DecreaseKey和Pop，数组堆使用handle的Update
+func BenchmarkDecreaseKey(b *testing.B) {
+	testsizes := []int{100000, 1000000}
+	testNames := []string{"10w", "100w"}
+	for i, v := range testsizes {
+		size := v
+		name := testNames[i]
+		nums := rand.Perm(size)
+		b.Run(fmt.Sprintf("arraryheap[%s]", name), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				h := arraryheap.New(heap.IntLess)
+				handles := make([]*arraryheap.Handle, size)
+				for j, v := range nums {
+					handles[j] = h.PushHandle(v + size)
+				}
+				for j := size - 1; j >= 0; j-- {
+					h.Update(handles[j], h.Value(handles[j]).(int)-size)
+					if j%2 == 0 {
+						h.Pop()
+					}
+				}
+			}
+		})
+		b.Run(fmt.Sprintf("pairingheap[%s]", name), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				h := pairingheap.New(heap.IntLess)
+				handles := make([]*pairingheap.Handle, size)
+				for j, v := range nums {
+					handles[j] = h.PushHandle(v + size)
+				}
+				for j := size - 1; j >= 0; j-- {
+					h.DecreaseKey(handles[j], handles[j].Value().(int)-size)
+					if j%2 == 0 {
+						h.Pop()
+					}
+				}
+			}
+		})
+		b.Run(fmt.Sprintf("fibheap[%s]", name), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				h := fibheap.New(heap.IntLess)
+				handles := make([]*fibheap.Handle, size)
+				for j, v := range nums {
+					handles[j] = h.PushHandle(v + size)
+				}
+				for j := size - 1; j >= 0; j-- {
+					h.DecreaseKey(handles[j], handles[j].Value().(int)-size)
+					if j%2 == 0 {
+						h.Pop()
+					}
+				}
+			}
+		})
+	}
+}
+
+func TestBoundedTopK(t *testing.T) {
+	k := heap.BoundedTopK(10, func(a, b interface{}) bool { return a.(int) > b.(int) })
+	assert.Equal(t, k.Best(), nil)
+	assert.Equal(t, k.Worst(), nil)
+	for _, v := range rand.Perm(1000) {
+		evicted, ok := k.Push(v)
+		if ok {
+			assert.Equal(t, evicted.(int) < 990, true)
+		}
+		assert.Greater(t, 11, k.Size())
+	}
+	assert.Equal(t, k.Size(), 10)
+	assert.Equal(t, k.Best(), 999)
+	assert.Equal(t, k.Worst(), 990)
+	evicted, ok := k.Push(995)
+	assert.Equal(t, ok, true)
+	assert.Equal(t, evicted, 990)
+	evicted, ok = k.Push(1)
+	assert.Equal(t, evicted, 1)
+	sorted := k.Sorted()
+	assert.Equal(t, len(sorted), 10)
+	want := []int{999, 998, 997, 996, 995, 995, 994, 993, 992, 991}
+	for i, v := range sorted {
+		assert.Equal(t, v, want[i])
+	}
+
+	k.Clean()
+	assert.Equal(t, k.Empty(), true)
+	k = heap.BoundedTopK(0, heap.IntLess)
+	evicted, ok = k.Push(1)
+	assert.Equal(t, ok, true)
+	assert.Equal(t, evicted, 1)
+	assert.Equal(t, k.Size(), 0)
+}
@@ -0,0 +1,64 @@
+// Copyright (c) 2025 Tian ChunXing. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package minmaxheap
+
+import (
+	"github.com/mrtcx/plusdata/heap"
+	"github.com/mrtcx/plusdata/internal/minmaxheap"
+)
+
+var _ heap.Heap = (*MinMaxHeap)(nil)
+
+// MinMaxHeap 最小最大堆(双端优先队列)，可以同时取出最小和最大的元素，堆内元素不去重
+// Top、Pop与heap.Heap一致，对应最小的元素
+type MinMaxHeap struct {
+	h *minmaxheap.Heap[interface{}]
+}
+
+func New(cmp heap.Less) *MinMaxHeap {
+	return &MinMaxHeap{h: minmaxheap.New[interface{}](cmp)}
+}
+
+func (h *MinMaxHeap) Clean() {
+	h.h.Clean()
+}
+
+func (h *MinMaxHeap) Size() int {
+	return h.h.Size()
+}
+
+func (h *MinMaxHeap) Empty() bool {
+	return h.h.Size() == 0
+}
+
+func (h *MinMaxHeap) Push(val interface{}) {
+	h.h.Push(val)
+}
+
+// Top 最小的元素，堆为空时返回nil
+func (h *MinMaxHeap) Top() interface{} {
+	val, _ := h.h.Min()
+	return val
+}
+
+// Bottom 最大的元素，堆为空时返回nil
+func (h *MinMaxHeap) Bottom() interface{} {
+	val, _ := h.h.Max()
+	return val
+}
+
+func (h *MinMaxHeap) Pop() interface{} {
+	return h.PopTop()
+}
+
+func (h *MinMaxHeap) PopTop() interface{} {
+	val, _ := h.h.PopMin()
+	return val
+}
+
+func (h *MinMaxHeap) PopBottom() interface{} {
+	val, _ := h.h.PopMax()
+	return val
+}
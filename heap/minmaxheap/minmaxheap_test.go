@@ -0,0 +1,63 @@
+// Copyright (c) 2025 Tian ChunXing. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package minmaxheap
+
+import (
+	"fmt"
+	"math/rand"
+	"testing"
+
+	"github.com/mrtcx/plusdata/heap"
+	"github.com/mrtcx/plusdata/internal/assert"
+)
+
+func TestPushPop(t *testing.T) {
+	testNum := []int{1, 2, 3, 4, 5, 100, 100000}
+	for _, v := range testNum {
+		num := v
+		t.Run(fmt.Sprintf("[num:%d]", num), func(t *testing.T) {
+			q := New(heap.IntLess)
+			for _, v := range rand.Perm(num) {
+				q.Push(v)
+			}
+			assert.Equal(t, q.Size(), num)
+			// 从两端交替取出
+			lo, hi := 0, num-1
+			for i := 0; lo <= hi; i++ {
+				assert.Equal(t, q.Top(), lo)
+				assert.Equal(t, q.Bottom(), hi)
+				if i%2 == 0 {
+					assert.Equal(t, q.PopTop(), lo)
+					lo++
+				} else {
+					assert.Equal(t, q.PopBottom(), hi)
+					hi--
+				}
+			}
+			assert.Equal(t, q.Empty(), true)
+			assert.Equal(t, q.Top(), nil)
+			assert.Equal(t, q.Bottom(), nil)
+			assert.Equal(t, q.Pop(), nil)
+			assert.Equal(t, q.PopBottom(), nil)
+		})
+	}
+}
+
+func TestDuplicate(t *testing.T) {
+	q := New(heap.IntLess)
+	for i := 0; i < 100; i++ {
+		q.Push(i % 3)
+	}
+	for i := 0; i < 34; i++ {
+		assert.Equal(t, q.Pop(), 0)
+	}
+	for i := 0; i < 33; i++ {
+		assert.Equal(t, q.PopBottom(), 2)
+	}
+	assert.Equal(t, q.Top(), 1)
+	assert.Equal(t, q.Bottom(), 1)
+	q.Clean()
+	assert.Equal(t, q.Size(), 0)
+}
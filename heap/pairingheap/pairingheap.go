@@ -0,0 +1,181 @@
+// Copyright (c) 2025 Tian ChunXing. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package pairingheap
+
+import (
+	"github.com/mrtcx/plusdata/heap"
+)
+
+var _ heap.Heap = (*PairingHeap)(nil)
+
+// PairingHeap 配对堆，堆内元素不去重，Push、Meld为O(1)，Pop均摊O(logN)
+type PairingHeap struct {
+	root *Handle
+	size int
+	cmp  heap.Less
+}
+
+// Handle 堆中的一个节点，用于DecreaseKey、Remove，元素被Pop或Remove后失效
+// 子节点按child、sibling组成链表，prev为左兄弟，最左的子节点prev为父节点
+type Handle struct {
+	val     interface{}
+	child   *Handle
+	sibling *Handle
+	prev    *Handle
+	removed bool
+}
+
+// Value handle指向的元素
+func (n *Handle) Value() interface{} {
+	return n.val
+}
+
+func New(cmp heap.Less) *PairingHeap {
+	return &PairingHeap{cmp: cmp}
+}
+
+// Clean 清空后之前的handle不能再使用
+func (h *PairingHeap) Clean() {
+	h.root, h.size = nil, 0
+}
+
+func (h *PairingHeap) Size() int {
+	return h.size
+}
+
+func (h *PairingHeap) Empty() bool {
+	return h.size == 0
+}
+
+func (h *PairingHeap) Top() interface{} {
+	if h.root == nil {
+		return nil
+	}
+	return h.root.val
+}
+
+func (h *PairingHeap) Push(val interface{}) {
+	h.PushHandle(val)
+}
+
+// PushHandle 与Push相同，返回指向该元素的handle
+func (h *PairingHeap) PushHandle(val interface{}) *Handle {
+	n := &Handle{val: val}
+	h.root = h.link(h.root, n)
+	h.size++
+	return n
+}
+
+func (h *PairingHeap) Pop() interface{} {
+	if h.root == nil {
+		return nil
+	}
+	top := h.root
+	h.root = h.mergePairs(top.child)
+	h.size--
+	top.child, top.removed = nil, true
+	return top.val
+}
+
+// Meld 把other中的元素全部合并到h中，other变为空，other的handle在h中仍然有效
+// 两个堆的比较函数需要相同
+func (h *PairingHeap) Meld(other *PairingHeap) {
+	if other == h {
+		return
+	}
+	h.root = h.link(h.root, other.root)
+	h.size += other.size
+	other.Clean()
+}
+
+// DecreaseKey 把handle指向的元素改为更小(或相等)的val，val更大或handle失效时返回false
+func (h *PairingHeap) DecreaseKey(n *Handle, val interface{}) bool {
+	if n == nil || n.removed || h.cmp(n.val, val) {
+		return false
+	}
+	n.val = val
+	if n != h.root {
+		h.cut(n)
+		h.root = h.link(h.root, n)
+	}
+	return true
+}
+
+// Remove 删除handle指向的元素并返回，handle失效时返回nil
+func (h *PairingHeap) Remove(n *Handle) interface{} {
+	if n == nil || n.removed {
+		return nil
+	}
+	if n == h.root {
+		return h.Pop()
+	}
+	h.cut(n)
+	h.root = h.link(h.root, h.mergePairs(n.child))
+	h.size--
+	n.child, n.removed = nil, true
+	return n.val
+}
+
+// 把以n为根的子树从父节点的子节点链表中摘下
+func (h *PairingHeap) cut(n *Handle) {
+	if n.prev.child == n {
+		n.prev.child = n.sibling
+	} else {
+		n.prev.sibling = n.sibling
+	}
+	if n.sibling != nil {
+		n.sibling.prev = n.prev
+	}
+	n.prev, n.sibling = nil, nil
+}
+
+// 两个根合并，较大的根成为较小的根的最左子节点
+func (h *PairingHeap) link(a, b *Handle) *Handle {
+	if a == nil {
+		return b
+	}
+	if b == nil {
+		return a
+	}
+	if h.cmp(b.val, a.val) {
+		a, b = b, a
+	}
+	b.prev, b.sibling = a, a.child
+	if a.child != nil {
+		a.child.prev = b
+	}
+	a.child = b
+	return a
+}
+
+// 子节点从左到右两两合并，再从右到左依次合并
+func (h *PairingHeap) mergePairs(first *Handle) *Handle {
+	if first == nil {
+		return nil
+	}
+	var pairs *Handle //两两合并的结果，通过sibling逆序链接
+	for first != nil {
+		a, b := first, first.sibling
+		if b == nil {
+			first = nil
+		} else {
+			first = b.sibling
+			b.prev, b.sibling = nil, nil
+		}
+		a.prev, a.sibling = nil, nil
+		a = h.link(a, b)
+		a.sibling, pairs = pairs, a
+	}
+	root := pairs
+	pairs = pairs.sibling
+	root.sibling = nil
+	for pairs != nil {
+		next := pairs.sibling
+		pairs.sibling = nil
+		root = h.link(root, pairs)
+		pairs = next
+	}
+	return root
+}
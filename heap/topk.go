@@ -0,0 +1,77 @@
+// Copyright (c) 2025 Tian ChunXing. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package heap
+
+import (
+	"sort"
+
+	"github.com/mrtcx/plusdata/internal/minmaxheap"
+)
+
+// TopK 保留最好的n个元素，less(a, b)为true时a比b好，满了以后放入更好的元素会淘汰最差的元素
+// 底层为最小最大堆，最好和最差的元素都可以O(1)取得
+type TopK struct {
+	n    int
+	less Less
+	h    *minmaxheap.Heap[interface{}]
+}
+
+func BoundedTopK(n int, less Less) *TopK {
+	return &TopK{n: n, less: less, h: minmaxheap.New[interface{}](less)}
+}
+
+func (k *TopK) Size() int {
+	return k.h.Size()
+}
+
+func (k *TopK) Empty() bool {
+	return k.h.Size() == 0
+}
+
+func (k *TopK) Clean() {
+	k.h.Clean()
+}
+
+// Push 放入val，返回被淘汰的元素，没有淘汰时ok为false
+// 满了以后val不比最差的元素好时，被淘汰的是val自己
+func (k *TopK) Push(val interface{}) (evicted interface{}, ok bool) {
+	if k.n <= 0 {
+		return val, true
+	}
+	if k.h.Size() < k.n {
+		k.h.Push(val)
+		return nil, false
+	}
+	worst, _ := k.h.Max()
+	if !k.less(val, worst) {
+		return val, true
+	}
+	k.h.ReplaceMax(val)
+	return worst, true
+}
+
+// Best 最好的元素，为空时返回nil
+func (k *TopK) Best() interface{} {
+	val, _ := k.h.Min()
+	return val
+}
+
+// Worst 最差的元素，为空时返回nil
+func (k *TopK) Worst() interface{} {
+	val, _ := k.h.Max()
+	return val
+}
+
+// Sorted 从好到差返回全部元素，不修改TopK，O(nlogn)
+func (k *TopK) Sorted() []interface{} {
+	vals := make([]interface{}, k.h.Size())
+	for i := range vals {
+		vals[i] = k.h.Get(i)
+	}
+	sort.SliceStable(vals, func(i, j int) bool {
+		return k.less(vals[i], vals[j])
+	})
+	return vals
+}
@@ -0,0 +1,72 @@
+// Copyright (c) 2025 Tian ChunXing. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package treeheap
+
+import (
+	"bytes"
+	"io"
+
+	"github.com/mrtcx/plusdata/codec"
+)
+
+// SetCodec 设置编解码元素的codec，元素为interface{}，需要通过codec.Any转换，如codec.Any[int](codec.IntCodec{})
+func (h *TreeHeap) SetCodec(c codec.Codec[interface{}]) {
+	h.codec = c
+}
+
+// Encode 从堆顶开始按取出的顺序把元素逐个编码写入w，解码后相等元素的顺序不变
+func (h *TreeHeap) Encode(w io.Writer) error {
+	if h.codec == nil {
+		return codec.ErrNoCodec
+	}
+	enc := codec.NewEncoder(w, codec.KindHeap, h.Size())
+	for e := h.tree.Left(); e != nil; e = e.Next() {
+		b := e.Value().(*bucket)
+		for i := 0; i < b.size(); i++ {
+			codec.Write(enc, h.codec, b.get(i))
+		}
+	}
+	return enc.Close()
+}
+
+// Decode 清空后从r中解码，出错时容器为空
+func (h *TreeHeap) Decode(r io.Reader) error {
+	h.Clean()
+	if err := h.decode(r); err != nil {
+		h.Clean()
+		return err
+	}
+	return nil
+}
+
+func (h *TreeHeap) decode(r io.Reader) error {
+	if h.codec == nil {
+		return codec.ErrNoCodec
+	}
+	dec, n, err := codec.NewDecoder(r, codec.KindHeap)
+	if err != nil {
+		return err
+	}
+	for i := 0; i < n; i++ {
+		v, err := codec.Read(dec, h.codec)
+		if err != nil {
+			return err
+		}
+		h.Push(v)
+	}
+	return dec.Close()
+}
+
+func (h *TreeHeap) MarshalBinary() ([]byte, error) {
+	var buf bytes.Buffer
+	if err := h.Encode(&buf); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func (h *TreeHeap) UnmarshalBinary(data []byte) error {
+	return h.Decode(bytes.NewReader(data))
+}
@@ -0,0 +1,40 @@
+// Copyright (c) 2025 Tian ChunXing. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package treeheap
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/mrtcx/plusdata/codec"
+	"github.com/mrtcx/plusdata/internal/assert"
+	"github.com/mrtcx/plusdata/tree/rbtree"
+	"github.com/mrtcx/plusdata/tree/skiplist"
+)
+
+func TestMarshalBinary(t *testing.T) {
+	h := New(rbtree.New[interface{}, interface{}](cmp))
+	_, err := h.MarshalBinary()
+	assert.Equal(t, err, codec.ErrNoCodec)
+
+	h.SetCodec(codec.Any[int](codec.IntCodec{}))
+	for i := 0; i < 1000; i++ {
+		h.Push(rand.Intn(200))
+	}
+	data, err := h.MarshalBinary()
+	assert.Equal(t, err, nil)
+	// 可以解码到底层为其他树的堆中
+	h2 := New(skiplist.New[interface{}, interface{}](cmp))
+	h2.SetCodec(codec.Any[int](codec.IntCodec{}))
+	assert.Equal(t, h2.UnmarshalBinary(data), nil)
+	assert.Equal(t, h2.Size(), h.Size())
+	assert.Equal(t, h2.Size(), 1000)
+	for !h.Empty() {
+		assert.Equal(t, h2.Pop(), h.Pop())
+	}
+	assert.NotEqual(t, h2.UnmarshalBinary(data[:len(data)-1]), nil)
+	assert.Equal(t, h2.Size(), 0)
+	assert.Equal(t, h2.Top(), nil)
+}
@@ -5,18 +5,62 @@
 package treeheap
 
 import (
+	"github.com/mrtcx/plusdata/codec"
+	"github.com/mrtcx/plusdata/deque"
+	"github.com/mrtcx/plusdata/deque/circularblocks"
 	"github.com/mrtcx/plusdata/heap"
 	"github.com/mrtcx/plusdata/tree"
 )
 
 var _ heap.Heap = (*TreeHeap)(nil)
 
+// TreeHeap 排序树堆，树的key为优先级，value为该优先级的元素桶，相等的元素按放入的顺序(FIFO)取出
 type TreeHeap struct {
-	top  interface{}
-	tree tree.Tree
+	top   *bucket
+	size  int
+	tree  tree.Tree[interface{}, interface{}]
+	codec codec.Codec[interface{}]
 }
 
-func New(tree tree.Tree) *TreeHeap {
+// bucket 优先级相等的元素，第一个元素单独存放，有重复元素时才创建队列
+type bucket struct {
+	front interface{}
+	more  deque.Deque[interface{}]
+}
+
+func (b *bucket) push(val interface{}) {
+	if b.more == nil {
+		b.more = circularblocks.New[interface{}]()
+	}
+	b.more.PushBack(val)
+}
+
+// 取出第一个元素，返回桶是否为空
+func (b *bucket) pop() (interface{}, bool) {
+	val := b.front
+	if b.more == nil || b.more.Empty() {
+		b.front = nil
+		return val, true
+	}
+	b.front = b.more.PopFront()
+	return val, false
+}
+
+func (b *bucket) size() int {
+	if b.more == nil {
+		return 1
+	}
+	return 1 + b.more.Size()
+}
+
+func (b *bucket) get(index int) interface{} {
+	if index == 0 {
+		return b.front
+	}
+	return b.more.Get(index - 1)
+}
+
+func New(tree tree.Tree[interface{}, interface{}]) *TreeHeap {
 	return &TreeHeap{
 		tree: tree,
 	}
@@ -24,35 +68,47 @@ func New(tree tree.Tree) *TreeHeap {
 
 func (h *TreeHeap) Clean() {
 	h.tree.Clean()
-	h.top = nil
+	h.top, h.size = nil, 0
 }
 
 func (h *TreeHeap) Size() int {
-	return h.tree.Size()
+	return h.size
 }
 
 func (h *TreeHeap) Empty() bool {
-	return h.tree.Empty()
+	return h.size == 0
 }
 
 func (h *TreeHeap) Top() interface{} {
-	return h.top
+	if h.top == nil {
+		return nil
+	}
+	return h.top.front
 }
 
+// Push 与已有元素相等时放入该优先级的桶尾
 func (h *TreeHeap) Push(val interface{}) {
-	h.tree.Insert(val, nil)
-	h.top = h.tree.Left().Key()
+	if e := h.tree.Find(val); e != nil {
+		e.Value().(*bucket).push(val)
+	} else {
+		h.tree.Insert(val, &bucket{front: val})
+	}
+	h.size++
+	h.top = h.tree.Left().Value().(*bucket)
 }
 
 func (h *TreeHeap) Pop() interface{} {
 	if h.top == nil {
 		return nil
 	}
-	h.tree.Remove(h.top)
-	top := h.top
-	h.top = nil
-	if left := h.tree.Left(); left != nil {
-		h.top = left.Key()
+	val, empty := h.top.pop()
+	h.size--
+	if empty {
+		h.tree.Remove(val)
+		h.top = nil
+		if left := h.tree.Left(); left != nil {
+			h.top = left.Value().(*bucket)
+		}
 	}
-	return top
+	return val
 }
@@ -6,6 +6,7 @@ package treeheap
 
 import (
 	"fmt"
+	"math/rand"
 	"testing"
 
 	"github.com/mrtcx/plusdata/internal/assert"
@@ -26,7 +27,7 @@ func TestPushPop(t *testing.T) {
 	for _, v := range testNum {
 		num := v
 		t.Run(fmt.Sprintf("[num:%d]", num), func(t *testing.T) {
-			q := New(rbtree.New(cmp))
+			q := New(rbtree.New[interface{}, interface{}](cmp))
 			testPushPop(t, q, num)
 		})
 	}
@@ -38,7 +39,7 @@ func TestClean(t *testing.T) {
 	for _, v := range testNums {
 		num := v
 		t.Run(fmt.Sprintf("[num:%d]", num), func(t *testing.T) {
-			q := New(rbtree.New(cmp))
+			q := New(rbtree.New[interface{}, interface{}](cmp))
 			for i := 0; i < num; i++ {
 				q.Push(i)
 			}
@@ -51,6 +52,54 @@ func TestClean(t *testing.T) {
 	}
 }
 
+type task struct {
+	priority int
+	id       int
+}
+
+var taskCmp = func(a, b interface{}) int {
+	return a.(task).priority - b.(task).priority
+}
+
+// 相等的元素都保留，按放入的顺序取出
+func TestDuplicate(t *testing.T) {
+	trees := []tree.Tree[interface{}, interface{}]{
+		skiplist.New[interface{}, interface{}](taskCmp),
+		avltree.New[interface{}, interface{}](taskCmp),
+		rbtree.New[interface{}, interface{}](taskCmp),
+		btree.New[interface{}, interface{}](taskCmp, 4),
+		bplustree.New[interface{}, interface{}](taskCmp, 4),
+	}
+	for _, tr := range trees {
+		q := New(tr)
+		for i := 0; i < 1000; i++ {
+			q.Push(task{priority: rand.Intn(10), id: i})
+		}
+		assert.Equal(t, q.Size(), 1000)
+		prev := task{-1, -1}
+		n := 0
+		for i := 0; !q.Empty(); i++ {
+			n++
+			top := q.Top().(task)
+			v := q.Pop().(task)
+			assert.Equal(t, v, top)
+			if v.priority == prev.priority {
+				assert.Greater(t, v.id, prev.id)
+			} else {
+				assert.Greater(t, v.priority, prev.priority)
+			}
+			prev = v
+			if i < 1000 && i%100 == 0 {
+				// 中途放入相等的元素排在已有元素之后
+				q.Push(task{priority: v.priority, id: 1000 + i})
+			}
+		}
+		assert.Equal(t, n, 1010)
+		assert.Equal(t, q.Size(), 0)
+		assert.Equal(t, q.Top(), nil)
+	}
+}
+
 func testPushPop(t *testing.T, q *TreeHeap, num int) {
 	for k := 0; k < 3; k++ {
 		pushVal := num
@@ -94,7 +143,7 @@ func BenchmarkPush(b *testing.B) {
 		size := v
 		name := testNames[i]
 		b.Run(fmt.Sprintf("skiplist-%s", name), func(b *testing.B) {
-			h := New(skiplist.New(tree.IntComparator))
+			h := New(skiplist.New[interface{}, interface{}](tree.IntComparator))
 			b.ResetTimer()
 			for i := 0; i < b.N; i++ {
 				for j := 0; j < size; j++ {
@@ -103,7 +152,7 @@ func BenchmarkPush(b *testing.B) {
 			}
 		})
 		b.Run(fmt.Sprintf("rbtree-%s", name), func(b *testing.B) {
-			h := New(rbtree.New(tree.IntComparator))
+			h := New(rbtree.New[interface{}, interface{}](tree.IntComparator))
 			b.ResetTimer()
 			for i := 0; i < b.N; i++ {
 				for j := 0; j < size; j++ {
@@ -112,7 +161,7 @@ func BenchmarkPush(b *testing.B) {
 			}
 		})
 		b.Run(fmt.Sprintf("avltree-%s", name), func(b *testing.B) {
-			h := New(avltree.New(tree.IntComparator))
+			h := New(avltree.New[interface{}, interface{}](tree.IntComparator))
 			b.ResetTimer()
 			for i := 0; i < b.N; i++ {
 				for j := 0; j < size; j++ {
@@ -121,7 +170,7 @@ func BenchmarkPush(b *testing.B) {
 			}
 		})
 		b.Run(fmt.Sprintf("btree-%s", name), func(b *testing.B) {
-			h := New(btree.New(tree.IntComparator, 32))
+			h := New(btree.New[interface{}, interface{}](tree.IntComparator, 32))
 			b.ResetTimer()
 			for i := 0; i < b.N; i++ {
 				for j := 0; j < size; j++ {
@@ -130,7 +179,7 @@ func BenchmarkPush(b *testing.B) {
 			}
 		})
 		b.Run(fmt.Sprintf("bplustree-%s", name), func(b *testing.B) {
-			h := New(bplustree.New(tree.IntComparator, 32))
+			h := New(bplustree.New[interface{}, interface{}](tree.IntComparator, 32))
 			b.ResetTimer()
 			for i := 0; i < b.N; i++ {
 				for j := 0; j < size; j++ {
@@ -0,0 +1,26 @@
+// Copyright (c) 2025 Tian ChunXing. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+// Package blocksize 分块容器(blockslices、circularblocks)共用的块大小
+package blocksize
+
+import "unsafe"
+
+const (
+	MinCap       = 4 //新块的初始容量，也是块内元素个数的下限
+	MaxShift     = 15
+	MinShift     = 2         //块内元素个数不低于MinCap
+	_l1CacheSize = 1024 * 32 //L1-cache-32kb
+)
+
+// Shift 块内元素个数取2的幂，按元素大小使一个块不超过L1-cache, 如interface{}(8 * 2)为2048, int为4096
+func Shift[T any]() uint {
+	var zero T
+	size := unsafe.Sizeof(zero)
+	shift := uint(MaxShift)
+	for shift > MinShift && size<<shift > _l1CacheSize {
+		shift--
+	}
+	return shift
+}
@@ -0,0 +1,20 @@
+// Copyright (c) 2025 Tian ChunXing. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package blocksize
+
+import (
+	"testing"
+
+	"github.com/mrtcx/plusdata/internal/assert"
+)
+
+func TestShift(t *testing.T) {
+	assert.Equal(t, 1<<Shift[interface{}](), 2048)
+	assert.Equal(t, 1<<Shift[int](), 4096)
+	assert.Equal(t, 1<<Shift[int32](), 8192)
+	assert.Equal(t, 1<<Shift[struct{}](), 1<<MaxShift)
+	assert.Equal(t, 1<<Shift[[1024]byte](), 32)
+	assert.Equal(t, 1<<Shift[[1 << 16]byte](), MinCap)
+}
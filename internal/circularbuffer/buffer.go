@@ -6,57 +6,59 @@ package circularbuffer
 
 import "fmt"
 
-type Buffer struct {
+type Buffer[T any] struct {
 	start int
 	end   int
 	size  int
-	array []interface{}
+	array []T
 }
 
-func New(capacity int) *Buffer {
-	return &Buffer{
+func New[T any](capacity int) *Buffer[T] {
+	return &Buffer[T]{
 		start: 0,
 		end:   0,
-		array: make([]interface{}, capacity),
+		array: make([]T, capacity),
 	}
 }
 
-func (b *Buffer) arraryIndex(point int) int {
+func (b *Buffer[T]) arraryIndex(point int) int {
 	idx := (point%len(b.array) + len(b.array)) % len(b.array)
 	return idx
 }
 
-func (b *Buffer) Size() int {
+func (b *Buffer[T]) Size() int {
 	return b.size
 }
 
-func (b *Buffer) Capacity() int {
+func (b *Buffer[T]) Capacity() int {
 	return len(b.array)
 }
 
-func (b *Buffer) IsFull() bool {
+func (b *Buffer[T]) IsFull() bool {
 	return b.size == len(b.array)
 }
 
-func (b *Buffer) IsEmpty() bool {
+func (b *Buffer[T]) IsEmpty() bool {
 	return b.size == 0
 }
 
-func (b *Buffer) Back() interface{} {
+func (b *Buffer[T]) Back() T {
 	if b.size == 0 {
-		return nil
+		var zero T
+		return zero
 	}
 	return b.array[b.arraryIndex(b.end)]
 }
 
-func (b *Buffer) Front() interface{} {
+func (b *Buffer[T]) Front() T {
 	if b.size == 0 {
-		return nil
+		var zero T
+		return zero
 	}
 	return b.array[b.arraryIndex(b.start)]
 }
 
-func (b *Buffer) PushFront(value interface{}) {
+func (b *Buffer[T]) PushFront(value T) {
 	if b.size == len(b.array) {
 		panic("is full")
 	}
@@ -67,13 +69,15 @@ func (b *Buffer) PushFront(value interface{}) {
 	b.size++
 }
 
-func (b *Buffer) PopFront() interface{} {
+func (b *Buffer[T]) PopFront() T {
 	if b.size == 0 {
-		return nil
+		var zero T
+		return zero
 	}
 	idx := b.arraryIndex(b.start)
 	pv := b.array[idx]
-	b.array[idx] = nil
+	var zero T
+	b.array[idx] = zero
 	if b.size > 1 {
 		b.start++
 	}
@@ -81,7 +85,7 @@ func (b *Buffer) PopFront() interface{} {
 	return pv
 }
 
-func (b *Buffer) PushBack(value interface{}) {
+func (b *Buffer[T]) PushBack(value T) {
 	if b.size == len(b.array) {
 		panic("is full")
 	}
@@ -92,13 +96,15 @@ func (b *Buffer) PushBack(value interface{}) {
 	b.size++
 }
 
-func (b *Buffer) PopBack() interface{} {
+func (b *Buffer[T]) PopBack() T {
 	if b.size == 0 {
-		return nil
+		var zero T
+		return zero
 	}
 	idx := b.arraryIndex(b.end)
 	pv := b.array[idx]
-	b.array[idx] = nil
+	var zero T
+	b.array[idx] = zero
 	if b.size > 1 {
 		b.end--
 	}
@@ -106,28 +112,28 @@ func (b *Buffer) PopBack() interface{} {
 	return pv
 }
 
-func (b *Buffer) Get(index int) interface{} {
+func (b *Buffer[T]) Get(index int) T {
 	if index >= b.size || index < 0 {
 		panic(fmt.Sprintf("index[%d] beyond bound [%d:%d)", index, 0, b.size))
 	}
 	return b.array[b.arraryIndex(b.start+index)]
 }
 
-func (b *Buffer) Set(index int, val interface{}) {
+func (b *Buffer[T]) Set(index int, val T) {
 	if index >= b.size || index < 0 {
 		panic(fmt.Sprintf("index[%d] beyond bound [%d:%d)", index, 0, b.size))
 	}
 	b.array[b.arraryIndex(b.start+index)] = val
 }
 
-func (b *Buffer) ResetCapacity(capacity int) {
+func (b *Buffer[T]) ResetCapacity(capacity int) {
 	if capacity < b.size {
 		panic(fmt.Sprintf("cpacity[%d] less size[%d]", capacity, b.size))
 	}
 	if capacity == len(b.array) {
 		return
 	}
-	newarrary := make([]interface{}, capacity)
+	newarrary := make([]T, capacity)
 	oldarrary := b.array
 	sidx, eidx := b.arraryIndex(b.start), b.arraryIndex(b.end)
 	if sidx <= eidx {
@@ -31,7 +31,7 @@ func TestResetCapacity(t *testing.T) {
 }
 
 func TestGetSet(t *testing.T) {
-	b := New(1)
+	b := New[int](1)
 	b.PushBack(1)
 	if b.Get(0) != 1 {
 		t.Errorf("expected %d got %d", 1, b.Get(0))
@@ -46,7 +46,7 @@ func testPushPopWithCapactiy(t *testing.T, capacity int) {
 	step := (capacity + 1) / 2
 	minxPos, maxPos := -1*(capacity+step), capacity+step
 	for i := minxPos; i <= maxPos; i += step {
-		block := New(capacity)
+		block := New[int](capacity)
 		block.start, block.end = i, i
 		t.Run(fmt.Sprintf("[position:%d]", i), func(t *testing.T) {
 			testPushFrontPopFront(t, block, capacity)
@@ -57,22 +57,22 @@ func testPushPopWithCapactiy(t *testing.T, capacity int) {
 	}
 }
 
-func testPushFrontPopFront(t *testing.T, block *Buffer, capacity int) {
+func testPushFrontPopFront(t *testing.T, block *Buffer[int], capacity int) {
 	pushFull := capacity
 	for i := 1; i <= pushFull; i++ {
 		block.PushFront(i)
 	}
 	for pos, targetNum := 1, pushFull; pos <= pushFull; pos++ {
-		if block.Get(pos-1).(int) != targetNum {
-			t.Errorf("expected %d got %d", targetNum, block.Get(pos).(int))
+		if block.Get(pos-1) != targetNum {
+			t.Errorf("expected %d got %d", targetNum, block.Get(pos))
 		}
 		targetNum--
 	}
-	if block.Front().(int) != pushFull {
-		t.Errorf("expected %d got %d", capacity, block.Front().(int))
+	if block.Front() != pushFull {
+		t.Errorf("expected %d got %d", capacity, block.Front())
 	}
-	if block.Back().(int) != 1 {
-		t.Errorf("expected %d got %d", 1, block.Back().(int))
+	if block.Back() != 1 {
+		t.Errorf("expected %d got %d", 1, block.Back())
 	}
 	if block.Size() != pushFull {
 		t.Errorf("expected %d got %d", capacity, block.Size())
@@ -81,7 +81,7 @@ func testPushFrontPopFront(t *testing.T, block *Buffer, capacity int) {
 		t.Errorf("expected true got false")
 	}
 	for i := pushFull; i >= 1; i-- {
-		v := block.PopFront().(int)
+		v := block.PopFront()
 		if v != i {
 			t.Errorf("expected %d got %d", i, v)
 		}
@@ -92,27 +92,27 @@ func testPushFrontPopFront(t *testing.T, block *Buffer, capacity int) {
 	if !block.IsEmpty() {
 		t.Errorf("expected true got false")
 	}
-	if block.PopBack() != nil || block.PopFront() != nil || block.Back() != nil || block.Front() != nil {
-		t.Errorf("expected nil")
+	if block.PopBack() != 0 || block.PopFront() != 0 || block.Back() != 0 || block.Front() != 0 {
+		t.Errorf("expected zero value")
 	}
 }
 
-func testPushBackPopBack(t *testing.T, block *Buffer, capacity int) {
+func testPushBackPopBack(t *testing.T, block *Buffer[int], capacity int) {
 	pushFull := capacity
 	for i := 1; i <= pushFull; i++ {
 		block.PushBack(i)
 	}
 	for pos, targetNum := 1, 1; pos <= pushFull; pos++ {
-		if block.Get(pos-1).(int) != targetNum {
-			t.Errorf("expected %d got %d", targetNum, block.Get(pos).(int))
+		if block.Get(pos-1) != targetNum {
+			t.Errorf("expected %d got %d", targetNum, block.Get(pos))
 		}
 		targetNum++
 	}
-	if block.Front().(int) != 1 {
-		t.Errorf("expected %d got %d", 1, block.Front().(int))
+	if block.Front() != 1 {
+		t.Errorf("expected %d got %d", 1, block.Front())
 	}
-	if block.Back().(int) != pushFull {
-		t.Errorf("expected %d got %d", capacity, block.Back().(int))
+	if block.Back() != pushFull {
+		t.Errorf("expected %d got %d", capacity, block.Back())
 	}
 	if block.Size() != pushFull {
 		t.Errorf("expected %d got %d", capacity, block.Size())
@@ -121,7 +121,7 @@ func testPushBackPopBack(t *testing.T, block *Buffer, capacity int) {
 		t.Errorf("expected true got false")
 	}
 	for i := pushFull; i >= 1; i-- {
-		v := block.PopBack().(int)
+		v := block.PopBack()
 		if v != i {
 			t.Errorf("expected %d got %d", i, v)
 		}
@@ -132,19 +132,19 @@ func testPushBackPopBack(t *testing.T, block *Buffer, capacity int) {
 	if !block.IsEmpty() {
 		t.Errorf("expected true got false")
 	}
-	if block.PopBack() != nil || block.PopFront() != nil || block.Front() != nil || block.Back() != nil {
-		t.Errorf("expected nil")
+	if block.PopBack() != 0 || block.PopFront() != 0 || block.Front() != 0 || block.Back() != 0 {
+		t.Errorf("expected zero value")
 	}
 }
 
-func testPushFrontPopBack(t *testing.T, block *Buffer, capacity int) {
+func testPushFrontPopBack(t *testing.T, block *Buffer[int], capacity int) {
 	for times := 1; times <= 2; times++ {
 		pushFull := capacity
 		for i := 1; i <= pushFull; i++ {
 			block.PushFront(i)
 		}
 		for i := 1; i <= pushFull; i++ {
-			v := block.PopBack().(int)
+			v := block.PopBack()
 			if v != i {
 				t.Errorf("expected %d got %d", i, v)
 			}
@@ -152,20 +152,20 @@ func testPushFrontPopBack(t *testing.T, block *Buffer, capacity int) {
 		if !block.IsEmpty() {
 			t.Errorf("expected true got false")
 		}
-		if block.PopBack() != nil || block.PopFront() != nil || block.Front() != nil || block.Back() != nil {
-			t.Errorf("expected nil")
+		if block.PopBack() != 0 || block.PopFront() != 0 || block.Front() != 0 || block.Back() != 0 {
+			t.Errorf("expected zero value")
 		}
 	}
 }
 
-func testPushBackPopFront(t *testing.T, block *Buffer, capacity int) {
+func testPushBackPopFront(t *testing.T, block *Buffer[int], capacity int) {
 	for times := 1; times <= 2; times++ {
 		pushFull := capacity
 		for i := 1; i <= pushFull; i++ {
 			block.PushBack(i)
 		}
 		for i := 1; i <= pushFull; i++ {
-			v := block.PopFront().(int)
+			v := block.PopFront()
 			if v != i {
 				t.Errorf("expected %d got %d", i, v)
 			}
@@ -173,8 +173,8 @@ func testPushBackPopFront(t *testing.T, block *Buffer, capacity int) {
 		if !block.IsEmpty() {
 			t.Errorf("expected true got false")
 		}
-		if block.PopBack() != nil || block.PopFront() != nil || block.Front() != nil || block.Back() != nil {
-			t.Errorf("expected nil")
+		if block.PopBack() != 0 || block.PopFront() != 0 || block.Front() != 0 || block.Back() != 0 {
+			t.Errorf("expected zero value")
 		}
 	}
 }
@@ -194,7 +194,7 @@ func testResetCapacityWithCapaction(t *testing.T, capacity int) {
 
 func testExpandCapacityWithPosion(t *testing.T, capacity int, stepLen int, pos int) {
 	for pushVal := 1; pushVal <= capacity; pushVal += stepLen {
-		block := New(capacity)
+		block := New[int](capacity)
 		block.start, block.end = pos, pos
 		for j := 1; j <= pushVal; j++ {
 			block.PushBack(j)
@@ -207,23 +207,23 @@ func testExpandCapacityWithPosion(t *testing.T, capacity int, stepLen int, pos i
 			t.Errorf("expected %d got %d", pushVal+capacity, block.Capacity())
 		}
 		for num, idx := 1, 0; num <= pushVal; {
-			if block.Get(idx).(int) != num {
-				t.Errorf("expected %d got %d", num, block.Get(num-1).(int))
+			if block.Get(idx) != num {
+				t.Errorf("expected %d got %d", num, block.Get(num-1))
 			}
 			num++
 			idx++
 		}
 		block.PushFront(0)
-		if v := block.PopFront().(int); v != 0 {
+		if v := block.PopFront(); v != 0 {
 			t.Errorf("expected 0 get %d", v)
 		}
 		block.PushBack(0)
-		if v := block.PopBack().(int); v != 0 {
+		if v := block.PopBack(); v != 0 {
 			t.Errorf("expected 0 get %d", v)
 		}
 		for num, idx := 1, 0; num <= pushVal; {
-			if block.Get(idx).(int) != num {
-				t.Errorf("expected %d got %d", num, block.Get(idx).(int))
+			if block.Get(idx) != num {
+				t.Errorf("expected %d got %d", num, block.Get(idx))
 			}
 			num++
 			idx++
@@ -233,7 +233,7 @@ func testExpandCapacityWithPosion(t *testing.T, capacity int, stepLen int, pos i
 
 func testReduceCapacityWithPosion(t *testing.T, capacity int, stepLen int, pos int) {
 	for pushVal := 1; pushVal <= capacity; pushVal += stepLen {
-		block := New(capacity)
+		block := New[int](capacity)
 		block.start, block.end = pos, pos
 		for j := 1; j <= pushVal; j++ {
 			block.PushBack(j)
@@ -249,22 +249,22 @@ func testReduceCapacityWithPosion(t *testing.T, capacity int, stepLen int, pos i
 			t.Errorf("expected false got true")
 		}
 		for num := 1; num <= pushVal; num++ {
-			if block.Get(num-1).(int) != num {
-				t.Errorf("expected %d got %d", num, block.Get(num-1).(int))
+			if block.Get(num-1) != num {
+				t.Errorf("expected %d got %d", num, block.Get(num-1))
 			}
 		}
 		block.PushFront(0)
-		if v := block.PopFront().(int); v != 0 {
+		if v := block.PopFront(); v != 0 {
 			t.Errorf("expected 0 get %d", v)
 		}
 		block.PushBack(0)
-		if v := block.PopBack().(int); v != 0 {
+		if v := block.PopBack(); v != 0 {
 			t.Errorf("expected 0 get %d", v)
 		}
 		block.ResetCapacity(pushVal)
 		for num, idx := 1, 0; num <= pushVal; {
-			if block.Get(idx).(int) != num {
-				t.Errorf("expected %d got %d", num, block.Get(idx).(int))
+			if block.Get(idx) != num {
+				t.Errorf("expected %d got %d", num, block.Get(idx))
 			}
 			num++
 			idx++
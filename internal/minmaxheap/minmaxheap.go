@@ -0,0 +1,176 @@
+// Copyright (c) 2025 Tian ChunXing. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+// Package minmaxheap 最小最大堆，偶数层(根为第0层)的节点不大于其子树，奇数层的节点不小于其子树
+// 根为最小值，最大值为根的两个子节点之一
+package minmaxheap
+
+import (
+	"math/bits"
+
+	"github.com/mrtcx/plusdata/arrary"
+	"github.com/mrtcx/plusdata/arrary/blockslices"
+)
+
+type Heap[T any] struct {
+	container arrary.Arrary[T]
+	less      func(a, b T) bool
+}
+
+func New[T any](less func(a, b T) bool) *Heap[T] {
+	return &Heap[T]{
+		container: blockslices.New[T](),
+		less:      less,
+	}
+}
+
+func (h *Heap[T]) Clean() {
+	h.container.Clean()
+}
+
+func (h *Heap[T]) Size() int {
+	return h.container.Size()
+}
+
+func (h *Heap[T]) Get(index int) T {
+	return h.container.Get(index)
+}
+
+func (h *Heap[T]) Min() (T, bool) {
+	if h.container.Empty() {
+		var zero T
+		return zero, false
+	}
+	return h.container.Get(0), true
+}
+
+func (h *Heap[T]) Max() (T, bool) {
+	if h.container.Empty() {
+		var zero T
+		return zero, false
+	}
+	return h.container.Get(h.maxIndex()), true
+}
+
+func (h *Heap[T]) Push(val T) {
+	h.container.PushBack(val)
+	h.bubbleUp(h.container.Size() - 1)
+}
+
+func (h *Heap[T]) PopMin() (T, bool) {
+	if h.container.Empty() {
+		var zero T
+		return zero, false
+	}
+	return h.remove(0), true
+}
+
+func (h *Heap[T]) PopMax() (T, bool) {
+	if h.container.Empty() {
+		var zero T
+		return zero, false
+	}
+	return h.remove(h.maxIndex()), true
+}
+
+// ReplaceMax 用val替换最大值，相当于PopMax后Push，只调整一次
+func (h *Heap[T]) ReplaceMax(val T) {
+	i := h.maxIndex()
+	h.container.Set(i, val)
+	if i > 0 && h.less(val, h.container.Get(0)) {
+		// 比根还小，与根交换后根的位置仍然是最小值
+		h.swap(0, i)
+	}
+	h.trickleDown(i)
+}
+
+func (h *Heap[T]) maxIndex() int {
+	switch size := h.container.Size(); {
+	case size == 1:
+		return 0
+	case size == 2 || !h.less(h.container.Get(1), h.container.Get(2)):
+		return 1
+	default:
+		return 2
+	}
+}
+
+// 用末尾元素替换index处的元素
+func (h *Heap[T]) remove(index int) T {
+	val := h.container.Get(index)
+	tail := h.container.PopBack()
+	if index < h.container.Size() {
+		h.container.Set(index, tail)
+		h.trickleDown(index)
+	}
+	return val
+}
+
+func isMinLevel(i int) bool {
+	return bits.Len(uint(i+1))%2 == 1
+}
+
+func (h *Heap[T]) swap(i, j int) {
+	vi, vj := h.container.Get(i), h.container.Get(j)
+	h.container.Set(i, vj)
+	h.container.Set(j, vi)
+}
+
+// 比较时max层把顺序反过来
+func (h *Heap[T]) before(a, b T, min bool) bool {
+	if min {
+		return h.less(a, b)
+	}
+	return h.less(b, a)
+}
+
+func (h *Heap[T]) bubbleUp(i int) {
+	if i == 0 {
+		return
+	}
+	min := isMinLevel(i)
+	p := (i - 1) / 2
+	if h.before(h.container.Get(p), h.container.Get(i), min) {
+		// 父节点在另一种层上，与父节点交换后在父节点的层上继续
+		h.swap(i, p)
+		i, min = p, !min
+	}
+	for i > 2 {
+		gp := ((i-1)/2 - 1) / 2
+		if !h.before(h.container.Get(i), h.container.Get(gp), min) {
+			break
+		}
+		h.swap(i, gp)
+		i = gp
+	}
+}
+
+func (h *Heap[T]) trickleDown(i int) {
+	min := isMinLevel(i)
+	size := h.container.Size()
+	for {
+		// 在子节点和孙节点中找最小(max层为最大)的
+		m := -1
+		for _, c := range [...]int{2*i + 1, 2*i + 2, 4*i + 3, 4*i + 4, 4*i + 5, 4*i + 6} {
+			if c >= size {
+				break
+			}
+			if m < 0 || h.before(h.container.Get(c), h.container.Get(m), min) {
+				m = c
+			}
+		}
+		if m < 0 || !h.before(h.container.Get(m), h.container.Get(i), min) {
+			return
+		}
+		h.swap(m, i)
+		if m <= 2*i+2 {
+			return
+		}
+		// 孙节点与父节点(另一种层)比较
+		if p := (m - 1) / 2; h.before(h.container.Get(p), h.container.Get(m), min) {
+			h.swap(m, p)
+		}
+		i = m
+	}
+}
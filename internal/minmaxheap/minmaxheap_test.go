@@ -0,0 +1,83 @@
+// Copyright (c) 2025 Tian ChunXing. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package minmaxheap
+
+import (
+	"math/rand"
+	"sort"
+	"testing"
+
+	"github.com/mrtcx/plusdata/internal/assert"
+)
+
+func intLess(a, b int) bool { return a < b }
+
+// 检查每个节点与其所有后代的关系
+func checkHeap(t *testing.T, h *Heap[int]) {
+	size := h.Size()
+	for i := 1; i < size; i++ {
+		for a := (i - 1) / 2; ; a = (a - 1) / 2 {
+			if isMinLevel(a) {
+				assert.Equal(t, h.Get(a) <= h.Get(i), true)
+			} else {
+				assert.Equal(t, h.Get(a) >= h.Get(i), true)
+			}
+			if a == 0 {
+				break
+			}
+		}
+	}
+}
+
+func TestRandom(t *testing.T) {
+	h := New(intLess)
+	_, ok := h.Min()
+	assert.Equal(t, ok, false)
+	_, ok = h.PopMax()
+	assert.Equal(t, ok, false)
+	model := []int{}
+	for i := 0; i < 20000; i++ {
+		switch rand.Intn(7) {
+		case 0, 1, 2:
+			v := rand.Intn(1000)
+			h.Push(v)
+			model = append(model, v)
+		case 3:
+			if len(model) > 0 {
+				v := rand.Intn(1000)
+				h.ReplaceMax(v)
+				model[len(model)-1] = v
+			}
+		case 4:
+			v, ok := h.PopMin()
+			assert.Equal(t, ok, len(model) > 0)
+			if ok {
+				assert.Equal(t, v, model[0])
+				model = model[1:]
+			}
+		default:
+			v, ok := h.PopMax()
+			assert.Equal(t, ok, len(model) > 0)
+			if ok {
+				assert.Equal(t, v, model[len(model)-1])
+				model = model[:len(model)-1]
+			}
+		}
+		sort.Ints(model)
+		assert.Equal(t, h.Size(), len(model))
+		if len(model) > 0 {
+			min, _ := h.Min()
+			max, _ := h.Max()
+			assert.Equal(t, min, model[0])
+			assert.Equal(t, max, model[len(model)-1])
+		}
+		if i%1000 == 0 {
+			checkHeap(t, h)
+		}
+	}
+	checkHeap(t, h)
+	h.Clean()
+	assert.Equal(t, h.Size(), 0)
+}
@@ -0,0 +1,578 @@
+// Copyright (c) 2025 Tian ChunXing. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+// Package treetest tree.Tree各实现共用的测试，只通过接口访问树，
+// 树实现了tree.Iterable、encoding.BinaryMarshaler时同时测试迭代器和编码
+package treetest
+
+import (
+	"encoding"
+	"fmt"
+	"math/rand"
+	"sort"
+	"testing"
+
+	"github.com/mrtcx/plusdata/internal/assert"
+	"github.com/mrtcx/plusdata/tree"
+)
+
+var (
+	elementNums = []int{1, 2, 4, 8, 9, 1024 + 1}
+	rangeNums   = []int{0, 1, 2, 4, 8, 9, 1024 + 1}
+	bounds      = []tree.Bound{tree.ClosedOpen, tree.Closed, tree.OpenClosed, tree.Open}
+)
+
+// Run 依次运行所有测试，newTree每次返回一棵空树
+func Run(t *testing.T, newTree func() tree.Tree[int, int]) {
+	tests := []struct {
+		name string
+		fn   func(t *testing.T, newTree func() tree.Tree[int, int])
+	}{
+		{"Find", testFind},
+		{"Left", testLeft},
+		{"Right", testRight},
+		{"Prev", testPrev},
+		{"Next", testNext},
+		{"ElementPrev", testElementPrev},
+		{"ElementNext", testElementNext},
+		{"ElementSet", testElementSet},
+		{"AscendRange", testAscendRange},
+		{"DescendRange", testDescendRange},
+		{"AscendGreaterOrEqual", testAscendGreaterOrEqual},
+		{"DescendLessOrEqual", testDescendLessOrEqual},
+		{"RankSelect", testRankSelect},
+		{"RankSelectRandom", testRankSelectRandom},
+		{"Iterator", testIterator},
+		{"IteratorSeek", testIteratorSeek},
+		{"MarshalBinary", testMarshalBinary},
+	}
+	for _, test := range tests {
+		ttest := test
+		t.Run(ttest.name, func(t *testing.T) {
+			ttest.fn(t, newTree)
+		})
+	}
+}
+
+func testFind(t *testing.T, newTree func() tree.Tree[int, int]) {
+	for _, num := range elementNums {
+		tnum := num
+		t.Run(fmt.Sprintf("[num:%d]", tnum), func(t *testing.T) {
+			tr := newTree()
+			for i := 1; i <= tnum; i++ {
+				tr.Insert(2*i, 2*i)
+			}
+			for i := 1; i <= tnum; i++ {
+				e := tr.Find(2 * i)
+				assert.Equal(t, e.Key(), 2*i)
+				assert.Equal(t, e.Value(), 2*i)
+				assert.Equal(t, tr.Find(2*i-1), nil)
+				v, ok := tr.Get(2 * i)
+				assert.Equal(t, ok, true)
+				assert.Equal(t, v, 2*i)
+			}
+			for i := 1; i <= tnum; i++ {
+				tr.Remove(2 * i)
+				assert.Equal(t, tr.Find(2*i), nil)
+				_, ok := tr.Get(2 * i)
+				assert.Equal(t, ok, false)
+			}
+			assert.Equal(t, tr.Empty(), true)
+		})
+	}
+}
+
+func testLeft(t *testing.T, newTree func() tree.Tree[int, int]) {
+	for _, num := range elementNums {
+		tnum := num
+		t.Run(fmt.Sprintf("[num:%d]", tnum), func(t *testing.T) {
+			tr := newTree()
+			assert.Equal(t, tr.Left(), nil)
+			for i := 1; i <= tnum; i++ {
+				tr.Insert(i, i)
+			}
+			left := tr.Left()
+			for i := 1; i <= tnum; i++ {
+				assert.Equal(t, left.Key(), i)
+				assert.Equal(t, left.Value(), i)
+				left = left.Next()
+			}
+			assert.Equal(t, left, nil)
+		})
+	}
+}
+
+func testRight(t *testing.T, newTree func() tree.Tree[int, int]) {
+	for _, num := range elementNums {
+		tnum := num
+		t.Run(fmt.Sprintf("[num:%d]", tnum), func(t *testing.T) {
+			tr := newTree()
+			assert.Equal(t, tr.Right(), nil)
+			for i := 1; i <= tnum; i++ {
+				tr.Insert(i, i)
+			}
+			right := tr.Right()
+			for i := tnum; i >= 1; i-- {
+				assert.Equal(t, right.Key(), i)
+				assert.Equal(t, right.Value(), i)
+				right = right.Prev()
+			}
+			assert.Equal(t, right, nil)
+		})
+	}
+}
+
+func testPrev(t *testing.T, newTree func() tree.Tree[int, int]) {
+	for _, num := range elementNums {
+		tnum := num
+		t.Run(fmt.Sprintf("[num:%d]", tnum), func(t *testing.T) {
+			tr := newTree()
+			assert.Equal(t, tr.Prev(2), nil)
+			for i := 1; i <= tnum; i++ {
+				tr.Insert(2*i, 2*i)
+			}
+			assert.Equal(t, tr.Prev(0), nil)
+			assert.Equal(t, tr.Prev(2*1), nil)
+			for i := 2; i <= tnum; i++ {
+				e := tr.Prev(2 * i)
+				assert.Equal(t, e.Key(), (i-1)*2)
+				assert.Equal(t, e.Value(), (i-1)*2)
+
+				e = tr.Prev(2*i + 1)
+				assert.Equal(t, e.Key(), i*2)
+				assert.Equal(t, e.Value(), i*2)
+			}
+
+			l, r := (tnum+1)/2, (tnum+1)/2+1
+			for ; r <= tnum; r++ {
+				tr.Remove(2 * r)
+				e := tr.Prev(2 * r)
+				assert.Equal(t, e.Key(), l*2)
+				assert.Equal(t, e.Value(), l*2)
+
+				e = tr.Prev(2 * (r + 1))
+				assert.Equal(t, e.Key(), l*2)
+				assert.Equal(t, e.Value(), l*2)
+			}
+			for ; l > 1; l-- {
+				tr.Remove(2 * l)
+				e := tr.Prev(2 * l)
+				assert.Equal(t, e.Key(), (l-1)*2)
+				assert.Equal(t, e.Value(), (l-1)*2)
+
+				e = tr.Prev(2 * (l + 1))
+				assert.Equal(t, e.Key(), (l-1)*2)
+				assert.Equal(t, e.Value(), (l-1)*2)
+			}
+			tr.Remove(2 * l)
+			assert.Equal(t, tr.Prev(r), nil)
+			assert.Equal(t, tr.Prev(l), nil)
+		})
+	}
+}
+
+func testNext(t *testing.T, newTree func() tree.Tree[int, int]) {
+	for _, num := range elementNums {
+		tnum := num
+		t.Run(fmt.Sprintf("[num:%d]", tnum), func(t *testing.T) {
+			tr := newTree()
+			assert.Equal(t, tr.Next(0), nil)
+			for i := 1; i <= tnum; i++ {
+				tr.Insert(2*i, 2*i)
+			}
+			assert.Equal(t, tr.Next(2*tnum), nil)
+			assert.Equal(t, tr.Next(2*tnum+1), nil)
+			for i := 1; i <= tnum-1; i++ {
+				e := tr.Next(2 * i)
+				assert.Equal(t, e.Key(), (i+1)*2)
+				assert.Equal(t, e.Value(), (i+1)*2)
+
+				e = tr.Next(2*i + 1)
+				assert.Equal(t, e.Key(), (i+1)*2)
+				assert.Equal(t, e.Value(), (i+1)*2)
+			}
+
+			l, r := (tnum+1)/2, (tnum+1)/2+1
+			for ; l >= 1 && r <= tnum; l-- {
+				tr.Remove(2 * l)
+				e := tr.Next(2 * l)
+				assert.Equal(t, e.Key(), r*2)
+				assert.Equal(t, e.Value(), r*2)
+
+				e = tr.Next((l - 1) * 2)
+				assert.Equal(t, e.Key(), r*2)
+				assert.Equal(t, e.Value(), r*2)
+			}
+			for ; r < tnum; r++ {
+				tr.Remove(2 * r)
+				e := tr.Next(2 * r)
+				assert.Equal(t, e.Key(), (r+1)*2)
+				assert.Equal(t, e.Value(), (r+1)*2)
+			}
+			tr.Remove(2 * r)
+			assert.Equal(t, tr.Prev(r), nil)
+			assert.Equal(t, tr.Prev(l), nil)
+		})
+	}
+}
+
+func testElementPrev(t *testing.T, newTree func() tree.Tree[int, int]) {
+	for _, num := range elementNums {
+		tnum := num
+		t.Run(fmt.Sprintf("[num:%d]", tnum), func(t *testing.T) {
+			tr := newTree()
+			for i := 1; i <= tnum; i++ {
+				tr.Insert(i, i)
+			}
+			for i := 1; i <= tnum; i++ {
+				e := tr.Find(i)
+				for j := i; j >= 1; j-- {
+					assert.Equal(t, e.Key(), j)
+					assert.Equal(t, e.Value(), j)
+					e = e.Prev()
+				}
+				assert.Equal(t, e, nil)
+			}
+		})
+	}
+}
+
+func testElementNext(t *testing.T, newTree func() tree.Tree[int, int]) {
+	for _, num := range elementNums {
+		tnum := num
+		t.Run(fmt.Sprintf("[num:%d]", tnum), func(t *testing.T) {
+			tr := newTree()
+			for i := 1; i <= tnum; i++ {
+				tr.Insert(i, i)
+			}
+			for i := 1; i <= tnum; i++ {
+				e := tr.Find(i)
+				for j := i; j <= tnum; j++ {
+					assert.Equal(t, e.Key(), j)
+					assert.Equal(t, e.Value(), j)
+					e = e.Next()
+				}
+				assert.Equal(t, e, nil)
+			}
+		})
+	}
+}
+
+func testElementSet(t *testing.T, newTree func() tree.Tree[int, int]) {
+	tr := newTree()
+	for i := 1; i <= 100; i++ {
+		tr.Insert(i, i)
+	}
+	for e := tr.Left(); e != nil; e = e.Next() {
+		e.SetValue(-e.Key())
+		assert.Equal(t, e.Value(), -e.Key())
+	}
+	for i := 1; i <= 100; i++ {
+		v, _ := tr.Get(i)
+		assert.Equal(t, v, -i)
+	}
+}
+
+func testAscendRange(t *testing.T, newTree func() tree.Tree[int, int]) {
+	for _, num := range rangeNums {
+		tnum := num
+		t.Run(fmt.Sprintf("[num:%d]", tnum), func(t *testing.T) {
+			tr, keys := newRangeTree(newTree, tnum)
+			for _, r := range rangePairs(tnum) {
+				for _, b := range bounds {
+					var got []int
+					tr.AscendRange(r[0], r[1], func(key, value int) bool {
+						assert.Equal(t, key, value)
+						got = append(got, key)
+						return true
+					}, b)
+					assert.Equal(t, fmt.Sprint(got), fmt.Sprint(expectRange(keys, r[0], r[1], b)))
+				}
+			}
+			var got []int
+			tr.AscendRange(0, 3*tnum+1, func(key, value int) bool {
+				got = append(got, key)
+				return len(got) < 3
+			})
+			assert.Equal(t, fmt.Sprint(got), fmt.Sprint(firstN(keys, 3)))
+		})
+	}
+}
+
+func testDescendRange(t *testing.T, newTree func() tree.Tree[int, int]) {
+	for _, num := range rangeNums {
+		tnum := num
+		t.Run(fmt.Sprintf("[num:%d]", tnum), func(t *testing.T) {
+			tr, keys := newRangeTree(newTree, tnum)
+			for _, r := range rangePairs(tnum) {
+				for _, b := range bounds {
+					var got []int
+					tr.DescendRange(r[0], r[1], func(key, value int) bool {
+						assert.Equal(t, key, value)
+						got = append(got, key)
+						return true
+					}, b)
+					assert.Equal(t, fmt.Sprint(got), fmt.Sprint(reverse(expectRange(keys, r[0], r[1], b))))
+				}
+			}
+			var got []int
+			tr.DescendRange(0, 3*tnum+1, func(key, value int) bool {
+				got = append(got, key)
+				return len(got) < 3
+			})
+			assert.Equal(t, fmt.Sprint(got), fmt.Sprint(firstN(reverse(keys), 3)))
+		})
+	}
+}
+
+func testAscendGreaterOrEqual(t *testing.T, newTree func() tree.Tree[int, int]) {
+	for _, num := range rangeNums {
+		tnum := num
+		t.Run(fmt.Sprintf("[num:%d]", tnum), func(t *testing.T) {
+			tr, keys := newRangeTree(newTree, tnum)
+			for lo := -1; lo <= 3*tnum+1; lo++ {
+				var got []int
+				tr.AscendGreaterOrEqual(lo, func(key, value int) bool {
+					got = append(got, key)
+					return true
+				})
+				assert.Equal(t, fmt.Sprint(got), fmt.Sprint(expectRange(keys, lo, 3*tnum+1, tree.Closed)))
+			}
+		})
+	}
+}
+
+func testDescendLessOrEqual(t *testing.T, newTree func() tree.Tree[int, int]) {
+	for _, num := range rangeNums {
+		tnum := num
+		t.Run(fmt.Sprintf("[num:%d]", tnum), func(t *testing.T) {
+			tr, keys := newRangeTree(newTree, tnum)
+			for hi := -1; hi <= 3*tnum+1; hi += 1 + tnum/64 {
+				var got []int
+				tr.DescendLessOrEqual(hi, func(key, value int) bool {
+					got = append(got, key)
+					return true
+				})
+				assert.Equal(t, fmt.Sprint(got), fmt.Sprint(reverse(expectRange(keys, -1, hi, tree.Closed))))
+			}
+		})
+	}
+}
+
+func testRankSelect(t *testing.T, newTree func() tree.Tree[int, int]) {
+	for _, num := range rangeNums {
+		tnum := num
+		t.Run(fmt.Sprintf("[num:%d]", tnum), func(t *testing.T) {
+			tr, keys := newRangeTree(newTree, tnum)
+			expectRankSelect(t, tr, keys, 3*tnum)
+		})
+	}
+}
+
+func testRankSelectRandom(t *testing.T, newTree func() tree.Tree[int, int]) {
+	for _, num := range []int{8, 64, 1024} {
+		tnum := num
+		t.Run(fmt.Sprintf("[num:%d]", tnum), func(t *testing.T) {
+			tr := newTree()
+			exist := map[int]bool{}
+			for i := 0; i < 4*tnum; i++ {
+				key := rand.Intn(tnum)
+				if rand.Intn(3) == 0 {
+					tr.Remove(key)
+					delete(exist, key)
+				} else {
+					tr.Insert(key, key)
+					exist[key] = true
+				}
+				if i%(1+tnum/16) == 0 {
+					expectRankSelect(t, tr, sortedKeys(exist), tnum)
+				}
+			}
+			expectRankSelect(t, tr, sortedKeys(exist), tnum)
+		})
+	}
+}
+
+func testIterator(t *testing.T, newTree func() tree.Tree[int, int]) {
+	if _, ok := newTree().(tree.Iterable[int, int]); !ok {
+		t.Skip("not tree.Iterable")
+	}
+	for _, num := range rangeNums {
+		tnum := num
+		t.Run(fmt.Sprintf("[num:%d]", tnum), func(t *testing.T) {
+			tr, keys := newRangeTree(newTree, tnum)
+			it := tr.(tree.Iterable[int, int]).Iterator()
+			var got []int
+			for ok := it.First(); ok; ok = it.Next() {
+				assert.Equal(t, it.Key(), it.Value())
+				got = append(got, it.Key())
+			}
+			assert.Equal(t, fmt.Sprint(got), fmt.Sprint(keys))
+			assert.Equal(t, it.Valid(), false)
+			assert.Equal(t, it.Next(), false)
+			assert.Equal(t, it.Prev(), false)
+
+			got = got[:0]
+			for ok := it.Last(); ok; ok = it.Prev() {
+				got = append(got, it.Key())
+			}
+			assert.Equal(t, fmt.Sprint(got), fmt.Sprint(reverse(keys)))
+		})
+	}
+}
+
+func testIteratorSeek(t *testing.T, newTree func() tree.Tree[int, int]) {
+	if _, ok := newTree().(tree.Iterable[int, int]); !ok {
+		t.Skip("not tree.Iterable")
+	}
+	for _, num := range rangeNums {
+		tnum := num
+		t.Run(fmt.Sprintf("[num:%d]", tnum), func(t *testing.T) {
+			tr, keys := newRangeTree(newTree, tnum)
+			it := tr.(tree.Iterable[int, int]).Iterator()
+			for key := -1; key <= 3*tnum+1; key++ {
+				idx := sort.SearchInts(keys, key)
+				assert.Equal(t, it.Seek(key), idx < len(keys))
+				if idx == len(keys) {
+					continue
+				}
+				assert.Equal(t, it.Key(), keys[idx])
+				if it.Next() {
+					assert.Equal(t, it.Key(), keys[idx+1])
+					assert.Equal(t, it.Prev(), true)
+				} else {
+					assert.Equal(t, idx, len(keys)-1)
+					it.Seek(key)
+				}
+				if it.Prev() {
+					assert.Equal(t, it.Key(), keys[idx-1])
+				} else {
+					assert.Equal(t, idx, 0)
+				}
+			}
+		})
+	}
+}
+
+type binaryTree interface {
+	tree.Tree[int, int]
+	encoding.BinaryMarshaler
+	encoding.BinaryUnmarshaler
+}
+
+func testMarshalBinary(t *testing.T, newTree func() tree.Tree[int, int]) {
+	if _, ok := newTree().(binaryTree); !ok {
+		t.Skip("not encoding.BinaryMarshaler")
+	}
+	for _, num := range []int{0, 1, 1000} {
+		tnum := num
+		t.Run(fmt.Sprintf("[num:%d]", tnum), func(t *testing.T) {
+			tr := newTree().(binaryTree)
+			for i := 0; i < tnum; i++ {
+				tr.Insert(rand.Intn(tnum), i)
+			}
+			data, err := tr.MarshalBinary()
+			assert.Equal(t, err, nil)
+			tr2 := newTree().(binaryTree)
+			tr2.Insert(-1, -1)
+			assert.Equal(t, tr2.UnmarshalBinary(data), nil)
+			assert.Equal(t, Dump[int, int](tr2), Dump[int, int](tr))
+			assert.Equal(t, tr2.Size(), tr.Size())
+			if tnum > 0 {
+				assert.NotEqual(t, tr2.UnmarshalBinary(data[:len(data)-1]), nil)
+				assert.Equal(t, tr2.Size(), 0)
+			}
+		})
+	}
+}
+
+// Dump 按顺序输出树中的元素，用于比较两棵树
+func Dump[K, V any](tr tree.Tree[K, V]) string {
+	s := []string{}
+	for e := tr.Left(); e != nil; e = e.Next() {
+		s = append(s, fmt.Sprint(e.Key(), ":", e.Value()))
+	}
+	return fmt.Sprint(s)
+}
+
+// 乱序插入[1, 3*num]，再删除3的倍数
+func newRangeTree(newTree func() tree.Tree[int, int], num int) (tree.Tree[int, int], []int) {
+	tr := newTree()
+	for _, k := range rand.Perm(3 * num) {
+		tr.Insert(k+1, k+1)
+	}
+	var keys []int
+	for i := 1; i <= 3*num; i++ {
+		if i%3 == 0 {
+			tr.Remove(i)
+		} else {
+			keys = append(keys, i)
+		}
+	}
+	return tr, keys
+}
+
+func rangePairs(num int) [][2]int {
+	var pairs [][2]int
+	if num <= 9 {
+		for lo := -1; lo <= 3*num+1; lo++ {
+			for hi := -1; hi <= 3*num+1; hi++ {
+				pairs = append(pairs, [2]int{lo, hi})
+			}
+		}
+		return pairs
+	}
+	for i := 0; i < 512; i++ {
+		lo := rand.Intn(3*num+2) - 1
+		pairs = append(pairs, [2]int{lo, lo + rand.Intn(64)})
+	}
+	return pairs
+}
+
+func expectRange(keys []int, lo, hi int, b tree.Bound) []int {
+	var ret []int
+	for _, k := range keys {
+		if (k > lo || k == lo && b.LoInclusive()) && (k < hi || k == hi && b.HiInclusive()) {
+			ret = append(ret, k)
+		}
+	}
+	return ret
+}
+
+func reverse(keys []int) []int {
+	ret := make([]int, 0, len(keys))
+	for i := len(keys) - 1; i >= 0; i-- {
+		ret = append(ret, keys[i])
+	}
+	return ret
+}
+
+func firstN(keys []int, n int) []int {
+	if len(keys) < n {
+		return keys
+	}
+	return keys[:n]
+}
+
+func expectRankSelect(t *testing.T, tr tree.Tree[int, int], keys []int, maxKey int) {
+	for key := -1; key <= maxKey+1; key++ {
+		assert.Equal(t, tr.Rank(key), sort.SearchInts(keys, key))
+	}
+	assert.Equal(t, tr.Select(-1), nil)
+	assert.Equal(t, tr.Select(len(keys)), nil)
+	for k, key := range keys {
+		e := tr.Select(k)
+		assert.Equal(t, e.Key(), key)
+		assert.Equal(t, e.Value(), key)
+	}
+}
+
+func sortedKeys(exist map[int]bool) []int {
+	keys := make([]int, 0, len(exist))
+	for k := range exist {
+		keys = append(keys, k)
+	}
+	sort.Ints(keys)
+	return keys
+}
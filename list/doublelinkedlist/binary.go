@@ -0,0 +1,69 @@
+// Copyright (c) 2025 Tian ChunXing. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package doublelinkedlist
+
+import (
+	"bytes"
+	"io"
+
+	"github.com/mrtcx/plusdata/codec"
+)
+
+// SetCodec 设置编解码元素的codec，元素为interface{}，需要通过codec.Any转换，如codec.Any[int](codec.IntCodec{})
+func (l *List) SetCodec(c codec.Codec[interface{}]) {
+	l.codec = c
+}
+
+// Encode 从头到尾把元素逐个编码写入w
+func (l *List) Encode(w io.Writer) error {
+	if l.codec == nil {
+		return codec.ErrNoCodec
+	}
+	enc := codec.NewEncoder(w, codec.KindList, l.Size())
+	for e := l.Front(); e != nil; e = e.Next() {
+		codec.Write(enc, l.codec, e.Value)
+	}
+	return enc.Close()
+}
+
+// Decode 清空后从r中解码，出错时容器为空
+func (l *List) Decode(r io.Reader) error {
+	l.Clean()
+	if err := l.decode(r); err != nil {
+		l.Clean()
+		return err
+	}
+	return nil
+}
+
+func (l *List) decode(r io.Reader) error {
+	if l.codec == nil {
+		return codec.ErrNoCodec
+	}
+	dec, n, err := codec.NewDecoder(r, codec.KindList)
+	if err != nil {
+		return err
+	}
+	for i := 0; i < n; i++ {
+		v, err := codec.Read(dec, l.codec)
+		if err != nil {
+			return err
+		}
+		l.PushBack(v)
+	}
+	return dec.Close()
+}
+
+func (l *List) MarshalBinary() ([]byte, error) {
+	var buf bytes.Buffer
+	if err := l.Encode(&buf); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func (l *List) UnmarshalBinary(data []byte) error {
+	return l.Decode(bytes.NewReader(data))
+}
@@ -0,0 +1,43 @@
+// Copyright (c) 2025 Tian ChunXing. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package doublelinkedlist
+
+import (
+	"testing"
+
+	"github.com/mrtcx/plusdata/codec"
+)
+
+func TestMarshalBinary(t *testing.T) {
+	l := New()
+	if _, err := l.MarshalBinary(); err != codec.ErrNoCodec {
+		t.Errorf("Expected ErrNoCodec, got %v", err)
+	}
+	l.SetCodec(codec.Any[string](codec.StringCodec{}))
+	for _, v := range []string{"a", "bb", "", "ccc"} {
+		l.PushBack(v)
+	}
+	data, err := l.MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+	l2 := New()
+	l2.SetCodec(codec.Any[string](codec.StringCodec{}))
+	l2.PushBack("x")
+	if err := l2.UnmarshalBinary(data); err != nil {
+		t.Fatal(err)
+	}
+	if l2.Size() != 4 {
+		t.Errorf("Expected size 4, got %d", l2.Size())
+	}
+	for e, e2 := l.Front(), l2.Front(); e != nil; e, e2 = e.Next(), e2.Next() {
+		if e.Value != e2.Value {
+			t.Errorf("Expected %v, got %v", e.Value, e2.Value)
+		}
+	}
+	if err := l2.UnmarshalBinary(data[:len(data)-1]); err == nil || l2.Size() != 0 {
+		t.Errorf("Expected error and empty list, got %v %d", err, l2.Size())
+	}
+}
@@ -4,9 +4,12 @@
 
 package doublelinkedlist
 
+import "github.com/mrtcx/plusdata/codec"
+
 type List struct {
-	head *Element
-	size int
+	head  *Element
+	size  int
+	codec codec.Codec[interface{}]
 }
 
 type Element struct {
@@ -45,6 +48,10 @@ func New() *List {
 	return l
 }
 
+func (l *List) Clean() {
+	l.head, l.size = sentry(l), 0
+}
+
 func (l *List) Size() int {
 	return l.size
 }
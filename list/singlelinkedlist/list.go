@@ -4,10 +4,13 @@
 
 package singlelinkedlist
 
+import "github.com/mrtcx/plusdata/codec"
+
 type List struct {
-	head *Element
-	tail *Element
-	size int
+	head  *Element
+	tail  *Element
+	size  int
+	codec codec.Codec[interface{}]
 }
 
 type Element struct {
@@ -0,0 +1,139 @@
+// Copyright (c) 2025 Tian ChunXing. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package set
+
+import (
+	"github.com/mrtcx/plusdata/tree"
+	"github.com/mrtcx/plusdata/tree/rbtree"
+)
+
+// keyRange 集合运算限定的区间，nil为全部的key
+type keyRange[K any] struct {
+	lo, hi K
+	bound  tree.Bound
+}
+
+func newKeyRange[K any](lo, hi K, bound []tree.Bound) *keyRange[K] {
+	return &keyRange[K]{lo: lo, hi: hi, bound: tree.RangeBound(bound)}
+}
+
+func ascend[K any](s Set[K], r *keyRange[K], fn func(key K) bool) {
+	if r == nil {
+		s.Ascend(fn)
+	} else {
+		s.AscendRange(r.lo, r.hi, fn, r.bound)
+	}
+}
+
+func collect[K any](s Set[K], r *keyRange[K]) []K {
+	var keys []K
+	ascend(s, r, func(key K) bool {
+		keys = append(keys, key)
+		return true
+	})
+	return keys
+}
+
+func (s *treeSet[K]) Union(other Set[K]) Set[K] {
+	return s.merge(other, nil, true, true, true)
+}
+
+func (s *treeSet[K]) Intersection(other Set[K]) Set[K] {
+	return s.merge(other, nil, false, false, true)
+}
+
+func (s *treeSet[K]) Difference(other Set[K]) Set[K] {
+	return s.merge(other, nil, true, false, false)
+}
+
+func (s *treeSet[K]) SymmetricDifference(other Set[K]) Set[K] {
+	return s.merge(other, nil, true, true, false)
+}
+
+func (s *treeSet[K]) IsSubset(other Set[K]) bool {
+	if s.Size() > other.Size() {
+		return false
+	}
+	return s.isSubset(other, nil)
+}
+
+func (s *treeSet[K]) UnionRange(other Set[K], lo, hi K, bound ...tree.Bound) Set[K] {
+	return s.merge(other, newKeyRange(lo, hi, bound), true, true, true)
+}
+
+func (s *treeSet[K]) IntersectionRange(other Set[K], lo, hi K, bound ...tree.Bound) Set[K] {
+	return s.merge(other, newKeyRange(lo, hi, bound), false, false, true)
+}
+
+func (s *treeSet[K]) DifferenceRange(other Set[K], lo, hi K, bound ...tree.Bound) Set[K] {
+	return s.merge(other, newKeyRange(lo, hi, bound), true, false, false)
+}
+
+func (s *treeSet[K]) SymmetricDifferenceRange(other Set[K], lo, hi K, bound ...tree.Bound) Set[K] {
+	return s.merge(other, newKeyRange(lo, hi, bound), true, true, false)
+}
+
+func (s *treeSet[K]) IsSubsetRange(other Set[K], lo, hi K, bound ...tree.Bound) bool {
+	return s.isSubset(other, newKeyRange(lo, hi, bound))
+}
+
+// 归并s和other在区间r内的key，onlyS、onlyOther、both分别为是否保留只在s中、只在other中、两者都有的key。
+// 归并为O(N+M)，得到的key有序且不重复，再由fromSorted构建结果
+func (s *treeSet[K]) merge(other Set[K], r *keyRange[K], onlyS, onlyOther, both bool) Set[K] {
+	others := collect(other, r)
+	var keys []K
+	j := 0
+	ascend[K](s, r, func(key K) bool {
+		for ; j < len(others) && s.cmp(others[j], key) < 0; j++ {
+			if onlyOther {
+				keys = append(keys, others[j])
+			}
+		}
+		if j < len(others) && s.cmp(others[j], key) == 0 {
+			if both {
+				keys = append(keys, key)
+			}
+			j++
+		} else if onlyS {
+			keys = append(keys, key)
+		}
+		return true
+	})
+	if onlyOther {
+		keys = append(keys, others[j:]...)
+	}
+	return s.fromSorted(keys)
+}
+
+// 由严格递增的keys构建集合，默认的红黑树通过BulkLoad直接构建，O(K)；其他的树逐个插入，O(KlogK)
+func (s *treeSet[K]) fromSorted(keys []K) *treeSet[K] {
+	res := &treeSet[K]{cmp: s.cmp, newTree: s.newTree, bulk: s.bulk}
+	if s.bulk {
+		if rb, err := rbtree.BulkLoad(s.cmp, keys, make([]struct{}, len(keys))); err == nil {
+			res.t = rb
+			return res
+		}
+	}
+	res.t = s.newTree()
+	for _, key := range keys {
+		res.t.Insert(key, struct{}{})
+	}
+	return res
+}
+
+func (s *treeSet[K]) isSubset(other Set[K], r *keyRange[K]) bool {
+	others := collect(other, r)
+	j, subset := 0, true
+	ascend[K](s, r, func(key K) bool {
+		for j < len(others) && s.cmp(others[j], key) < 0 {
+			j++
+		}
+		if j == len(others) || s.cmp(others[j], key) != 0 {
+			subset = false
+		}
+		return subset
+	})
+	return subset
+}
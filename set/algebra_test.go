@@ -0,0 +1,120 @@
+// Copyright (c) 2025 Tian ChunXing. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package set
+
+import (
+	"fmt"
+	"math/rand"
+	"testing"
+
+	"github.com/mrtcx/plusdata/internal/assert"
+	"github.com/mrtcx/plusdata/tree"
+	"github.com/mrtcx/plusdata/tree/avltree"
+)
+
+func TestAlgebra(t *testing.T) {
+	nums := []int{0, 1, 8, 64, 1024}
+	for _, num := range nums {
+		tnum := num
+		t.Run(fmt.Sprintf("[num:%d]", tnum), func(t *testing.T) {
+			sets := newSets()
+			a, b := sets["rbtree"], sets["skiplist"]
+			inA, inB := map[int]bool{}, map[int]bool{}
+			for i := 0; i < tnum; i++ {
+				ka, kb := rand.Intn(2*tnum), rand.Intn(2*tnum)
+				a.Add(ka)
+				b.Add(kb)
+				inA[ka], inB[kb] = true, true
+			}
+			all := func(key int) bool { return true }
+			expectAlgebra(t, a, b, inA, inB, all)
+			expectAlgebra(t, b, a, inB, inA, all)
+			expectAlgebra(t, a, a, inA, inA, all)
+			assert.Equal(t, a.IsSubset(a.Union(b)), true)
+			assert.Equal(t, a.Intersection(b).IsSubset(b), true)
+
+			for _, bound := range []tree.Bound{tree.ClosedOpen, tree.Closed, tree.OpenClosed, tree.Open} {
+				lo, hi := rand.Intn(2*tnum+1), rand.Intn(2*tnum+1)
+				inRange := func(key int) bool {
+					return (key > lo || key == lo && bound.LoInclusive()) && (key < hi || key == hi && bound.HiInclusive())
+				}
+				expectKeys(t, a.UnionRange(b, lo, hi, bound), filter(inRange, func(key int) bool { return inA[key] || inB[key] }))
+				expectKeys(t, a.IntersectionRange(b, lo, hi, bound), filter(inRange, func(key int) bool { return inA[key] && inB[key] }))
+				expectKeys(t, a.DifferenceRange(b, lo, hi, bound), filter(inRange, func(key int) bool { return inA[key] && !inB[key] }))
+				expectKeys(t, a.SymmetricDifferenceRange(b, lo, hi, bound), filter(inRange, func(key int) bool { return inA[key] != inB[key] }))
+				subset := len(filter(inRange, func(key int) bool { return inA[key] && !inB[key] })) == 0
+				assert.Equal(t, a.IsSubsetRange(b, lo, hi, bound), subset)
+				assert.Equal(t, a.IntersectionRange(b, lo, hi, bound).IsSubsetRange(b, lo, hi, bound), true)
+			}
+		})
+	}
+}
+
+func TestResultTree(t *testing.T) {
+	a := New[int](avltree.New[int, struct{}](intcmp), intcmp, func() tree.Tree[int, struct{}] {
+		return avltree.New[int, struct{}](intcmp)
+	})
+	b := newSets()["bplustree"]
+	a.Add(1, 2, 3)
+	b.Add(2, 3, 4)
+	u := a.Union(b)
+	_, ok := u.Tree().(tree.Iterable[int, struct{}])
+	assert.Equal(t, ok, true)
+	expectKeys(t, u, []int{1, 2, 3, 4})
+	// 结果的运算继续使用相同的newTree
+	_, ok = u.Difference(b).Tree().(tree.Iterable[int, struct{}])
+	assert.Equal(t, ok, true)
+	expectKeys(t, u.Difference(b), []int{1})
+	expectKeys(t, b.SymmetricDifference(a), []int{1, 4})
+	assert.Equal(t, a.IsSubset(b), false)
+	assert.Equal(t, a.Intersection(b).IsSubset(b), true)
+}
+
+// 默认的红黑树由有序的结果直接构建，之后可以继续添加和删除
+func TestResultBulkLoad(t *testing.T) {
+	a, b := newSets()["rbtree"], newSets()["skiplist"]
+	for i := 0; i < 1000; i++ {
+		a.Add(2 * i)
+		b.Add(3 * i)
+	}
+	u := a.Union(b)
+	assert.Equal(t, u.Size(), 1000+1000-334)
+	for i := 0; i < 3000; i++ {
+		u.Add(i)
+	}
+	for i := 0; i < 3000; i += 2 {
+		u.Remove(i)
+	}
+	var wanted []int
+	for i := 1; i < 3000; i += 2 {
+		wanted = append(wanted, i)
+	}
+	expectKeys(t, u, wanted)
+	expectKeys(t, a.Intersection(b).Intersection(newSets()["rbtree"]), nil)
+}
+
+func expectAlgebra(t *testing.T, a, b Set[int], inA, inB map[int]bool, inRange func(key int) bool) {
+	expectKeys(t, a.Union(b), filter(inRange, func(key int) bool { return inA[key] || inB[key] }))
+	expectKeys(t, a.Intersection(b), filter(inRange, func(key int) bool { return inA[key] && inB[key] }))
+	expectKeys(t, a.Difference(b), filter(inRange, func(key int) bool { return inA[key] && !inB[key] }))
+	expectKeys(t, a.SymmetricDifference(b), filter(inRange, func(key int) bool { return inA[key] != inB[key] }))
+	subset := len(filter(inRange, func(key int) bool { return inA[key] && !inB[key] })) == 0
+	assert.Equal(t, a.IsSubset(b), subset)
+}
+
+// [-1, 4096]中满足全部条件的key，按顺序返回
+func filter(conds ...func(key int) bool) []int {
+	var keys []int
+	for key := -1; key <= 4096; key++ {
+		keep := true
+		for _, cond := range conds {
+			keep = keep && cond(key)
+		}
+		if keep {
+			keys = append(keys, key)
+		}
+	}
+	return keys
+}
@@ -0,0 +1,122 @@
+// Copyright (c) 2025 Tian ChunXing. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+// Package set 基于tree.Tree的有序集合
+//
+// 集合运算按顺序归并两个集合，复杂度为O(N+M)。结果默认为红黑树，由归并得到的有序key直接构建(rbtree.BulkLoad)，
+// 整个运算为O(N+M)；通过New的newTree指定其他的树时，结果的K个key逐个插入，为O(N+M+KlogK)。
+// 参与运算的两个集合需要使用相同的比较函数。
+package set
+
+import (
+	"github.com/mrtcx/plusdata/tree"
+	"github.com/mrtcx/plusdata/tree/rbtree"
+)
+
+type Set[K any] interface {
+	Size() int
+	Empty() bool
+	Clean()
+	Add(keys ...K)
+	Remove(keys ...K)
+	Contains(key K) bool
+	Keys() []K                                                      //按顺序返回全部的key
+	Ascend(fn func(key K) bool)                                     //正序遍历，fn返回false停止
+	AscendRange(lo, hi K, fn func(key K) bool, bound ...tree.Bound) //正序遍历区间内的key，默认[lo, hi)
+	Tree() tree.Tree[K, struct{}]                                   //底层的树
+
+	Union(other Set[K]) Set[K]               //并集
+	Intersection(other Set[K]) Set[K]        //交集
+	Difference(other Set[K]) Set[K]          //差集，在s中不在other中
+	SymmetricDifference(other Set[K]) Set[K] //对称差，只在其中一个集合中
+	IsSubset(other Set[K]) bool              //s是否为other的子集
+
+	// 只对区间内的key做运算，默认[lo, hi)
+	UnionRange(other Set[K], lo, hi K, bound ...tree.Bound) Set[K]
+	IntersectionRange(other Set[K], lo, hi K, bound ...tree.Bound) Set[K]
+	DifferenceRange(other Set[K], lo, hi K, bound ...tree.Bound) Set[K]
+	SymmetricDifferenceRange(other Set[K], lo, hi K, bound ...tree.Bound) Set[K]
+	IsSubsetRange(other Set[K], lo, hi K, bound ...tree.Bound) bool
+}
+
+var _ Set[int] = (*treeSet[int])(nil)
+
+type treeSet[K any] struct {
+	t       tree.Tree[K, struct{}]
+	cmp     tree.Comparator[K]
+	newTree func() tree.Tree[K, struct{}]
+	bulk    bool //newTree为默认的红黑树，集合运算的结果由有序的key直接构建
+}
+
+// New 以t作为存储的集合，cmp需要与t的比较函数相同。newTree用于创建集合运算结果的树，不指定时使用红黑树
+func New[K any](t tree.Tree[K, struct{}], cmp tree.Comparator[K], newTree ...func() tree.Tree[K, struct{}]) *treeSet[K] {
+	s := &treeSet[K]{t: t, cmp: cmp}
+	if len(newTree) > 0 && newTree[0] != nil {
+		s.newTree = newTree[0]
+	} else {
+		s.newTree = func() tree.Tree[K, struct{}] {
+			return rbtree.New[K, struct{}](cmp)
+		}
+		s.bulk = true
+	}
+	return s
+}
+
+func (s *treeSet[K]) Size() int {
+	return s.t.Size()
+}
+
+func (s *treeSet[K]) Empty() bool {
+	return s.t.Empty()
+}
+
+func (s *treeSet[K]) Clean() {
+	s.t.Clean()
+}
+
+func (s *treeSet[K]) Add(keys ...K) {
+	for _, key := range keys {
+		s.t.Insert(key, struct{}{})
+	}
+}
+
+func (s *treeSet[K]) Remove(keys ...K) {
+	for _, key := range keys {
+		s.t.Remove(key)
+	}
+}
+
+func (s *treeSet[K]) Contains(key K) bool {
+	_, ok := s.t.Get(key)
+	return ok
+}
+
+func (s *treeSet[K]) Keys() []K {
+	keys := make([]K, 0, s.t.Size())
+	s.Ascend(func(key K) bool {
+		keys = append(keys, key)
+		return true
+	})
+	return keys
+}
+
+func (s *treeSet[K]) Ascend(fn func(key K) bool) {
+	left := s.t.Left()
+	if left == nil {
+		return
+	}
+	s.t.AscendGreaterOrEqual(left.Key(), func(key K, _ struct{}) bool {
+		return fn(key)
+	})
+}
+
+func (s *treeSet[K]) AscendRange(lo, hi K, fn func(key K) bool, bound ...tree.Bound) {
+	s.t.AscendRange(lo, hi, func(key K, _ struct{}) bool {
+		return fn(key)
+	}, bound...)
+}
+
+func (s *treeSet[K]) Tree() tree.Tree[K, struct{}] {
+	return s.t
+}
@@ -0,0 +1,63 @@
+// Copyright (c) 2025 Tian ChunXing. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package set
+
+import (
+	"testing"
+
+	"github.com/mrtcx/plusdata/internal/assert"
+	"github.com/mrtcx/plusdata/tree"
+	"github.com/mrtcx/plusdata/tree/bplustree"
+	"github.com/mrtcx/plusdata/tree/rbtree"
+	"github.com/mrtcx/plusdata/tree/skiplist"
+)
+
+var intcmp = tree.OrderedComparator[int]
+
+func newSets() map[string]*treeSet[int] {
+	return map[string]*treeSet[int]{
+		"rbtree":    New[int](rbtree.New[int, struct{}](intcmp), intcmp),
+		"skiplist":  New[int](skiplist.New[int, struct{}](intcmp), intcmp),
+		"bplustree": New[int](bplustree.New[int, struct{}](intcmp, 8), intcmp),
+	}
+}
+
+func TestSet(t *testing.T) {
+	for name, s := range newSets() {
+		tname, ts := name, s
+		t.Run(tname, func(t *testing.T) {
+			assert.Equal(t, ts.Empty(), true)
+			ts.Add(3, 1, 2, 3)
+			assert.Equal(t, ts.Size(), 3)
+			assert.Equal(t, ts.Contains(2), true)
+			assert.Equal(t, ts.Contains(4), false)
+			expectKeys(t, ts, []int{1, 2, 3})
+			ts.Remove(2, 4)
+			expectKeys(t, ts, []int{1, 3})
+			var keys []int
+			ts.AscendRange(0, 3, func(key int) bool {
+				keys = append(keys, key)
+				return true
+			}, tree.Closed)
+			expectSlice(t, keys, []int{1, 3})
+			assert.Equal(t, ts.Tree().Size(), 2)
+			ts.Clean()
+			assert.Equal(t, ts.Empty(), true)
+			expectKeys(t, ts, nil)
+		})
+	}
+}
+
+func expectKeys(t *testing.T, s Set[int], wanted []int) {
+	assert.Equal(t, s.Size(), len(wanted))
+	expectSlice(t, s.Keys(), wanted)
+}
+
+func expectSlice(t *testing.T, got, wanted []int) {
+	assert.Equal(t, len(got), len(wanted))
+	for i := 0; i < len(got) && i < len(wanted); i++ {
+		assert.Equal(t, got[i], wanted[i])
+	}
+}
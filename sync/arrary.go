@@ -0,0 +1,105 @@
+// Copyright (c) 2025 Tian ChunXing. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package sync
+
+import (
+	"sync"
+
+	"github.com/mrtcx/plusdata/arrary"
+)
+
+var _ arrary.Arrary[int] = (*syncArrary[int])(nil)
+
+type syncArrary[T any] struct {
+	mu sync.RWMutex
+	a  arrary.Arrary[T]
+}
+
+func NewSyncArrary[T any](a arrary.Arrary[T]) *syncArrary[T] {
+	return &syncArrary[T]{a: a}
+}
+
+// Snapshot 按下标顺序复制全部元素
+func (s *syncArrary[T]) Snapshot() []T {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return snapshot[T](s.a)
+}
+
+// Range 在快照上按下标顺序调用fn，fn返回false时停止
+func (s *syncArrary[T]) Range(fn func(index int, value T) bool) {
+	for i, v := range s.Snapshot() {
+		if !fn(i, v) {
+			return
+		}
+	}
+}
+
+func (s *syncArrary[T]) Size() int {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.a.Size()
+}
+
+func (s *syncArrary[T]) Empty() bool {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.a.Empty()
+}
+
+func (s *syncArrary[T]) Clean() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.a.Clean()
+}
+
+func (s *syncArrary[T]) Get(index int) T {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.a.Get(index)
+}
+
+func (s *syncArrary[T]) Set(index int, value T) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.a.Set(index, value)
+}
+
+func (s *syncArrary[T]) Front() T {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.a.Front()
+}
+
+func (s *syncArrary[T]) Back() T {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.a.Back()
+}
+
+func (s *syncArrary[T]) PushBack(value T) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.a.PushBack(value)
+}
+
+func (s *syncArrary[T]) PopBack() T {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.a.PopBack()
+}
+
+type indexed[T any] interface {
+	Size() int
+	Get(int) T
+}
+
+func snapshot[T any](a indexed[T]) []T {
+	values := make([]T, a.Size())
+	for i := range values {
+		values[i] = a.Get(i)
+	}
+	return values
+}
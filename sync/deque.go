@@ -0,0 +1,104 @@
+// Copyright (c) 2025 Tian ChunXing. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package sync
+
+import (
+	"sync"
+
+	"github.com/mrtcx/plusdata/deque"
+)
+
+var _ deque.Deque[int] = (*syncDeque[int])(nil)
+
+type syncDeque[T any] struct {
+	mu sync.RWMutex
+	d  deque.Deque[T]
+}
+
+func NewSyncDeque[T any](d deque.Deque[T]) *syncDeque[T] {
+	return &syncDeque[T]{d: d}
+}
+
+// Snapshot 从头到尾复制全部元素
+func (s *syncDeque[T]) Snapshot() []T {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return snapshot[T](s.d)
+}
+
+// Range 在快照上从头到尾调用fn，fn返回false时停止
+func (s *syncDeque[T]) Range(fn func(index int, value T) bool) {
+	for i, v := range s.Snapshot() {
+		if !fn(i, v) {
+			return
+		}
+	}
+}
+
+func (s *syncDeque[T]) Size() int {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.d.Size()
+}
+
+func (s *syncDeque[T]) Empty() bool {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.d.Empty()
+}
+
+func (s *syncDeque[T]) Clean() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.d.Clean()
+}
+
+func (s *syncDeque[T]) Get(index int) T {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.d.Get(index)
+}
+
+func (s *syncDeque[T]) Set(index int, value T) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.d.Set(index, value)
+}
+
+func (s *syncDeque[T]) Front() T {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.d.Front()
+}
+
+func (s *syncDeque[T]) Back() T {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.d.Back()
+}
+
+func (s *syncDeque[T]) PushBack(value T) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.d.PushBack(value)
+}
+
+func (s *syncDeque[T]) PopBack() T {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.d.PopBack()
+}
+
+func (s *syncDeque[T]) PushFront(value T) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.d.PushFront(value)
+}
+
+func (s *syncDeque[T]) PopFront() T {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.d.PopFront()
+}
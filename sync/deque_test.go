@@ -0,0 +1,130 @@
+// Copyright (c) 2025 Tian ChunXing. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package sync
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/mrtcx/plusdata/arrary/blockslices"
+	"github.com/mrtcx/plusdata/deque/circularblocks"
+	"github.com/mrtcx/plusdata/heap"
+	"github.com/mrtcx/plusdata/heap/arraryheap"
+	"github.com/mrtcx/plusdata/internal/assert"
+)
+
+func TestDeque(t *testing.T) {
+	d := NewSyncDeque[int](circularblocks.New[int]())
+	for i := 0; i < 100; i++ {
+		d.PushBack(i)
+		d.PushFront(-i)
+	}
+	assert.Equal(t, d.Size(), 200)
+	assert.Equal(t, d.Front(), -99)
+	assert.Equal(t, d.Back(), 99)
+	d.Set(0, 1)
+	assert.Equal(t, d.Get(0), 1)
+	assert.Equal(t, d.PopFront(), 1)
+	assert.Equal(t, d.PopBack(), 99)
+
+	// 回调中修改不影响快照
+	n := 0
+	d.Range(func(index, value int) bool {
+		assert.Equal(t, index, n)
+		n++
+		d.PopFront()
+		return true
+	})
+	assert.Equal(t, n, 198)
+	assert.Equal(t, d.Empty(), true)
+}
+
+func TestArrary(t *testing.T) {
+	a := NewSyncArrary[int](blockslices.New[int]())
+	for i := 0; i < 100; i++ {
+		a.PushBack(i)
+	}
+	assert.Equal(t, a.Front(), 0)
+	assert.Equal(t, a.PopBack(), 99)
+	values := a.Snapshot()
+	assert.Equal(t, len(values), 99)
+	a.Range(func(index, value int) bool {
+		a.Set(index, value*2)
+		return index < 10
+	})
+	assert.Equal(t, a.Get(10), 20)
+	assert.Equal(t, a.Get(11), 11)
+	assert.Equal(t, a.Back(), 98)
+	a.Clean()
+	assert.Equal(t, a.Size(), 0)
+}
+
+func TestHeap(t *testing.T) {
+	h := NewSyncHeap(arraryheap.New(heap.IntLess))
+	var wg sync.WaitGroup
+	for g := 0; g < 4; g++ {
+		wg.Add(1)
+		go func(g int) {
+			defer wg.Done()
+			for i := 0; i < 1000; i++ {
+				h.Push(g*1000 + i)
+			}
+		}(g)
+	}
+	wg.Wait()
+	assert.Equal(t, h.Size(), 4000)
+	assert.Equal(t, h.Top(), 0)
+
+	got := make([]int, 4)
+	for g := 0; g < 4; g++ {
+		wg.Add(1)
+		go func(g int) {
+			defer wg.Done()
+			prev := -1
+			for {
+				v, ok := h.TryPop()
+				if !ok {
+					return
+				}
+				// 每个协程取出的元素递增
+				assert.Greater(t, v.(int), prev)
+				prev = v.(int)
+				got[g]++
+			}
+		}(g)
+	}
+	wg.Wait()
+	assert.Equal(t, got[0]+got[1]+got[2]+got[3], 4000)
+	assert.Equal(t, h.Empty(), true)
+	h.Clean()
+}
+
+func TestDequeConcurrent(t *testing.T) {
+	d := NewSyncDeque[int](circularblocks.New[int]())
+	var wg sync.WaitGroup
+	for g := 0; g < 8; g++ {
+		wg.Add(1)
+		go func(g int) {
+			defer wg.Done()
+			for i := 0; i < 2000; i++ {
+				switch i % 4 {
+				case 0:
+					d.PushBack(i)
+				case 1:
+					d.PushFront(i)
+				case 2:
+					d.Range(func(index, value int) bool {
+						return index < 10
+					})
+				default:
+					d.PopBack()
+				}
+			}
+		}(g)
+	}
+	wg.Wait()
+	assert.Equal(t, d.Size(), 4000)
+	assert.Equal(t, len(d.Snapshot()), 4000)
+}
@@ -0,0 +1,68 @@
+// Copyright (c) 2025 Tian ChunXing. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package sync
+
+import (
+	"sync"
+
+	"github.com/mrtcx/plusdata/heap"
+)
+
+var _ heap.Heap = (*syncHeap)(nil)
+
+type syncHeap struct {
+	mu sync.RWMutex
+	h  heap.Heap
+}
+
+func NewSyncHeap(h heap.Heap) *syncHeap {
+	return &syncHeap{h: h}
+}
+
+func (s *syncHeap) Size() int {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.h.Size()
+}
+
+func (s *syncHeap) Empty() bool {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.h.Empty()
+}
+
+func (s *syncHeap) Clean() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.h.Clean()
+}
+
+func (s *syncHeap) Top() interface{} {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.h.Top()
+}
+
+func (s *syncHeap) Push(value interface{}) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.h.Push(value)
+}
+
+func (s *syncHeap) Pop() interface{} {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.h.Pop()
+}
+
+// TryPop 堆为空时返回false，避免先Empty再Pop之间被其他协程取走
+func (s *syncHeap) TryPop() (interface{}, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.h.Empty() {
+		return nil, false
+	}
+	return s.h.Pop(), true
+}
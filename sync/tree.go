@@ -0,0 +1,243 @@
+// Copyright (c) 2025 Tian ChunXing. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+// Package sync 为各种容器接口提供基于读写锁的并发安全包装
+//
+// 读操作持有读锁，可以并发执行；写操作持有写锁。被包装的容器的读操作不能修改内部状态，
+// 读操作会修改内部状态的树(如伸展树、磁盘b+树)使用NewSyncTreeExclusive，读写都持有写锁。
+// 遍历先在读锁内复制元素(快照)，释放锁后再调用回调，回调中可以继续读写容器。
+package sync
+
+import (
+	"sync"
+
+	"github.com/mrtcx/plusdata/tree"
+)
+
+var _ tree.Tree[int, int] = (*syncTree[int, int])(nil)
+
+// Entry 快照中的一个元素
+type Entry[K, V any] struct {
+	Key   K
+	Value V
+}
+
+type syncTree[K, V any] struct {
+	mu        sync.RWMutex
+	t         tree.Tree[K, V]
+	exclusive bool //读操作也持有写锁
+}
+
+func NewSyncTree[K, V any](t tree.Tree[K, V]) *syncTree[K, V] {
+	return &syncTree[K, V]{t: t}
+}
+
+// NewSyncTreeExclusive 读操作也持有写锁，读写互斥，用于读操作会修改内部状态的树，如伸展树、磁盘b+树
+func NewSyncTreeExclusive[K, V any](t tree.Tree[K, V]) *syncTree[K, V] {
+	return &syncTree[K, V]{t: t, exclusive: true}
+}
+
+func (s *syncTree[K, V]) rlock() {
+	if s.exclusive {
+		s.mu.Lock()
+	} else {
+		s.mu.RLock()
+	}
+}
+
+func (s *syncTree[K, V]) runlock() {
+	if s.exclusive {
+		s.mu.Unlock()
+	} else {
+		s.mu.RUnlock()
+	}
+}
+
+// View 持有读锁(exclusive时为写锁)执行fn，fn中只能读t，不能保存t返回的元素
+func (s *syncTree[K, V]) View(fn func(t tree.Tree[K, V])) {
+	s.rlock()
+	defer s.runlock()
+	fn(s.t)
+}
+
+// Update 持有写锁执行fn，用于需要原子执行的多个操作，如不存在时插入
+func (s *syncTree[K, V]) Update(fn func(t tree.Tree[K, V])) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	fn(s.t)
+}
+
+// Snapshot 按顺序复制全部元素
+func (s *syncTree[K, V]) Snapshot() []Entry[K, V] {
+	s.rlock()
+	defer s.runlock()
+	entries := make([]Entry[K, V], 0, s.t.Size())
+	for e := s.t.Left(); e != nil; e = e.Next() {
+		entries = append(entries, Entry[K, V]{Key: e.Key(), Value: e.Value()})
+	}
+	return entries
+}
+
+func (s *syncTree[K, V]) Size() int {
+	s.rlock()
+	defer s.runlock()
+	return s.t.Size()
+}
+
+func (s *syncTree[K, V]) Empty() bool {
+	s.rlock()
+	defer s.runlock()
+	return s.t.Empty()
+}
+
+func (s *syncTree[K, V]) Clean() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.t.Clean()
+}
+
+func (s *syncTree[K, V]) Insert(key K, value V) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.t.Insert(key, value)
+}
+
+func (s *syncTree[K, V]) Remove(key K) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.t.Remove(key)
+}
+
+func (s *syncTree[K, V]) Get(key K) (V, bool) {
+	s.rlock()
+	defer s.runlock()
+	return s.t.Get(key)
+}
+
+func (s *syncTree[K, V]) Find(key K) tree.Element[K, V] {
+	s.rlock()
+	defer s.runlock()
+	return s.wrap(s.t.Find(key))
+}
+
+func (s *syncTree[K, V]) Left() tree.Element[K, V] {
+	s.rlock()
+	defer s.runlock()
+	return s.wrap(s.t.Left())
+}
+
+func (s *syncTree[K, V]) Right() tree.Element[K, V] {
+	s.rlock()
+	defer s.runlock()
+	return s.wrap(s.t.Right())
+}
+
+func (s *syncTree[K, V]) Prev(key K) tree.Element[K, V] {
+	s.rlock()
+	defer s.runlock()
+	return s.wrap(s.t.Prev(key))
+}
+
+func (s *syncTree[K, V]) Next(key K) tree.Element[K, V] {
+	s.rlock()
+	defer s.runlock()
+	return s.wrap(s.t.Next(key))
+}
+
+func (s *syncTree[K, V]) Rank(key K) int {
+	s.rlock()
+	defer s.runlock()
+	return s.t.Rank(key)
+}
+
+func (s *syncTree[K, V]) Select(k int) tree.Element[K, V] {
+	s.rlock()
+	defer s.runlock()
+	return s.wrap(s.t.Select(k))
+}
+
+// AscendRange 先复制区间内的元素，再依次调用fn
+func (s *syncTree[K, V]) AscendRange(lo, hi K, fn func(key K, value V) bool, bound ...tree.Bound) {
+	s.each(func(collect func(key K, value V) bool) {
+		s.t.AscendRange(lo, hi, collect, bound...)
+	}, fn)
+}
+
+// DescendRange 先复制区间内的元素，再依次调用fn
+func (s *syncTree[K, V]) DescendRange(lo, hi K, fn func(key K, value V) bool, bound ...tree.Bound) {
+	s.each(func(collect func(key K, value V) bool) {
+		s.t.DescendRange(lo, hi, collect, bound...)
+	}, fn)
+}
+
+func (s *syncTree[K, V]) AscendGreaterOrEqual(lo K, fn func(key K, value V) bool) {
+	s.each(func(collect func(key K, value V) bool) {
+		s.t.AscendGreaterOrEqual(lo, collect)
+	}, fn)
+}
+
+func (s *syncTree[K, V]) DescendLessOrEqual(hi K, fn func(key K, value V) bool) {
+	s.each(func(collect func(key K, value V) bool) {
+		s.t.DescendLessOrEqual(hi, collect)
+	}, fn)
+}
+
+// 在读锁内通过scan复制元素，释放锁后调用fn
+func (s *syncTree[K, V]) each(scan func(collect func(key K, value V) bool), fn func(key K, value V) bool) {
+	var entries []Entry[K, V]
+	s.rlock()
+	scan(func(key K, value V) bool {
+		entries = append(entries, Entry[K, V]{Key: key, Value: value})
+		return true
+	})
+	s.runlock()
+	for _, e := range entries {
+		if !fn(e.Key, e.Value) {
+			return
+		}
+	}
+}
+
+// element 包装被包装树的元素，每个方法都加锁
+type element[K, V any] struct {
+	s *syncTree[K, V]
+	e tree.Element[K, V]
+}
+
+func (s *syncTree[K, V]) wrap(e tree.Element[K, V]) tree.Element[K, V] {
+	if e == nil {
+		return nil
+	}
+	return &element[K, V]{s: s, e: e}
+}
+
+func (e *element[K, V]) Key() K {
+	e.s.rlock()
+	defer e.s.runlock()
+	return e.e.Key()
+}
+
+func (e *element[K, V]) Value() V {
+	e.s.rlock()
+	defer e.s.runlock()
+	return e.e.Value()
+}
+
+func (e *element[K, V]) SetValue(value V) {
+	e.s.mu.Lock()
+	defer e.s.mu.Unlock()
+	e.e.SetValue(value)
+}
+
+func (e *element[K, V]) Next() tree.Element[K, V] {
+	e.s.rlock()
+	defer e.s.runlock()
+	return e.s.wrap(e.e.Next())
+}
+
+func (e *element[K, V]) Prev() tree.Element[K, V] {
+	e.s.rlock()
+	defer e.s.runlock()
+	return e.s.wrap(e.e.Prev())
+}
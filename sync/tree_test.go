@@ -0,0 +1,152 @@
+// Copyright (c) 2025 Tian ChunXing. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package sync
+
+import (
+	"math/rand"
+	"sync"
+	"testing"
+
+	"github.com/mrtcx/plusdata/internal/assert"
+	"github.com/mrtcx/plusdata/tree"
+	"github.com/mrtcx/plusdata/tree/avltree"
+	"github.com/mrtcx/plusdata/tree/rbtree"
+	"github.com/mrtcx/plusdata/tree/splaytree"
+)
+
+var intcmp = tree.OrderedComparator[int]
+
+func TestTree(t *testing.T) {
+	s := NewSyncTree[int, int](avltree.New[int, int](intcmp))
+	assert.Equal(t, s.Left(), nil)
+	for i := 1; i <= 100; i++ {
+		s.Insert(i*2, i)
+	}
+	assert.Equal(t, s.Size(), 100)
+	assert.Equal(t, s.Left().Key(), 2)
+	assert.Equal(t, s.Right().Key(), 200)
+	assert.Equal(t, s.Find(3), nil)
+	assert.Equal(t, s.Prev(5).Key(), 4)
+	assert.Equal(t, s.Next(4).Key(), 6)
+	assert.Equal(t, s.Rank(10), 4)
+	assert.Equal(t, s.Select(4).Key(), 10)
+
+	i := 0
+	for e := s.Left(); e != nil; e = e.Next() {
+		i++
+		assert.Equal(t, e.Key(), i*2)
+		e.SetValue(-i)
+	}
+	assert.Equal(t, i, 100)
+	v, ok := s.Get(20)
+	assert.Equal(t, ok, true)
+	assert.Equal(t, v, -10)
+
+	keys := []int{}
+	s.DescendRange(10, 20, func(key, value int) bool {
+		keys = append(keys, key)
+		return true
+	}, tree.Closed)
+	assert.Equal(t, len(keys), 6)
+	assert.Equal(t, keys[0], 20)
+
+	entries := s.Snapshot()
+	assert.Equal(t, len(entries), 100)
+	assert.Equal(t, entries[99].Key, 200)
+	assert.Equal(t, entries[99].Value, -100)
+
+	s.Update(func(t tree.Tree[int, int]) {
+		if _, ok := t.Get(1); !ok {
+			t.Insert(1, 1)
+		}
+	})
+	s.View(func(tr tree.Tree[int, int]) {
+		assert.Equal(t, tr.Size(), 101)
+	})
+	s.Remove(1)
+	s.Clean()
+	assert.Equal(t, s.Empty(), true)
+}
+
+// 遍历的回调中修改树不会死锁，遍历的是调用时的快照
+func TestRangeCallback(t *testing.T) {
+	s := NewSyncTree[int, int](rbtree.New[int, int](intcmp))
+	for i := 0; i < 100; i++ {
+		s.Insert(i, i)
+	}
+	n := 0
+	s.AscendGreaterOrEqual(0, func(key, value int) bool {
+		n++
+		s.Remove(key + 1)
+		s.Insert(key+1000, key)
+		return true
+	})
+	assert.Equal(t, n, 100)
+	assert.Equal(t, s.Size(), 101)
+
+	n = 0
+	s.DescendLessOrEqual(2000, func(key, value int) bool {
+		n++
+		s.Clean()
+		return n < 10
+	})
+	assert.Equal(t, n, 10)
+	assert.Equal(t, s.Empty(), true)
+}
+
+// 伸展树的读操作会调整树的结构，需要读写互斥
+var concurrentTrees = map[string]func() *syncTree[int, int]{
+	"avltree":   func() *syncTree[int, int] { return NewSyncTree[int, int](avltree.New[int, int](intcmp)) },
+	"splaytree": func() *syncTree[int, int] { return NewSyncTreeExclusive[int, int](splaytree.New[int, int](intcmp)) },
+}
+
+func TestTreeConcurrent(t *testing.T) {
+	for name, newTree := range concurrentTrees {
+		tname, s := name, newTree()
+		t.Run(tname, func(t *testing.T) {
+			testTreeConcurrent(t, s)
+		})
+	}
+}
+
+func testTreeConcurrent(t *testing.T, s *syncTree[int, int]) {
+	var wg sync.WaitGroup
+	for g := 0; g < 8; g++ {
+		wg.Add(1)
+		go func(g int) {
+			defer wg.Done()
+			r := rand.New(rand.NewSource(int64(g)))
+			for i := 0; i < 2000; i++ {
+				k := r.Intn(500)
+				switch r.Intn(6) {
+				case 0:
+					s.Remove(k)
+				case 1:
+					s.Insert(k, k)
+				case 2:
+					if e := s.Find(k); e != nil {
+						e.SetValue(k)
+						e.Next()
+					}
+				case 3:
+					s.AscendRange(k, k+20, func(key, value int) bool {
+						assert.Equal(t, key, value)
+						return true
+					})
+				default:
+					if v, ok := s.Get(k); ok {
+						assert.Equal(t, v, k)
+					}
+				}
+			}
+		}(g)
+	}
+	wg.Wait()
+	entries := s.Snapshot()
+	assert.Equal(t, len(entries), s.Size())
+	for i := 1; i < len(entries); i++ {
+		assert.Greater(t, entries[i].Key, entries[i-1].Key)
+	}
+}
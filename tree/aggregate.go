@@ -0,0 +1,25 @@
+// Copyright (c) 2025 Tian ChunXing. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package tree
+
+// Aggregator 子树聚合值的幺半群，如区间和、最小值、最大值
+// Identity为单位元，Combine需要满足结合律(不要求交换律，按key的顺序合并)，Lift由一个元素得到聚合值
+type Aggregator[K, V, A any] struct {
+	Identity A
+	Combine  func(a, b A) A
+	Lift     func(key K, value V) A
+}
+
+// AggregateTree 每个子树维护聚合值的树
+type AggregateTree[K, V, A any] interface {
+	Tree[K, V]
+	Aggregate(lo, hi K, bound ...Bound) A //区间内元素的聚合值，默认[lo, hi)
+	// SearchPrefix 第一个前缀(从最左端到该元素)聚合值满足pred的元素，不存在时返回nil
+	// pred需要单调：对某个前缀为true时，对更长的前缀也为true
+	SearchPrefix(pred func(agg A) bool) Element[K, V]
+	// AscendFilter 按顺序遍历聚合值满足pred的元素，聚合值不满足pred的子树整个跳过，fn返回false停止
+	// pred需要满足：子树中有元素满足pred时，子树的聚合值也满足pred，如聚合值为最大值、pred为大于某个值
+	AscendFilter(pred func(agg A) bool, fn func(key K, value V) bool)
+}
@@ -0,0 +1,216 @@
+// Copyright (c) 2025 Tian ChunXing. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package tree_test
+
+import (
+	"fmt"
+	"math/rand"
+	"sort"
+	"strconv"
+	"testing"
+
+	"github.com/mrtcx/plusdata/internal/assert"
+	"github.com/mrtcx/plusdata/tree"
+	"github.com/mrtcx/plusdata/tree/avltree"
+	"github.com/mrtcx/plusdata/tree/rbtree"
+)
+
+var sum = tree.Aggregator[int, int, int]{
+	Identity: 0,
+	Combine:  func(a, b int) int { return a + b },
+	Lift:     func(key, value int) int { return value },
+}
+
+// 字符串拼接不满足交换律，可以检查合并的顺序
+var concat = tree.Aggregator[int, int, string]{
+	Identity: "",
+	Combine:  func(a, b string) string { return a + b },
+	Lift:     func(key, value int) string { return strconv.Itoa(key) + "," },
+}
+
+var sumTrees = map[string]func() tree.AggregateTree[int, int, int]{
+	"avltree": func() tree.AggregateTree[int, int, int] { return avltree.NewAggregate(intcmp, sum) },
+	"rbtree":  func() tree.AggregateTree[int, int, int] { return rbtree.NewAggregate(intcmp, sum) },
+}
+
+var concatTrees = map[string]func() tree.AggregateTree[int, int, string]{
+	"avltree": func() tree.AggregateTree[int, int, string] { return avltree.NewAggregate(intcmp, concat) },
+	"rbtree":  func() tree.AggregateTree[int, int, string] { return rbtree.NewAggregate(intcmp, concat) },
+}
+
+func TestAggregate(t *testing.T) {
+	nums := []int{0, 1, 8, 64, 1024}
+	for name, newTree := range sumTrees {
+		for _, num := range nums {
+			tname, tnum, tr := name, num, newTree()
+			t.Run(fmt.Sprintf("[%s][num:%d]", tname, tnum), func(t *testing.T) {
+				values := map[int]int{}
+				for i := 0; i < 4*tnum; i++ {
+					key := rand.Intn(tnum)
+					switch rand.Intn(4) {
+					case 0:
+						tr.Remove(key)
+						delete(values, key)
+					case 1:
+						if e := tr.Find(key); e != nil {
+							e.SetValue(i)
+							values[key] = i
+						}
+					default:
+						tr.Insert(key, i)
+						values[key] = i
+					}
+				}
+				bounds := []tree.Bound{tree.ClosedOpen, tree.Closed, tree.OpenClosed, tree.Open}
+				for i := 0; i < 64; i++ {
+					lo, hi, b := rand.Intn(tnum+2)-1, rand.Intn(tnum+2)-1, bounds[rand.Intn(len(bounds))]
+					wanted := 0
+					for key, value := range values {
+						if (key > lo || key == lo && b.LoInclusive()) && (key < hi || key == hi && b.HiInclusive()) {
+							wanted += value
+						}
+					}
+					assert.Equal(t, tr.Aggregate(lo, hi, b), wanted)
+				}
+				expectSearchPrefix(t, tr, values)
+			})
+		}
+	}
+}
+
+func TestAggregateOrder(t *testing.T) {
+	for name, newTree := range concatTrees {
+		tname, tr := name, newTree()
+		t.Run(tname, func(t *testing.T) {
+			for _, key := range rand.Perm(256) {
+				tr.Insert(key, key)
+			}
+			for i := 0; i < 64; i++ {
+				lo, hi := rand.Intn(256), rand.Intn(256)
+				wanted := ""
+				for key := lo; key <= hi; key++ {
+					wanted += strconv.Itoa(key) + ","
+				}
+				assert.Equal(t, tr.Aggregate(lo, hi, tree.Closed), wanted)
+			}
+			e := tr.SearchPrefix(func(agg string) bool { return len(agg) >= len("0,1,2,") })
+			assert.Equal(t, e.Key(), 2)
+		})
+	}
+}
+
+func TestAggregateDup(t *testing.T) {
+	tr := rbtree.NewAggregate(intcmp, sum)
+	for i := 0; i < 1024; i++ {
+		tr.InsertDup(i%16, 1)
+	}
+	assert.Equal(t, tr.Aggregate(0, 16), 1024)
+	assert.Equal(t, tr.Aggregate(3, 3, tree.Closed), 64)
+	first, _ := tr.EqualRange(3)
+	first.SetValue(65)
+	assert.Equal(t, tr.Aggregate(3, 4), 128)
+	assert.Equal(t, tr.SearchPrefix(func(agg int) bool { return agg >= 4*64+1 }).Key(), 3)
+	tr.RemoveAll(3)
+	assert.Equal(t, tr.Aggregate(0, 16), 1024-64)
+}
+
+var maxValue = tree.Aggregator[int, int, int]{
+	Identity: -1,
+	Combine:  func(a, b int) int { return max(a, b) },
+	Lift:     func(key, value int) int { return value },
+}
+
+// 只遍历value大于threshold的元素，子树的最大值不超过threshold时跳过
+func TestAscendFilter(t *testing.T) {
+	trees := map[string]tree.AggregateTree[int, int, int]{
+		"avltree": avltree.NewAggregate(intcmp, maxValue),
+		"rbtree":  rbtree.NewAggregate(intcmp, maxValue),
+	}
+	for name, tr := range trees {
+		tname, ttr := name, tr
+		t.Run(tname, func(t *testing.T) {
+			values := map[int]int{}
+			for i := 0; i < 1024; i++ {
+				key, value := rand.Intn(2048), rand.Intn(1024)
+				ttr.Insert(key, value)
+				values[key] = value
+			}
+			keys := make([]int, 0, len(values))
+			for key := range values {
+				keys = append(keys, key)
+			}
+			sort.Ints(keys)
+			for _, threshold := range []int{-1, 0, 512, 1000, 1023} {
+				var wanted, got []int
+				for _, key := range keys {
+					if values[key] > threshold {
+						wanted = append(wanted, key)
+					}
+				}
+				ttr.AscendFilter(func(agg int) bool { return agg > threshold }, func(key, value int) bool {
+					got = append(got, key)
+					return true
+				})
+				assert.Equal(t, fmt.Sprint(got), fmt.Sprint(wanted))
+			}
+			n := 0
+			ttr.AscendFilter(func(agg int) bool { return agg >= 0 }, func(key, value int) bool {
+				n++
+				return n < 3
+			})
+			assert.Equal(t, n, 3)
+		})
+	}
+}
+
+// 取得元素后树被修改，SetValue仍然更新元素所在路径上的聚合值
+func TestAggregateSetValueAfterInsert(t *testing.T) {
+	for name, newTree := range sumTrees {
+		tname, tr := name, newTree()
+		t.Run(tname, func(t *testing.T) {
+			for key := 10; key <= 50; key += 10 {
+				tr.Insert(key, 1)
+			}
+			e := tr.Find(30)
+			tr.Insert(5, 1)
+			e.SetValue(100)
+			assert.Equal(t, tr.Aggregate(0, 50), 104)
+			assert.Equal(t, tr.Aggregate(0, 50, tree.Closed), 105)
+			tr.Remove(10)
+			e.SetValue(1)
+			assert.Equal(t, tr.Aggregate(0, 50, tree.Closed), 5)
+		})
+	}
+}
+
+// 前缀和>=threshold的第一个元素
+func expectSearchPrefix(t *testing.T, tr tree.AggregateTree[int, int, int], values map[int]int) {
+	keys := make([]int, 0, len(values))
+	total := 0
+	for key, value := range values {
+		keys = append(keys, key)
+		total += value
+	}
+	sort.Ints(keys)
+	for i := 0; i < 64; i++ {
+		threshold := rand.Intn(total + 2)
+		e := tr.SearchPrefix(func(agg int) bool { return agg >= threshold })
+		prefix, idx := 0, -1
+		for j, key := range keys {
+			prefix += values[key]
+			if prefix >= threshold {
+				idx = j
+				break
+			}
+		}
+		if idx < 0 {
+			assert.Equal(t, e, nil)
+			continue
+		}
+		assert.NotEqual(t, e, nil)
+		assert.Equal(t, e.Key(), keys[idx])
+		assert.Equal(t, tr.Rank(e.Key()), idx)
+	}
+}
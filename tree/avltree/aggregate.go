@@ -0,0 +1,127 @@
+// Copyright (c) 2025 Tian ChunXing. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package avltree
+
+import "github.com/mrtcx/plusdata/tree"
+
+var _ tree.AggregateTree[int, int, int] = (*aggTree[int, int, int])(nil)
+
+// augmenter 维护子树的聚合值，init在创建节点时调用，update在子树变化后调用
+type augmenter[K, V any] interface {
+	init(node *Node[K, V])
+	update(node *Node[K, V])
+}
+
+type monoid[K, V, A any] struct {
+	tree.Aggregator[K, V, A]
+}
+
+func (m *monoid[K, V, A]) init(node *Node[K, V]) {
+	agg := m.Lift(node.key, node.value)
+	node.agg = &agg
+}
+
+func (m *monoid[K, V, A]) update(node *Node[K, V]) {
+	*node.agg.(*A) = m.Combine(m.Combine(m.get(node.lchild), m.Lift(node.key, node.value)), m.get(node.rchild))
+}
+
+func (m *monoid[K, V, A]) get(node *Node[K, V]) A {
+	if node.size == 0 {
+		return m.Identity
+	}
+	return *node.agg.(*A)
+}
+
+// aggTree 带聚合的avl树，其余操作与avl树相同
+type aggTree[K, V, A any] struct {
+	*avlTree[K, V]
+	m *monoid[K, V, A]
+}
+
+// NewAggregate 每个子树维护agg定义的聚合值，插入、删除、旋转和Element.SetValue时一起更新
+func NewAggregate[K, V, A any](cmp tree.Comparator[K], agg tree.Aggregator[K, V, A]) *aggTree[K, V, A] {
+	avl := New[K, V](cmp)
+	m := &monoid[K, V, A]{agg}
+	avl.aug = m
+	return &aggTree[K, V, A]{avlTree: avl, m: m}
+}
+
+// Aggregate 区间[lo, hi)内元素的聚合值，bound可指定区间开闭，O(logN)
+func (t *aggTree[K, V, A]) Aggregate(lo, hi K, bound ...tree.Bound) A {
+	return t.aggregate(t.root, &lo, &hi, tree.RangeBound(bound))
+}
+
+// 子树中区间内元素的聚合值，lo、hi为nil时该侧没有边界。两侧都有边界的节点只有一条路径，
+// 之后左右子树各只剩一侧边界，每层只向一个子树递归，另一个子树直接取聚合值
+func (t *aggTree[K, V, A]) aggregate(root *Node[K, V], lo, hi *K, b tree.Bound) A {
+	if root == t.nilNode {
+		return t.m.Identity
+	}
+	if lo == nil && hi == nil {
+		return t.m.get(root)
+	}
+	if lo != nil {
+		if less := t.cmp(root.key, *lo); less < 0 || less == 0 && !b.LoInclusive() {
+			return t.aggregate(root.rchild, lo, hi, b)
+		}
+	}
+	if hi != nil {
+		if less := t.cmp(root.key, *hi); less > 0 || less == 0 && !b.HiInclusive() {
+			return t.aggregate(root.lchild, lo, hi, b)
+		}
+	}
+	left := t.aggregate(root.lchild, lo, nil, b)
+	right := t.aggregate(root.rchild, nil, hi, b)
+	return t.m.Combine(t.m.Combine(left, t.m.Lift(root.key, root.value)), right)
+}
+
+// SearchPrefix 第一个前缀聚合值满足pred的元素，pred需要单调，O(logN)
+func (t *aggTree[K, V, A]) SearchPrefix(pred func(agg A) bool) tree.Element[K, V] {
+	acc := t.m.Identity
+	for root := t.root; root != t.nilNode; {
+		left := t.m.Combine(acc, t.m.get(root.lchild))
+		if root.lchild.size > 0 && pred(left) {
+			root = root.lchild
+			continue
+		}
+		cur := t.m.Combine(left, t.m.Lift(root.key, root.value))
+		if pred(cur) {
+			return &element[K, V]{avl: t.avlTree, node: root}
+		}
+		acc, root = cur, root.rchild
+	}
+	return nil
+}
+
+// AscendFilter 中序遍历，子树的聚合值不满足pred时跳过整个子树，复杂度O((k+1)logN)，k为访问到的元素个数
+func (t *aggTree[K, V, A]) AscendFilter(pred func(agg A) bool, fn func(key K, value V) bool) {
+	t.ascendFilter(t.root, pred, fn)
+}
+
+func (t *aggTree[K, V, A]) ascendFilter(root *Node[K, V], pred func(agg A) bool, fn func(key K, value V) bool) bool {
+	if root == t.nilNode || !pred(t.m.get(root)) {
+		return true
+	}
+	if !t.ascendFilter(root.lchild, pred, fn) {
+		return false
+	}
+	if pred(t.m.Lift(root.key, root.value)) && !fn(root.key, root.value) {
+		return false
+	}
+	return t.ascendFilter(root.rchild, pred, fn)
+}
+
+// 更新key所在的节点到根的路径上的聚合值
+func (avl *avlTree[K, V]) refresh(root *Node[K, V], key K) {
+	if root == avl.nilNode {
+		return
+	}
+	if less := avl.cmp(key, root.key); less < 0 {
+		avl.refresh(root.lchild, key)
+	} else if less > 0 {
+		avl.refresh(root.rchild, key)
+	}
+	avl.aug.update(root)
+}
@@ -5,82 +5,113 @@
 package avltree
 
 import (
+	"reflect"
+	"sync"
+
+	"github.com/mrtcx/plusdata/codec"
 	"github.com/mrtcx/plusdata/tree"
 )
 
-var _ tree.Tree = (*avlTree)(nil)
+var _ tree.Tree[int, int] = (*avlTree[int, int])(nil)
 
-type avlTree struct {
-	root *Node
-	cmp  tree.Comparator
-	size int
+type avlTree[K, V any] struct {
+	root    *Node[K, V]
+	cmp     tree.Comparator[K]
+	size    int
+	nilNode *Node[K, V]
+	kc      codec.Codec[K]
+	vc      codec.Codec[V]
+	aug     augmenter[K, V] //带聚合的树维护子树的聚合值，为nil时不维护
 }
 
-type Node struct {
-	key    interface{}
-	value  interface{}
-	lchild *Node
-	rchild *Node
+type Node[K, V any] struct {
+	key    K
+	value  V
+	lchild *Node[K, V]
+	rchild *Node[K, V]
 	h      int8
+	size   int //子树中节点的数量
+	agg    any //带聚合的树中为子树聚合值的指针
 }
 
-// 加一个nil的虚拟节点，左旋和右旋可以少很多特判
-var _nil *Node = &Node{h: 1}
+// 加一个nil的虚拟节点，左旋和右旋可以少很多特判。
+// 同一类型的树共享只读的nilNode，Split、Join时节点可以直接在树之间移动
+var sentinels sync.Map
 
-func newNode(key, val interface{}) *Node {
-	p := &Node{key: key, value: val, h: 1, rchild: _nil, lchild: _nil}
-	return p
+func sentinel[K, V any]() *Node[K, V] {
+	key := reflect.TypeOf((*Node[K, V])(nil))
+	if s, ok := sentinels.Load(key); ok {
+		return s.(*Node[K, V])
+	}
+	s, _ := sentinels.LoadOrStore(key, &Node[K, V]{})
+	return s.(*Node[K, V])
 }
 
-func New(cmp tree.Comparator) *avlTree {
-	return &avlTree{
-		cmp:  cmp,
-		root: _nil,
+func New[K, V any](cmp tree.Comparator[K]) *avlTree[K, V] {
+	nilNode := sentinel[K, V]()
+	return &avlTree[K, V]{
+		cmp:     cmp,
+		root:    nilNode,
+		nilNode: nilNode,
 	}
 }
 
-func (avl *avlTree) Clean() {
-	avl.size, avl.root = 0, _nil
+// NewAny interface{}版本的构造函数，与泛型之前的New用法相同，返回的树实现tree.AnyTree
+func NewAny(cmp tree.AnyComparator) *avlTree[any, any] {
+	return New[any, any](cmp)
 }
 
-func (avl *avlTree) Size() int {
+func (avl *avlTree[K, V]) newNode(key K, val V) *Node[K, V] {
+	p := &Node[K, V]{key: key, value: val, h: 1, size: 1, rchild: avl.nilNode, lchild: avl.nilNode}
+	if avl.aug != nil {
+		avl.aug.init(p)
+	}
+	return p
+}
+
+func (avl *avlTree[K, V]) Clean() {
+	avl.size, avl.root = 0, avl.nilNode
+}
+
+func (avl *avlTree[K, V]) Size() int {
 	return avl.size
 }
 
-func (avl *avlTree) Empty() bool {
+func (avl *avlTree[K, V]) Empty() bool {
 	return avl.size == 0
 }
 
-func (avl *avlTree) Insert(key, val interface{}) {
+func (avl *avlTree[K, V]) Insert(key K, val V) {
 	avl.root = avl.insert(avl.root, key, val)
 }
 
-func (avl *avlTree) insert(root *Node, key interface{}, val interface{}) *Node {
-	if root == _nil {
+func (avl *avlTree[K, V]) insert(root *Node[K, V], key K, val V) *Node[K, V] {
+	if root == avl.nilNode {
 		avl.size++
-		return newNode(key, val)
+		return avl.newNode(key, val)
 	}
 	less := avl.cmp(key, root.key)
 	if less == 0 {
 		root.value = val
+		avl.update(root)
 		return root
 	} else if less < 0 {
 		root.lchild = avl.insert(root.lchild, key, val)
 	} else {
 		root.rchild = avl.insert(root.rchild, key, val)
 	}
-	newroot := maintain(root)
-	updateHeight(root)
+	newroot := avl.maintain(root)
+	avl.update(root)
 	return newroot
 }
 
-func (avl *avlTree) Remove(key interface{}) {
+func (avl *avlTree[K, V]) Remove(key K) {
 	avl.root = avl.remove(avl.root, key)
 }
 
-func (avl *avlTree) remove(root *Node, key interface{}) *Node {
-	if root == _nil {
-		return _nil
+func (avl *avlTree[K, V]) remove(root *Node[K, V], key K) *Node[K, V] {
+	if root == avl.nilNode {
+		return avl.nilNode
 	}
 	less := avl.cmp(key, root.key)
 	if less < 0 {
@@ -88,37 +119,38 @@ func (avl *avlTree) remove(root *Node, key interface{}) *Node {
 	} else if less > 0 {
 		root.rchild = avl.remove(root.rchild, key)
 	} else {
-		if root.lchild == _nil || root.rchild == _nil {
+		if root.lchild == avl.nilNode || root.rchild == avl.nilNode {
 			temp := root.lchild
-			if temp == _nil {
+			if temp == avl.nilNode {
 				temp = root.rchild
 			}
 			avl.size--
 			return temp
 		} else {
-			temp := precusor(root)
+			temp := avl.precusor(root)
 			root.key = temp.key
 			root.value = temp.value
 			root.lchild = avl.remove(root.lchild, temp.key)
 		}
 	}
-	newroot := maintain(root)
-	updateHeight(root)
+	newroot := avl.maintain(root)
+	avl.update(root)
 	return newroot
 }
 
-func (avl *avlTree) Get(key interface{}) (interface{}, bool) {
+func (avl *avlTree[K, V]) Get(key K) (V, bool) {
 	node := avl.findNode(key)
 	if node == nil {
-		return nil, false
+		var zero V
+		return zero, false
 	}
 	return node.value, true
 }
 
-func (avl *avlTree) findNode(key interface{}) *Node {
+func (avl *avlTree[K, V]) findNode(key K) *Node[K, V] {
 	root := avl.root
 	for {
-		if root == _nil {
+		if root == avl.nilNode {
 			return nil
 		}
 		less := avl.cmp(key, root.key)
@@ -132,43 +164,43 @@ func (avl *avlTree) findNode(key interface{}) *Node {
 	}
 }
 
-func (avl *avlTree) leftNode() *Node {
+func (avl *avlTree[K, V]) leftNode() *Node[K, V] {
 	root := avl.root
-	if root == _nil {
+	if root == avl.nilNode {
 		return nil
 	}
-	for root.lchild != _nil {
+	for root.lchild != avl.nilNode {
 		root = root.lchild
 	}
 	return root
 }
 
-func (avl *avlTree) rightNode() *Node {
+func (avl *avlTree[K, V]) rightNode() *Node[K, V] {
 	root := avl.root
-	if root == _nil {
+	if root == avl.nilNode {
 		return nil
 	}
-	for root.rchild != _nil {
+	for root.rchild != avl.nilNode {
 		root = root.rchild
 	}
 	return root
 }
 
-func (avl *avlTree) findPrevNode(key interface{}) *Node {
+func (avl *avlTree[K, V]) findPrevNode(key K) *Node[K, V] {
 	root := avl.root
-	var lparent *Node = nil
+	var lparent *Node[K, V] = nil
 	for {
-		if root == _nil {
+		if root == avl.nilNode {
 			return nil
 		}
 		less := avl.cmp(key, root.key)
 		if less > 0 {
-			if root.rchild == _nil {
+			if root.rchild == avl.nilNode {
 				return root
 			}
 			root, lparent = root.rchild, root
 		} else {
-			if root.lchild == _nil {
+			if root.lchild == avl.nilNode {
 				return lparent
 			}
 			root = root.lchild
@@ -176,21 +208,21 @@ func (avl *avlTree) findPrevNode(key interface{}) *Node {
 	}
 }
 
-func (avl *avlTree) findNextNode(key interface{}) *Node {
+func (avl *avlTree[K, V]) findNextNode(key K) *Node[K, V] {
 	root := avl.root
-	var rparent *Node = nil
+	var rparent *Node[K, V] = nil
 	for {
-		if root == _nil {
+		if root == avl.nilNode {
 			return nil
 		}
 		less := avl.cmp(key, root.key)
 		if less >= 0 {
-			if root.rchild == _nil {
+			if root.rchild == avl.nilNode {
 				return rparent
 			}
 			root = root.rchild
 		} else {
-			if root.lchild == _nil {
+			if root.lchild == avl.nilNode {
 				return root
 			}
 			root, rparent = root.lchild, root
@@ -198,54 +230,59 @@ func (avl *avlTree) findNextNode(key interface{}) *Node {
 	}
 }
 
-func maintain(root *Node) *Node {
+func (avl *avlTree[K, V]) precusor(root *Node[K, V]) *Node[K, V] {
+	temp := root.lchild
+	for temp.rchild != avl.nilNode {
+		temp = temp.rchild
+	}
+	return temp
+}
+
+func (avl *avlTree[K, V]) maintain(root *Node[K, V]) *Node[K, V] {
 	diff := root.lchild.h - root.rchild.h
 	if diff <= 1 && diff >= -1 {
 		return root
 	}
 	if root.lchild.h > root.rchild.h {
 		if root.lchild.lchild.h < root.lchild.rchild.h {
-			root.lchild = leftRotate(root.lchild)
+			root.lchild = avl.leftRotate(root.lchild)
 		}
-		root = rightRotate(root)
+		root = avl.rightRotate(root)
 	} else {
 		if root.rchild.rchild.h < root.rchild.lchild.h {
-			root.rchild = rightRotate(root.rchild)
+			root.rchild = avl.rightRotate(root.rchild)
 		}
-		root = leftRotate(root)
+		root = avl.leftRotate(root)
 	}
 	return root
 }
 
-func precusor(root *Node) *Node {
-	temp := root.lchild
-	for temp.rchild != _nil {
-		temp = temp.rchild
-	}
-	return temp
-}
-
-func updateHeight(root *Node) {
+// 更新高度和子树大小，带聚合的树同时更新子树的聚合值
+func (avl *avlTree[K, V]) update(root *Node[K, V]) {
+	root.size = root.lchild.size + root.rchild.size + 1
 	root.h = root.lchild.h + 1
 	if root.rchild.h >= root.h {
 		root.h = root.rchild.h + 1
 	}
+	if avl.aug != nil {
+		avl.aug.update(root)
+	}
 }
 
-func leftRotate(root *Node) *Node {
+func (avl *avlTree[K, V]) leftRotate(root *Node[K, V]) *Node[K, V] {
 	temp := root.rchild
 	root.rchild = temp.lchild
 	temp.lchild = root
-	updateHeight(root)
-	updateHeight(temp)
+	avl.update(root)
+	avl.update(temp)
 	return temp
 }
 
-func rightRotate(root *Node) *Node {
+func (avl *avlTree[K, V]) rightRotate(root *Node[K, V]) *Node[K, V] {
 	temp := root.lchild
 	root.lchild = temp.rchild
 	temp.rchild = root
-	updateHeight(root)
-	updateHeight(temp)
+	avl.update(root)
+	avl.update(temp)
 	return temp
 }
@@ -13,15 +13,15 @@ import (
 	"github.com/mrtcx/plusdata/tree"
 )
 
-var intcmp = tree.IntComparator
+var intcmp = tree.OrderedComparator[int]
 
 func TestInsert(t *testing.T) {
 	nums := []int{1, 2, 4, 8, 1024, 1024 + 1}
 	for _, num := range nums {
 		tnum := num
 		t.Run(fmt.Sprintf("[num:%d]", tnum), func(t *testing.T) {
-			rb := New(intcmp)
-			assert.Equal(t, rb.root, _nil)
+			rb := New[int, int](intcmp)
+			assert.Equal(t, rb.root, rb.nilNode)
 			for i := 1; i <= tnum; i++ {
 				rb.Insert(i, i)
 				rb.Insert(i, i)
@@ -30,7 +30,7 @@ func TestInsert(t *testing.T) {
 			}
 			assert.Equal(t, rb.size, tnum)
 
-			rb = New(intcmp)
+			rb = New[int, int](intcmp)
 			for i := tnum; i >= 1; i-- {
 				rb.Insert(i, i)
 				rb.Insert(i, i)
@@ -39,7 +39,7 @@ func TestInsert(t *testing.T) {
 			}
 			assert.Equal(t, rb.size, tnum)
 
-			rb = New(intcmp)
+			rb = New[int, int](intcmp)
 			for i, j := 1, tnum; i <= j; i, j = i+1, j-1 {
 				rb.Insert(i, i)
 				rb.Insert(j, j)
@@ -48,7 +48,7 @@ func TestInsert(t *testing.T) {
 			}
 			assert.Equal(t, rb.size, tnum)
 
-			rb = New(intcmp)
+			rb = New[int, int](intcmp)
 			for i := 1; i <= tnum; i++ {
 				randnum := int(rand.Int31() % int32(tnum))
 				rb.Insert(randnum, randnum)
@@ -76,7 +76,7 @@ func TestRemove(t *testing.T) {
 				assert.Equal(t, checkOrder(t, rb, rb.root), true)
 				assert.Equal(t, rb.size, tnum-i)
 			}
-			assert.Equal(t, rb.root, _nil)
+			assert.Equal(t, rb.root, rb.nilNode)
 			assert.Equal(t, rb.size, 0)
 
 			rb = newInsertNum(tnum)
@@ -86,7 +86,7 @@ func TestRemove(t *testing.T) {
 				assert.Equal(t, checkOrder(t, rb, rb.root), true)
 				assert.Equal(t, rb.size, i-1)
 			}
-			assert.Equal(t, rb.root, _nil)
+			assert.Equal(t, rb.root, rb.nilNode)
 			assert.Equal(t, rb.size, 0)
 
 			rb = newInsertNum(tnum)
@@ -100,14 +100,14 @@ func TestRemove(t *testing.T) {
 			}
 			rb.Remove(left)
 			rb.Remove(right)
-			assert.Equal(t, rb.root, _nil)
+			assert.Equal(t, rb.root, rb.nilNode)
 			assert.Equal(t, rb.size, 0)
 		})
 	}
 }
 
-func newInsertNum(num int) *avlTree {
-	rb := New(intcmp)
+func newInsertNum(num int) *avlTree[int, int] {
+	rb := New[int, int](intcmp)
 	for i := 1; i <= num; i++ {
 		rb.Insert(i, i)
 	}
@@ -116,9 +116,9 @@ func newInsertNum(num int) *avlTree {
 
 func TestGet(t *testing.T) {
 	num := 1024
-	rb := New(intcmp)
+	rb := New[int, int](intcmp)
 	geti, ei := rb.Get(1)
-	assert.Equal(t, geti, nil)
+	assert.Equal(t, geti, 0)
 	assert.Equal(t, ei, false)
 	for i := 1; i <= num; i++ {
 		rb.Insert(i, i)
@@ -126,7 +126,7 @@ func TestGet(t *testing.T) {
 		assert.Equal(t, geti, i)
 		assert.Equal(t, ei, true)
 		geti, ei = rb.Get(i + 1)
-		assert.Equal(t, geti, nil)
+		assert.Equal(t, geti, 0)
 		assert.Equal(t, ei, false)
 	}
 	for i := 1; i <= num; i++ {
@@ -141,13 +141,13 @@ func TestGet(t *testing.T) {
 	}
 }
 
-func checkHeightBalance(t *testing.T, rb *avlTree, root *Node) bool {
-	balance := checkHeightBalance2(t, root)
+func checkHeightBalance(t *testing.T, rb *avlTree[int, int], root *Node[int, int]) bool {
+	balance := checkHeightBalance2(t, rb, root)
 	return balance
 }
 
-func checkHeightBalance2(t *testing.T, root *Node) bool {
-	if root == _nil {
+func checkHeightBalance2(t *testing.T, rb *avlTree[int, int], root *Node[int, int]) bool {
+	if root == rb.nilNode {
 		return true
 	}
 	diff := root.lchild.h - root.rchild.h
@@ -155,21 +155,21 @@ func checkHeightBalance2(t *testing.T, root *Node) bool {
 		t.Logf("fail node:%v", root)
 		return false
 	}
-	if !checkHeightBalance2(t, root.lchild) {
+	if !checkHeightBalance2(t, rb, root.lchild) {
 		return false
 	}
-	if !checkHeightBalance2(t, root.rchild) {
+	if !checkHeightBalance2(t, rb, root.rchild) {
 		return false
 	}
 	return true
 }
 
-func checkOrder(t *testing.T, rb *avlTree, root *Node) bool {
-	if root == _nil {
+func checkOrder(t *testing.T, rb *avlTree[int, int], root *Node[int, int]) bool {
+	if root == rb.nilNode {
 		return rb.size == 0
 	}
 	var arr []int = make([]int, 0)
-	seqence(root, &arr)
+	seqence(rb, root, &arr)
 	if len(arr) != rb.size {
 		t.Logf("fail seq %d %d", rb.size, len(arr))
 		return false
@@ -183,13 +183,31 @@ func checkOrder(t *testing.T, rb *avlTree, root *Node) bool {
 	return true
 }
 
-func seqence(root *Node, arr *[]int) {
-	if root == _nil {
+func seqence(rb *avlTree[int, int], root *Node[int, int], arr *[]int) {
+	if root == rb.nilNode {
 		return
 	}
-	seqence(root.lchild, arr)
-	*arr = append(*arr, root.key.(int))
-	seqence(root.rchild, arr)
+	seqence(rb, root.lchild, arr)
+	*arr = append(*arr, root.key)
+	seqence(rb, root.rchild, arr)
+}
+
+func TestAnyKey(t *testing.T) {
+	num := 1024
+	var tr tree.Tree[any, any] = New[any, any](tree.IntComparator)
+	for i := num; i >= 1; i-- {
+		tr.Insert(i, i)
+	}
+	e := tr.Left()
+	for i := 1; i <= num; i++ {
+		assert.Equal(t, e.Key(), i)
+		assert.Equal(t, e.Value(), i)
+		e = e.Next()
+	}
+	assert.Equal(t, e, nil)
+	geti, ei := tr.Get(num + 1)
+	assert.Equal(t, geti, nil)
+	assert.Equal(t, ei, false)
 }
 
 func BenchmarkInsert(b *testing.B) {
@@ -208,7 +226,7 @@ func BenchmarkInsert(b *testing.B) {
 			}
 		})
 		b.Run(fmt.Sprintf("avltree-%s", name), func(b *testing.B) {
-			tr := New(tree.IntComparator)
+			tr := New[int, interface{}](intcmp)
 			b.ResetTimer()
 			for i := 0; i < b.N; i++ {
 				for j := 0; j < size; j++ {
@@ -238,7 +256,7 @@ func BenchmarkRemove(b *testing.B) {
 			}
 		})
 		b.Run(fmt.Sprintf("avltree-%s", name), func(b *testing.B) {
-			tr := New(tree.IntComparator)
+			tr := New[int, interface{}](intcmp)
 			for j := 0; j < size; j++ {
 				tr.Insert(j, nil)
 			}
@@ -271,7 +289,7 @@ func BenchmarkGet(b *testing.B) {
 			}
 		})
 		b.Run(fmt.Sprintf("avltree-%s", name), func(b *testing.B) {
-			tr := New(tree.IntComparator)
+			tr := New[int, interface{}](intcmp)
 			for j := 0; j < size; j++ {
 				tr.Insert(j, nil)
 			}
@@ -6,67 +6,70 @@ package avltree
 
 import "github.com/mrtcx/plusdata/tree"
 
-type element struct {
-	avl  *avlTree
-	node *Node
+type element[K, V any] struct {
+	avl  *avlTree[K, V]
+	node *Node[K, V]
 }
 
-func (e *element) Key() interface{} {
+func (e *element[K, V]) Key() K {
 	return e.node.key
 }
 
-func (e *element) Value() interface{} {
+func (e *element[K, V]) Value() V {
 	return e.node.value
 }
 
-func (e *element) SetValue(value interface{}) {
+func (e *element[K, V]) SetValue(value V) {
 	e.node.value = value
+	if e.avl.aug != nil {
+		e.avl.refresh(e.avl.root, e.node.key)
+	}
 }
 
-func (e *element) Next() tree.Element {
+func (e *element[K, V]) Next() tree.Element[K, V] {
 	return e.avl.Next(e.node.key)
 }
 
-func (e *element) Prev() tree.Element {
+func (e *element[K, V]) Prev() tree.Element[K, V] {
 	return e.avl.Prev(e.node.key)
 }
 
-func (avl *avlTree) Find(key interface{}) tree.Element {
+func (avl *avlTree[K, V]) Find(key K) tree.Element[K, V] {
 	node := avl.findNode(key)
 	if node == nil {
 		return nil
 	}
-	return &element{avl: avl, node: node}
+	return &element[K, V]{avl: avl, node: node}
 }
 
-func (avl *avlTree) Left() tree.Element {
+func (avl *avlTree[K, V]) Left() tree.Element[K, V] {
 	mleft := avl.leftNode()
 	if mleft == nil {
 		return nil
 	}
-	return &element{avl: avl, node: mleft}
+	return &element[K, V]{avl: avl, node: mleft}
 }
 
-func (avl *avlTree) Right() tree.Element {
+func (avl *avlTree[K, V]) Right() tree.Element[K, V] {
 	mright := avl.rightNode()
 	if mright == nil {
 		return nil
 	}
-	return &element{avl: avl, node: mright}
+	return &element[K, V]{avl: avl, node: mright}
 }
 
-func (avl *avlTree) Prev(key interface{}) tree.Element {
+func (avl *avlTree[K, V]) Prev(key K) tree.Element[K, V] {
 	node := avl.findPrevNode(key)
 	if node == nil {
 		return nil
 	}
-	return &element{avl: avl, node: node}
+	return &element[K, V]{avl: avl, node: node}
 }
 
-func (avl *avlTree) Next(key interface{}) tree.Element {
+func (avl *avlTree[K, V]) Next(key K) tree.Element[K, V] {
 	node := avl.findNextNode(key)
 	if node == nil {
 		return nil
 	}
-	return &element{avl: avl, node: node}
+	return &element[K, V]{avl: avl, node: node}
 }
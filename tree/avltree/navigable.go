@@ -0,0 +1,91 @@
+// Copyright (c) 2025 Tian ChunXing. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package avltree
+
+import "github.com/mrtcx/plusdata/tree"
+
+var _ tree.Navigable[int, int] = (*avlTree[int, int])(nil)
+
+// Floor 最后一个<=key的元素
+func (avl *avlTree[K, V]) Floor(key K) tree.Element[K, V] {
+	var floor *Node[K, V]
+	for root := avl.root; root != avl.nilNode; {
+		less := avl.cmp(key, root.key)
+		if less == 0 {
+			floor = root
+			break
+		} else if less > 0 {
+			floor, root = root, root.rchild
+		} else {
+			root = root.lchild
+		}
+	}
+	if floor == nil {
+		return nil
+	}
+	return &element[K, V]{avl: avl, node: floor}
+}
+
+// Ceiling 第一个>=key的元素
+func (avl *avlTree[K, V]) Ceiling(key K) tree.Element[K, V] {
+	var ceiling *Node[K, V]
+	for root := avl.root; root != avl.nilNode; {
+		less := avl.cmp(key, root.key)
+		if less == 0 {
+			ceiling = root
+			break
+		} else if less < 0 {
+			ceiling, root = root, root.lchild
+		} else {
+			root = root.rchild
+		}
+	}
+	if ceiling == nil {
+		return nil
+	}
+	return &element[K, V]{avl: avl, node: ceiling}
+}
+
+func (avl *avlTree[K, V]) Lower(key K) tree.Element[K, V] {
+	return avl.Prev(key)
+}
+
+func (avl *avlTree[K, V]) Higher(key K) tree.Element[K, V] {
+	return avl.Next(key)
+}
+
+func (avl *avlTree[K, V]) PollFirst() (K, V, bool) {
+	return avl.poll(avl.leftNode())
+}
+
+func (avl *avlTree[K, V]) PollLast() (K, V, bool) {
+	return avl.poll(avl.rightNode())
+}
+
+func (avl *avlTree[K, V]) poll(node *Node[K, V]) (K, V, bool) {
+	if node == nil {
+		var zeroK K
+		var zeroV V
+		return zeroK, zeroV, false
+	}
+	key, value := node.key, node.value
+	avl.Remove(key)
+	return key, value, true
+}
+
+// HeadMap key<to的元素的视图，视图中的修改直接作用于树
+func (avl *avlTree[K, V]) HeadMap(to K) tree.Navigable[K, V] {
+	return tree.NewHeadMap[K, V](avl, avl.cmp, to)
+}
+
+// TailMap key>=from的元素的视图
+func (avl *avlTree[K, V]) TailMap(from K) tree.Navigable[K, V] {
+	return tree.NewTailMap[K, V](avl, avl.cmp, from)
+}
+
+// SubMap 区间[from, to)内的元素的视图，bound可指定区间开闭
+func (avl *avlTree[K, V]) SubMap(from, to K, bound ...tree.Bound) tree.Navigable[K, V] {
+	return tree.NewSubMap[K, V](avl, avl.cmp, from, to, bound...)
+}
@@ -0,0 +1,88 @@
+// Copyright (c) 2025 Tian ChunXing. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package avltree
+
+import "github.com/mrtcx/plusdata/tree"
+
+// AscendRange 正序遍历区间[lo, hi)内的元素, fn返回false时停止, bound可指定区间开闭
+func (avl *avlTree[K, V]) AscendRange(lo, hi K, fn func(key K, value V) bool, bound ...tree.Bound) {
+	b := tree.RangeBound(bound)
+	avl.ascend(lo, b.LoInclusive(), func(key K) bool {
+		less := avl.cmp(key, hi)
+		return less < 0 || less == 0 && b.HiInclusive()
+	}, fn)
+}
+
+// DescendRange 倒序遍历区间[lo, hi)内的元素, fn返回false时停止, bound可指定区间开闭
+func (avl *avlTree[K, V]) DescendRange(lo, hi K, fn func(key K, value V) bool, bound ...tree.Bound) {
+	b := tree.RangeBound(bound)
+	avl.descend(hi, b.HiInclusive(), func(key K) bool {
+		less := avl.cmp(key, lo)
+		return less > 0 || less == 0 && b.LoInclusive()
+	}, fn)
+}
+
+// AscendGreaterOrEqual 正序遍历key>=lo的元素
+func (avl *avlTree[K, V]) AscendGreaterOrEqual(lo K, fn func(key K, value V) bool) {
+	avl.ascend(lo, true, nil, fn)
+}
+
+// DescendLessOrEqual 倒序遍历key<=hi的元素
+func (avl *avlTree[K, V]) DescendLessOrEqual(hi K, fn func(key K, value V) bool) {
+	avl.descend(hi, true, nil, fn)
+}
+
+// 栈中保存还未访问的左父节点，出栈后把右子树的左链入栈，整体O(logN + k)
+func (avl *avlTree[K, V]) ascend(lo K, include bool, inRange func(key K) bool, fn func(key K, value V) bool) {
+	stack := make([]*Node[K, V], 0, 64)
+	for root := avl.root; root != avl.nilNode; {
+		less := avl.cmp(lo, root.key)
+		if less < 0 || less == 0 && include {
+			stack = append(stack, root)
+			if less == 0 {
+				break
+			}
+			root = root.lchild
+		} else {
+			root = root.rchild
+		}
+	}
+	for len(stack) > 0 {
+		node := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		if inRange != nil && !inRange(node.key) || !fn(node.key, node.value) {
+			return
+		}
+		for root := node.rchild; root != avl.nilNode; root = root.lchild {
+			stack = append(stack, root)
+		}
+	}
+}
+
+func (avl *avlTree[K, V]) descend(hi K, include bool, inRange func(key K) bool, fn func(key K, value V) bool) {
+	stack := make([]*Node[K, V], 0, 64)
+	for root := avl.root; root != avl.nilNode; {
+		less := avl.cmp(hi, root.key)
+		if less > 0 || less == 0 && include {
+			stack = append(stack, root)
+			if less == 0 {
+				break
+			}
+			root = root.rchild
+		} else {
+			root = root.lchild
+		}
+	}
+	for len(stack) > 0 {
+		node := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		if inRange != nil && !inRange(node.key) || !fn(node.key, node.value) {
+			return
+		}
+		for root := node.lchild; root != avl.nilNode; root = root.rchild {
+			stack = append(stack, root)
+		}
+	}
+}
@@ -0,0 +1,41 @@
+// Copyright (c) 2025 Tian ChunXing. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package avltree
+
+import "github.com/mrtcx/plusdata/tree"
+
+// Rank 返回树中小于key的元素个数，即key在顺序遍历中的下标
+func (avl *avlTree[K, V]) Rank(key K) int {
+	rank := 0
+	for root := avl.root; root != avl.nilNode; {
+		less := avl.cmp(key, root.key)
+		if less == 0 {
+			return rank + root.lchild.size
+		} else if less < 0 {
+			root = root.lchild
+		} else {
+			rank += root.lchild.size + 1
+			root = root.rchild
+		}
+	}
+	return rank
+}
+
+// Select 返回顺序遍历中下标为k的元素(从0开始)，k越界时返回nil
+func (avl *avlTree[K, V]) Select(k int) tree.Element[K, V] {
+	if k < 0 || k >= avl.size {
+		return nil
+	}
+	root := avl.root
+	for k != root.lchild.size {
+		if k < root.lchild.size {
+			root = root.lchild
+		} else {
+			k -= root.lchild.size + 1
+			root = root.rchild
+		}
+	}
+	return &element[K, V]{avl: avl, node: root}
+}
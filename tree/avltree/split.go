@@ -0,0 +1,163 @@
+// Copyright (c) 2025 Tian ChunXing. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package avltree
+
+import "github.com/mrtcx/plusdata/tree"
+
+// 与avl使用相同比较函数、codec和聚合的空树
+func (avl *avlTree[K, V]) empty() *avlTree[K, V] {
+	return &avlTree[K, V]{
+		cmp:     avl.cmp,
+		root:    avl.nilNode,
+		nilNode: avl.nilNode,
+		kc:      avl.kc,
+		vc:      avl.vc,
+		aug:     avl.aug,
+	}
+}
+
+func (avl *avlTree[K, V]) setRoot(root *Node[K, V]) {
+	avl.root, avl.size = root, root.size
+}
+
+// Split 按key把树分成key<key的left和key>=key的right，节点移动到两棵新树中，原树变为空树，O(logN)
+func (avl *avlTree[K, V]) Split(key K) (left, right *avlTree[K, V]) {
+	l, eq, r := avl.split(avl.root, key)
+	if eq != nil {
+		r = avl.join(avl.nilNode, eq, r)
+	}
+	left, right = avl.empty(), avl.empty()
+	left.setRoot(l)
+	right.setRoot(r)
+	avl.Clean()
+	return left, right
+}
+
+// Join 把right的全部节点合并到left中并返回left，right变为空树，O(logN)。
+// left中的key需要都小于right中的key，否则返回tree.ErrNotSorted，两棵树不变
+func Join[K, V any](left, right *avlTree[K, V]) (*avlTree[K, V], error) {
+	if !left.Empty() && !right.Empty() && left.cmp(left.rightNode().key, right.leftNode().key) >= 0 {
+		return left, tree.ErrNotSorted
+	}
+	left.setRoot(left.join2(left.root, right.root))
+	right.Clean()
+	return left, nil
+}
+
+// Union 并集，key相同时保留a中的元素。结果为a，b变为空树，较小的树大小为M时复杂度O(Mlog(N/M+1))
+func Union[K, V any](a, b *avlTree[K, V]) *avlTree[K, V] {
+	a.setRoot(a.union(a.root, b.root))
+	b.Clean()
+	return a
+}
+
+// Intersection 交集，保留a中key也在b中的元素。结果为a，b变为空树
+func Intersection[K, V any](a, b *avlTree[K, V]) *avlTree[K, V] {
+	a.setRoot(a.intersection(a.root, b.root))
+	b.Clean()
+	return a
+}
+
+// Difference 差集，保留a中key不在b中的元素。结果为a，b变为空树
+func Difference[K, V any](a, b *avlTree[K, V]) *avlTree[K, V] {
+	a.setRoot(a.difference(a.root, b.root))
+	b.Clean()
+	return a
+}
+
+// 以mid为根连接l和r，l中的key都小于mid，r中的key都大于mid。沿较高一侧的边向下找到高度接近的子树，
+// 回溯时和插入一样旋转，复杂度为两棵树的高度差
+func (avl *avlTree[K, V]) join(l, mid, r *Node[K, V]) *Node[K, V] {
+	if l.h > r.h+1 {
+		l.rchild = avl.join(l.rchild, mid, r)
+		newroot := avl.maintain(l)
+		avl.update(l)
+		return newroot
+	}
+	if r.h > l.h+1 {
+		r.lchild = avl.join(l, mid, r.lchild)
+		newroot := avl.maintain(r)
+		avl.update(r)
+		return newroot
+	}
+	mid.lchild, mid.rchild = l, r
+	avl.update(mid)
+	return mid
+}
+
+// 没有中间节点时，取出r中最小的节点作为中间节点
+func (avl *avlTree[K, V]) join2(l, r *Node[K, V]) *Node[K, V] {
+	if r == avl.nilNode {
+		return l
+	}
+	r, mid := avl.removeMin(r)
+	return avl.join(l, mid, r)
+}
+
+func (avl *avlTree[K, V]) removeMin(root *Node[K, V]) (*Node[K, V], *Node[K, V]) {
+	if root.lchild == avl.nilNode {
+		return root.rchild, root
+	}
+	var mid *Node[K, V]
+	root.lchild, mid = avl.removeMin(root.lchild)
+	newroot := avl.maintain(root)
+	avl.update(root)
+	return newroot, mid
+}
+
+// 把子树分成小于key的l、等于key的节点eq(不存在时为nil)和大于key的r
+func (avl *avlTree[K, V]) split(root *Node[K, V], key K) (l, eq, r *Node[K, V]) {
+	if root == avl.nilNode {
+		return avl.nilNode, nil, avl.nilNode
+	}
+	lchild, rchild := root.lchild, root.rchild
+	less := avl.cmp(key, root.key)
+	if less == 0 {
+		return lchild, root, rchild
+	} else if less < 0 {
+		l, eq, r = avl.split(lchild, key)
+		return l, eq, avl.join(r, root, rchild)
+	}
+	l, eq, r = avl.split(rchild, key)
+	return avl.join(lchild, root, l), eq, r
+}
+
+// 按t1的根分割t2，两侧分别递归后再连接
+func (avl *avlTree[K, V]) union(t1, t2 *Node[K, V]) *Node[K, V] {
+	if t1 == avl.nilNode {
+		return t2
+	} else if t2 == avl.nilNode {
+		return t1
+	}
+	l2, _, r2 := avl.split(t2, t1.key)
+	lchild, rchild := t1.lchild, t1.rchild
+	return avl.join(avl.union(lchild, l2), t1, avl.union(rchild, r2))
+}
+
+func (avl *avlTree[K, V]) intersection(t1, t2 *Node[K, V]) *Node[K, V] {
+	if t1 == avl.nilNode || t2 == avl.nilNode {
+		return avl.nilNode
+	}
+	l2, eq, r2 := avl.split(t2, t1.key)
+	lchild, rchild := t1.lchild, t1.rchild
+	l, r := avl.intersection(lchild, l2), avl.intersection(rchild, r2)
+	if eq == nil {
+		return avl.join2(l, r)
+	}
+	return avl.join(l, t1, r)
+}
+
+func (avl *avlTree[K, V]) difference(t1, t2 *Node[K, V]) *Node[K, V] {
+	if t1 == avl.nilNode || t2 == avl.nilNode {
+		return t1
+	}
+	l2, eq, r2 := avl.split(t2, t1.key)
+	lchild, rchild := t1.lchild, t1.rchild
+	l, r := avl.difference(lchild, l2), avl.difference(rchild, r2)
+	if eq != nil {
+		return avl.join2(l, r)
+	}
+	return avl.join(l, t1, r)
+}
@@ -0,0 +1,157 @@
+// Copyright (c) 2025 Tian ChunXing. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package avltree
+
+import (
+	"fmt"
+	"math/rand"
+	"testing"
+
+	"github.com/mrtcx/plusdata/internal/assert"
+	"github.com/mrtcx/plusdata/tree"
+)
+
+func TestSplitJoin(t *testing.T) {
+	nums := []int{0, 1, 2, 8, 1024}
+	for _, num := range nums {
+		tnum := num
+		t.Run(fmt.Sprintf("[num:%d]", tnum), func(t *testing.T) {
+			for i := 0; i < 16; i++ {
+				avl := New[int, int](intcmp)
+				for _, key := range rand.Perm(tnum) {
+					avl.Insert(2*key, key)
+				}
+				key := rand.Intn(2*tnum+3) - 1
+				left, right := avl.Split(key)
+				assert.Equal(t, avl.Size(), 0)
+				expectSplitTree(t, left, 0, min(max(key, 0), 2*tnum))
+				expectSplitTree(t, right, min(max(key, 0), 2*tnum), 2*tnum)
+
+				joined, err := Join(left, right)
+				assert.Equal(t, err, nil)
+				assert.Equal(t, joined, left)
+				assert.Equal(t, right.Size(), 0)
+				expectSplitTree(t, joined, 0, 2*tnum)
+			}
+		})
+	}
+}
+
+// 两棵独立创建、高度相差很大的树也能直接连接
+func TestJoinUnbalanced(t *testing.T) {
+	for _, num := range []int{1, 7, 1000} {
+		small, large := New[int, int](intcmp), New[int, int](intcmp)
+		for i := 0; i < num; i++ {
+			large.Insert(2*(i+3), i)
+		}
+		for i := 0; i < 3; i++ {
+			small.Insert(2*i, i)
+		}
+		assert.Equal(t, small.nilNode, large.nilNode)
+		_, err := Join(large, small)
+		assert.Equal(t, err, tree.ErrNotSorted)
+		assert.Equal(t, large.Size(), num)
+		assert.Equal(t, small.Size(), 3)
+
+		joined, err := Join(small, large)
+		assert.Equal(t, err, nil)
+		assert.Equal(t, joined.Size(), num+3)
+		checkSplitTree(t, joined)
+	}
+}
+
+func TestSetOperation(t *testing.T) {
+	nums := [][2]int{{0, 0}, {0, 16}, {16, 0}, {8, 1024}, {1024, 8}, {512, 512}}
+	for _, num := range nums {
+		na, nb := num[0], num[1]
+		t.Run(fmt.Sprintf("[num:%d,%d]", na, nb), func(t *testing.T) {
+			newTrees := func() (*avlTree[int, int], *avlTree[int, int], map[int]bool, map[int]bool) {
+				a, b := New[int, int](intcmp), New[int, int](intcmp)
+				ina, inb := map[int]bool{}, map[int]bool{}
+				for i := 0; i < na; i++ {
+					key := rand.Intn(2 * (na + nb))
+					a.Insert(key, key)
+					ina[key] = true
+				}
+				for i := 0; i < nb; i++ {
+					key := rand.Intn(2 * (na + nb))
+					b.Insert(key, -key)
+					inb[key] = true
+				}
+				return a, b, ina, inb
+			}
+			a, b, ina, inb := newTrees()
+			expectSetOperation(t, Union(a, b), b, func(key int) (bool, int) {
+				if ina[key] {
+					return true, key
+				}
+				return inb[key], -key
+			}, na+nb)
+
+			a, b, ina, inb = newTrees()
+			expectSetOperation(t, Intersection(a, b), b, func(key int) (bool, int) {
+				return ina[key] && inb[key], key
+			}, na+nb)
+
+			a, b, ina, inb = newTrees()
+			expectSetOperation(t, Difference(a, b), b, func(key int) (bool, int) {
+				return ina[key] && !inb[key], key
+			}, na+nb)
+		})
+	}
+}
+
+func expectSetOperation(t *testing.T, res, consumed *avlTree[int, int], expect func(key int) (bool, int), num int) {
+	checkSplitTree(t, res)
+	assert.Equal(t, consumed.Size(), 0)
+	size := 0
+	for key := 0; key < 2*num; key++ {
+		exist, value := expect(key)
+		v, ok := res.Get(key)
+		assert.Equal(t, ok, exist)
+		if exist {
+			assert.Equal(t, v, value)
+			size++
+		}
+	}
+	assert.Equal(t, res.Size(), size)
+}
+
+// 树中为[lo, hi)内的偶数key
+func expectSplitTree(t *testing.T, avl *avlTree[int, int], lo, hi int) {
+	checkSplitTree(t, avl)
+	var keys []int
+	avl.AscendGreaterOrEqual(-1, func(key, value int) bool {
+		assert.Equal(t, value, key/2)
+		keys = append(keys, key)
+		return true
+	})
+	i := 0
+	for key := lo; key < hi; key++ {
+		if key%2 == 0 {
+			assert.Equal(t, keys[i], key)
+			i++
+		}
+	}
+	assert.Equal(t, len(keys), i)
+}
+
+// 检查顺序、平衡、高度和子树大小
+func checkSplitTree(t *testing.T, avl *avlTree[int, int]) {
+	assert.Equal(t, checkOrder(t, avl, avl.root), true)
+	assert.Equal(t, checkHeightBalance(t, avl, avl.root), true)
+	var check func(root *Node[int, int])
+	check = func(root *Node[int, int]) {
+		if root == avl.nilNode {
+			return
+		}
+		check(root.lchild)
+		check(root.rchild)
+		assert.Equal(t, root.h, max(root.lchild.h, root.rchild.h)+1)
+		assert.Equal(t, root.size, root.lchild.size+root.rchild.size+1)
+	}
+	check(avl.root)
+	assert.Equal(t, avl.size, avl.root.size)
+}
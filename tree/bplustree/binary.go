@@ -0,0 +1,90 @@
+// Copyright (c) 2025 Tian ChunXing. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package bplustree
+
+import (
+	"bytes"
+	"io"
+
+	"github.com/mrtcx/plusdata/codec"
+)
+
+// SetCodec 设置编解码key和value的codec，int、int64、uint64、float64、string、[]byte可以不设置
+func (bp *bplusTree[K, V]) SetCodec(kc codec.Codec[K], vc codec.Codec[V]) {
+	bp.kc, bp.vc = kc, vc
+}
+
+// Encode 按顺序把元素逐个编码写入w，编码的格式与树的种类无关，可以解码到其他种类的树中
+func (bp *bplusTree[K, V]) Encode(w io.Writer) error {
+	kc, vc, err := bp.codecs()
+	if err != nil {
+		return err
+	}
+	enc := codec.NewEncoder(w, codec.KindTree, bp.size)
+	for node := bp.mostLeft(); node != nil; node = node.next {
+		for i := range node.keys {
+			codec.Write(enc, kc, node.keys[i])
+			codec.Write(enc, vc, node.valus[i])
+		}
+	}
+	return enc.Close()
+}
+
+// Decode 清空后从r中解码，重复的key按原有顺序保留，出错时树为空
+func (bp *bplusTree[K, V]) Decode(r io.Reader) error {
+	bp.Clean()
+	if err := bp.decode(r); err != nil {
+		bp.Clean()
+		return err
+	}
+	return nil
+}
+
+func (bp *bplusTree[K, V]) decode(r io.Reader) error {
+	kc, vc, err := bp.codecs()
+	if err != nil {
+		return err
+	}
+	dec, n, err := codec.NewDecoder(r, codec.KindTree)
+	if err != nil {
+		return err
+	}
+	for i := 0; i < n; i++ {
+		key, err := codec.Read(dec, kc)
+		if err != nil {
+			return err
+		}
+		value, err := codec.Read(dec, vc)
+		if err != nil {
+			return err
+		}
+		bp.InsertDup(key, value)
+	}
+	return dec.Close()
+}
+
+func (bp *bplusTree[K, V]) codecs() (codec.Codec[K], codec.Codec[V], error) {
+	kc, err := codec.Resolve(bp.kc)
+	if err != nil {
+		return nil, nil, err
+	}
+	vc, err := codec.Resolve(bp.vc)
+	if err != nil {
+		return nil, nil, err
+	}
+	return kc, vc, nil
+}
+
+func (bp *bplusTree[K, V]) MarshalBinary() ([]byte, error) {
+	var buf bytes.Buffer
+	if err := bp.Encode(&buf); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func (bp *bplusTree[K, V]) UnmarshalBinary(data []byte) error {
+	return bp.Decode(bytes.NewReader(data))
+}
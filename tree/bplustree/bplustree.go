@@ -5,98 +5,144 @@
 package bplustree
 
 import (
+	"github.com/mrtcx/plusdata/codec"
 	"github.com/mrtcx/plusdata/tree"
 )
 
-var _ tree.Tree = (*bplusTree)(nil)
+var _ tree.Tree[int, int] = (*bplusTree[int, int])(nil)
 
-type bplusTree struct {
-	root  *Node
-	cmp   tree.Comparator
+type bplusTree[K, V any] struct {
+	root  *Node[K, V]
+	cmp   tree.Comparator[K]
 	size  int
 	order int
+	kc    codec.Codec[K]
+	vc    codec.Codec[V]
 }
 
-type Node struct {
-	keys   []interface{}
-	valus  []interface{}
-	childs []*Node
-	next   *Node
-	prev   *Node
+type Node[K, V any] struct {
+	keys   []K
+	valus  []V
+	childs []*Node[K, V]
+	counts []int //counts[i]为子树childs[i]中叶子节点key的数量
+	next   *Node[K, V]
+	prev   *Node[K, V]
 }
 
-func (node *Node) isLeaf() bool {
+func (node *Node[K, V]) isLeaf() bool {
 	return len(node.childs) == 0
 }
 
-func New(cmp tree.Comparator, order int) *bplusTree {
-	return &bplusTree{
+// 子树中叶子节点key的数量
+func (node *Node[K, V]) count() int {
+	if node.isLeaf() {
+		return len(node.keys)
+	}
+	cnt := 0
+	for _, c := range node.counts {
+		cnt += c
+	}
+	return cnt
+}
+
+func New[K, V any](cmp tree.Comparator[K], order int) *bplusTree[K, V] {
+	return &bplusTree[K, V]{
 		cmp:   cmp,
 		order: order,
 	}
 }
 
-func (bp *bplusTree) Clean() {
+// NewAny interface{}版本的构造函数，与泛型之前的New用法相同，返回的树实现tree.AnyTree
+func NewAny(cmp tree.AnyComparator, order int) *bplusTree[any, any] {
+	return New[any, any](cmp, order)
+}
+
+func (bp *bplusTree[K, V]) Clean() {
 	bp.root, bp.size = nil, 0
 }
 
-func (bp *bplusTree) Size() int {
+func (bp *bplusTree[K, V]) Size() int {
 	return bp.size
 }
 
-func (bp *bplusTree) Empty() bool {
+func (bp *bplusTree[K, V]) Empty() bool {
 	return bp.size == 0
 }
 
-func (bp *bplusTree) Insert(key, val interface{}) {
-	root := bp.insert(bp.root, key, val)
+func (bp *bplusTree[K, V]) Insert(key K, val V) {
+	bp.put(key, val, false)
+}
+
+func (bp *bplusTree[K, V]) put(key K, val V, dup bool) {
+	root := bp.insert(bp.root, key, val, dup)
 	if len(root.keys) > int(bp.maxKeys()) {
 		skey, sleft, sright := split(root)
-		root = &Node{
-			keys:   []interface{}{skey},
-			childs: []*Node{sleft, sright},
+		root = &Node[K, V]{
+			keys:   []K{skey},
+			childs: []*Node[K, V]{sleft, sright},
+			counts: []int{sleft.count(), sright.count()},
 		}
 	}
 	bp.root = root
 }
 
-func (bp *bplusTree) insert(root *Node, key, val interface{}) *Node {
+// dup为true时不覆盖相同的key，插入到相同key的右侧
+func (bp *bplusTree[K, V]) insert(root *Node[K, V], key K, val V, dup bool) *Node[K, V] {
 	if root == nil {
 		bp.size++
-		return &Node{
-			keys:  []interface{}{key},
-			valus: []interface{}{val},
+		return &Node[K, V]{
+			keys:  []K{key},
+			valus: []V{val},
 		}
 	}
-	idx := bp.binarySearchIdx(key, root)
+	idx := bp.searchIdx(key, root, dup)
 	if root.isLeaf() {
-		if idx < len(root.keys) && bp.cmp(root.keys[idx], key) == 0 {
+		if !dup && idx < len(root.keys) && bp.cmp(root.keys[idx], key) == 0 {
 			root.valus[idx] = val
 			return root
 		}
+		// 存在重复key时，分隔key可能与右侧叶子的第一个key相同
+		if !dup && idx == len(root.keys) && root.next != nil && bp.cmp(root.next.keys[0], key) == 0 {
+			root.next.valus[0] = val
+			return root
+		}
 		bp.size++
 		root.keys = increaseSpace(root.keys, idx)
 		root.valus = increaseSpace(root.valus, idx)
 		root.keys[idx], root.valus[idx] = key, val
 		return root
 	} else {
-		retNode := bp.insert(root.childs[idx], key, val)
+		retNode := bp.insert(root.childs[idx], key, val, dup)
 		if len(retNode.keys) > bp.maxKeys() {
 			skey, sleft, sright := split(retNode)
 			root.keys = increaseSpace(root.keys, idx)
-			root.childs = increaseSpace2(root.childs, idx)
+			root.childs = increaseSpace(root.childs, idx)
+			root.counts = increaseSpace(root.counts, idx)
 			root.keys[idx] = skey
 			root.childs[idx], root.childs[idx+1] = sleft, sright
+			root.counts[idx+1] = sright.count()
 		}
+		root.counts[idx] = root.childs[idx].count()
 		return root
 	}
 }
 
-func (bp *bplusTree) Remove(key interface{}) {
+func (bp *bplusTree[K, V]) Remove(key K) {
 	if bp.root == nil {
 		return
 	}
-	root := bp.remove(bp.root, key)
+	size := bp.size
+	bp.shrink(bp.remove(bp.root, key))
+	if bp.size == size {
+		// 存在重复key时，key可能在查找到的叶子的右侧叶子中，按下标删除
+		if node, idx := bp.leafIdx(key, false); idx < len(node.keys) && bp.cmp(node.keys[idx], key) == 0 {
+			bp.removeAt(bp.Rank(key))
+		}
+	}
+}
+
+// 根节点为空的叶子或者只有一个子节点时，降低树的高度
+func (bp *bplusTree[K, V]) shrink(root *Node[K, V]) {
 	if root.isLeaf() {
 		if len(root.keys) == 0 {
 			bp.root = nil
@@ -109,7 +155,7 @@ func (bp *bplusTree) Remove(key interface{}) {
 	}
 }
 
-func (bp *bplusTree) remove(root *Node, key interface{}) *Node {
+func (bp *bplusTree[K, V]) remove(root *Node[K, V], key K) *Node[K, V] {
 	if root == nil {
 		return nil
 	}
@@ -121,97 +167,115 @@ func (bp *bplusTree) remove(root *Node, key interface{}) *Node {
 			root.valus = decreaseSpace(root.valus, idx)
 		}
 	} else {
-		retNode := bp.remove(root.childs[idx], key)
-		if len(retNode.keys) < bp.minKeys() {
-			minkeys, maxkeys := bp.minKeys(), bp.maxKeys()
-			if !borrowFromRight(root, idx, minkeys) &&
-				!mergeRight(root, idx, maxkeys) &&
-				!borrowFromLeft(root, idx, minkeys) &&
-				!mergeLeft(root, idx, maxkeys) {
-				panic("")
-			}
-		}
+		bp.remove(root.childs[idx], key)
+		bp.rebalance(root, idx)
+	}
+	return root
+}
+
+// 删除顺序遍历中下标为k的元素
+func (bp *bplusTree[K, V]) removeAt(k int) {
+	bp.shrink(bp.removeRank(bp.root, k))
+}
+
+func (bp *bplusTree[K, V]) removeRank(root *Node[K, V], k int) *Node[K, V] {
+	if root.isLeaf() {
+		bp.size--
+		root.keys = decreaseSpace(root.keys, k)
+		root.valus = decreaseSpace(root.valus, k)
+		return root
+	}
+	idx := 0
+	for k >= root.counts[idx] {
+		k -= root.counts[idx]
+		idx++
 	}
+	bp.removeRank(root.childs[idx], k)
+	bp.rebalance(root, idx)
 	return root
 }
 
-func (bp *bplusTree) Get(key interface{}) (interface{}, bool) {
+// 子节点childs[idx]删除元素后，更新数量，key不足时向兄弟节点借或者合并
+func (bp *bplusTree[K, V]) rebalance(root *Node[K, V], idx int) {
+	child := root.childs[idx]
+	root.counts[idx] = child.count()
+	if len(child.keys) < bp.minKeys() {
+		minkeys, maxkeys := bp.minKeys(), bp.maxKeys()
+		if !borrowFromRight(root, idx, minkeys) &&
+			!mergeRight(root, idx, maxkeys) &&
+			!borrowFromLeft(root, idx, minkeys) &&
+			!mergeLeft(root, idx, maxkeys) {
+			panic("")
+		}
+	}
+}
+
+func (bp *bplusTree[K, V]) Get(key K) (V, bool) {
 	node, idx := bp.getNodeIdx(key)
 	if node == nil {
-		return nil, false
+		var zero V
+		return zero, false
 	}
 	return node.valus[idx], true
 }
 
-func (bp *bplusTree) getNodeIdx(key interface{}) (*Node, int) {
-	root := bp.root
-	if root == nil {
+func (bp *bplusTree[K, V]) getNodeIdx(key K) (*Node[K, V], int) {
+	node, idx := bp.leafIdx(key, false)
+	if node == nil || idx == len(node.keys) || bp.cmp(node.keys[idx], key) != 0 {
 		return nil, 0
 	}
-	for {
-		idx := bp.binarySearchIdx(key, root)
-		if root.isLeaf() {
-			if idx < len(root.keys) && bp.cmp(root.keys[idx], key) == 0 {
-				return root, idx
-			} else {
-				return nil, 0
-			}
-		}
-		root = root.childs[idx]
-	}
+	return node, idx
 }
 
-func (bp *bplusTree) prevNodeIdx(key interface{}) (*Node, int) {
-	root := bp.root
-	if root == nil {
+func (bp *bplusTree[K, V]) prevNodeIdx(key K) (*Node[K, V], int) {
+	node, idx := bp.leafIdx(key, false)
+	if node == nil {
 		return nil, 0
 	}
-	for {
-		idx := bp.binarySearchIdx(key, root)
-		if root.isLeaf() {
-			if idx == len(root.keys) || bp.cmp(root.keys[idx], key) >= 0 {
-				idx--
-			}
-			if idx >= 0 {
-				return root, idx
-			} else {
-				if root.prev == nil {
-					return nil, 0
-				} else {
-					return root.prev, len(root.prev.keys) - 1
-				}
-			}
-		}
-		root = root.childs[idx]
+	if idx > 0 {
+		return node, idx - 1
+	}
+	if node.prev == nil {
+		return nil, 0
+	}
+	return node.prev, len(node.prev.keys) - 1
+}
+
+func (bp *bplusTree[K, V]) nextNodeIdx(key K) (*Node[K, V], int) {
+	node, idx := bp.leafIdx(key, true)
+	if node == nil || idx == len(node.keys) {
+		return nil, 0
 	}
+	return node, idx
 }
 
-func (bp *bplusTree) nextNodeIdx(key interface{}) (*Node, int) {
+// 查找第一个>=key(upper为true时>key)的元素所在的叶子和位置，不存在时位置为最后一个叶子的len(keys)
+func (bp *bplusTree[K, V]) leafIdx(key K, upper bool) (*Node[K, V], int) {
 	root := bp.root
 	if root == nil {
 		return nil, 0
 	}
 	for {
-		idx := bp.binarySearchIdx(key, root)
+		idx := bp.searchIdx(key, root, upper)
 		if root.isLeaf() {
-			if idx == len(root.keys) || bp.cmp(key, root.keys[idx]) >= 0 {
-				idx++
-			}
-			if idx < len(root.keys) {
-				return root, idx
-			} else {
-				if root.next == nil {
-					return nil, 0
-				} else {
-					return root.next, 0
-				}
+			// 分隔key不一定是左侧子树中的最大值(删除后不会更新)，叶子中可能没有>=key的元素
+			if idx == len(root.keys) && root.next != nil {
+				return root.next, 0
 			}
+			return root, idx
 		}
 		root = root.childs[idx]
 	}
 }
 
-func (bp *bplusTree) binarySearchIdx(key interface{}, root *Node) int {
+func (bp *bplusTree[K, V]) searchIdx(key K, root *Node[K, V], upper bool) int {
+	if upper {
+		return bp.binarySearchUpperIdx(key, root)
+	}
+	return bp.binarySearchIdx(key, root)
+}
+
+func (bp *bplusTree[K, V]) binarySearchIdx(key K, root *Node[K, V]) int {
 	if len(root.keys) <= 4 {
 		idx := 0
 		for ; idx < len(root.keys) && bp.cmp(key, root.keys[idx]) > 0; idx++ {
@@ -234,7 +298,21 @@ func (bp *bplusTree) binarySearchIdx(key interface{}, root *Node) int {
 	}
 }
 
-func (bp *bplusTree) mostLeft() *Node {
+// 第一个>key的位置
+func (bp *bplusTree[K, V]) binarySearchUpperIdx(key K, root *Node[K, V]) int {
+	l, r := 0, len(root.keys)
+	for l < r {
+		mid := (l + r) / 2
+		if bp.cmp(key, root.keys[mid]) >= 0 {
+			l = mid + 1
+		} else {
+			r = mid
+		}
+	}
+	return l
+}
+
+func (bp *bplusTree[K, V]) mostLeft() *Node[K, V] {
 	if bp.root == nil {
 		return nil
 	}
@@ -245,7 +323,7 @@ func (bp *bplusTree) mostLeft() *Node {
 	return root
 }
 
-func (bp *bplusTree) mostRight() *Node {
+func (bp *bplusTree[K, V]) mostRight() *Node[K, V] {
 	if bp.root == nil {
 		return nil
 	}
@@ -256,9 +334,9 @@ func (bp *bplusTree) mostRight() *Node {
 	return root
 }
 
-func split(node *Node) (key interface{}, left *Node, right *Node) {
+func split[K, V any](node *Node[K, V]) (key K, left *Node[K, V], right *Node[K, V]) {
 	pivot := len(node.keys) / 2
-	right = &Node{}
+	right = &Node[K, V]{}
 	if node.isLeaf() {
 		key = node.keys[pivot-1]
 		right.keys = append(right.keys, node.keys[pivot:]...)
@@ -270,7 +348,9 @@ func split(node *Node) (key interface{}, left *Node, right *Node) {
 		right.keys = append(right.keys, node.keys[pivot+1:]...)
 		node.keys = node.keys[:pivot]
 		right.childs = append(right.childs, node.childs[pivot+1:]...)
+		right.counts = append(right.counts, node.counts[pivot+1:]...)
 		node.childs = node.childs[:pivot+1]
+		node.counts = node.counts[:pivot+1]
 	}
 	left = node
 	if node.isLeaf() {
@@ -282,7 +362,7 @@ func split(node *Node) (key interface{}, left *Node, right *Node) {
 	return key, left, right
 }
 
-func mergeRight(parnet *Node, idx int, maxKeys int) bool {
+func mergeRight[K, V any](parnet *Node[K, V], idx int, maxKeys int) bool {
 	if idx == len(parnet.keys) {
 		return false
 	}
@@ -302,14 +382,17 @@ func mergeRight(parnet *Node, idx int, maxKeys int) bool {
 		idxChild.keys = append(idxChild.keys, parnet.keys[idx])
 		idxChild.keys = append(idxChild.keys, idxRightChild.keys...)
 		idxChild.childs = append(idxChild.childs, idxRightChild.childs...)
+		idxChild.counts = append(idxChild.counts, idxRightChild.counts...)
 	}
 	parnet.childs[idx+1] = idxChild
+	parnet.counts[idx+1] = idxChild.count()
 	parnet.keys = decreaseSpace(parnet.keys, idx)
-	parnet.childs = decreaseSpace2(parnet.childs, idx)
+	parnet.childs = decreaseSpace(parnet.childs, idx)
+	parnet.counts = decreaseSpace(parnet.counts, idx)
 	return true
 }
 
-func mergeLeft(parnet *Node, idx int, maxKeys int) bool {
+func mergeLeft[K, V any](parnet *Node[K, V], idx int, maxKeys int) bool {
 	if idx == 0 {
 		return false
 	}
@@ -329,14 +412,17 @@ func mergeLeft(parnet *Node, idx int, maxKeys int) bool {
 		idxLeftChild.keys = append(idxLeftChild.keys, parnet.keys[idx-1])
 		idxLeftChild.keys = append(idxLeftChild.keys, idxChild.keys...)
 		idxLeftChild.childs = append(idxLeftChild.childs, idxChild.childs...)
+		idxLeftChild.counts = append(idxLeftChild.counts, idxChild.counts...)
 	}
 	parnet.childs[idx] = idxLeftChild
+	parnet.counts[idx] = idxLeftChild.count()
 	parnet.keys = decreaseSpace(parnet.keys, idx-1)
-	parnet.childs = decreaseSpace2(parnet.childs, idx-1)
+	parnet.childs = decreaseSpace(parnet.childs, idx-1)
+	parnet.counts = decreaseSpace(parnet.counts, idx-1)
 	return true
 }
 
-func borrowFromRight(parent *Node, idx int, minKeys int) bool {
+func borrowFromRight[K, V any](parent *Node[K, V], idx int, minKeys int) bool {
 	if idx == len(parent.keys) {
 		return false
 	}
@@ -355,14 +441,17 @@ func borrowFromRight(parent *Node, idx int, minKeys int) bool {
 	} else {
 		iChild.keys = append(iChild.keys, parent.keys[idx])
 		iChild.childs = append(iChild.childs, iRightChild.childs[0])
+		iChild.counts = append(iChild.counts, iRightChild.counts[0])
 		parent.keys[idx] = iRightChild.keys[0]
 		iRightChild.keys = decreaseSpace(iRightChild.keys, 0)
-		iRightChild.childs = decreaseSpace2(iRightChild.childs, 0)
+		iRightChild.childs = decreaseSpace(iRightChild.childs, 0)
+		iRightChild.counts = decreaseSpace(iRightChild.counts, 0)
 	}
+	parent.counts[idx], parent.counts[idx+1] = iChild.count(), iRightChild.count()
 	return true
 }
 
-func borrowFromLeft(parent *Node, idx int, minKeys int) bool {
+func borrowFromLeft[K, V any](parent *Node[K, V], idx int, minKeys int) bool {
 	if idx == 0 {
 		return false
 	}
@@ -383,46 +472,37 @@ func borrowFromLeft(parent *Node, idx int, minKeys int) bool {
 	} else {
 		iChild.keys = increaseSpace(iChild.keys, 0)
 		iChild.keys[0] = parent.keys[idx-1]
-		iChild.childs = increaseSpace2(iChild.childs, 0)
+		iChild.childs = increaseSpace(iChild.childs, 0)
+		iChild.counts = increaseSpace(iChild.counts, 0)
 		iChild.childs[0] = iLeftChild.childs[lli+1]
+		iChild.counts[0] = iLeftChild.counts[lli+1]
 		parent.keys[idx-1] = iLeftChild.keys[lli]
 		iLeftChild.keys = decreaseSpace(iLeftChild.keys, lli)
-		iLeftChild.childs = decreaseSpace2(iLeftChild.childs, lli+1)
+		iLeftChild.childs = decreaseSpace(iLeftChild.childs, lli+1)
+		iLeftChild.counts = decreaseSpace(iLeftChild.counts, lli+1)
 	}
+	parent.counts[idx-1], parent.counts[idx] = iLeftChild.count(), iChild.count()
 	return true
 }
 
-func (bp *bplusTree) maxKeys() int {
+func (bp *bplusTree[K, V]) maxKeys() int {
 	return bp.order - 1
 }
 
-func (bp *bplusTree) minKeys() int {
+func (bp *bplusTree[K, V]) minKeys() int {
 	return (bp.order+1)/2 - 1
 }
 
-func increaseSpace(arr []interface{}, idx int) []interface{} {
-	arr = append(arr, nil)
+func increaseSpace[T any](arr []T, idx int) []T {
+	var zero T
+	arr = append(arr, zero)
 	if idx != len(arr)-1 {
 		copy(arr[idx+1:], arr[idx:])
 	}
 	return arr
 }
 
-func increaseSpace2(arr []*Node, idx int) []*Node {
-	arr = append(arr, nil)
-	if idx != len(arr)-1 {
-		copy(arr[idx+1:], arr[idx:])
-	}
-	return arr
-}
-
-func decreaseSpace(arr []interface{}, idx int) []interface{} {
-	copy(arr[idx:], arr[idx+1:])
-	arr = arr[:len(arr)-1]
-	return arr
-}
-
-func decreaseSpace2(arr []*Node, idx int) []*Node {
+func decreaseSpace[T any](arr []T, idx int) []T {
 	copy(arr[idx:], arr[idx+1:])
 	arr = arr[:len(arr)-1]
 	return arr
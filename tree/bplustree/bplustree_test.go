@@ -13,7 +13,7 @@ import (
 	"github.com/mrtcx/plusdata/tree"
 )
 
-var intcmp = tree.IntComparator
+var intcmp = tree.OrderedComparator[int]
 
 func TestInsert(t *testing.T) {
 	nums := []int{1, 2, 4, 8, 9, 1024, 1024 + 1}
@@ -23,7 +23,7 @@ func TestInsert(t *testing.T) {
 		for _, order := range orders {
 			torder := order
 			t.Run(fmt.Sprintf("[num:%d order:%d]", tnum, torder), func(t *testing.T) {
-				rb := New(intcmp, torder)
+				rb := New[int, int](intcmp, torder)
 				assert.Equal(t, rb.root, nil)
 				for i := 1; i <= tnum; i++ {
 					rb.Insert(i, i)
@@ -33,7 +33,7 @@ func TestInsert(t *testing.T) {
 				}
 				assert.Equal(t, rb.size, tnum)
 
-				rb = New(intcmp, torder)
+				rb = New[int, int](intcmp, torder)
 				for i := tnum; i >= 1; i-- {
 					rb.Insert(i, i)
 					rb.Insert(i, i)
@@ -42,7 +42,7 @@ func TestInsert(t *testing.T) {
 				}
 				assert.Equal(t, rb.size, tnum)
 
-				rb = New(intcmp, torder)
+				rb = New[int, int](intcmp, torder)
 				for i, j := 1, tnum; i <= j; i, j = i+1, j-1 {
 					rb.Insert(i, i)
 					rb.Insert(j, j)
@@ -51,7 +51,7 @@ func TestInsert(t *testing.T) {
 				}
 				assert.Equal(t, rb.size, tnum)
 
-				rb = New(intcmp, torder)
+				rb = New[int, int](intcmp, torder)
 				for i := 1; i <= tnum; i++ {
 					randnum := int(rand.Int31() % int32(tnum))
 					rb.Insert(randnum, randnum)
@@ -115,8 +115,8 @@ func TestRemove(t *testing.T) {
 	}
 }
 
-func newBpNum(insertNum, testorder int) *bplusTree {
-	rb := New(intcmp, testorder)
+func newBpNum(insertNum, testorder int) *bplusTree[int, int] {
+	rb := New[int, int](intcmp, testorder)
 	for i := 1; i <= insertNum; i++ {
 		rb.Insert(i, i)
 	}
@@ -129,9 +129,9 @@ func TestGet(t *testing.T) {
 	for _, v := range orders {
 		testOrder := v
 		t.Run(fmt.Sprintf("order:%d", testOrder), func(t *testing.T) {
-			rb := New(intcmp, testOrder)
+			rb := New[int, int](intcmp, testOrder)
 			geti, ei := rb.Get(1)
-			assert.Equal(t, geti, nil)
+			assert.Equal(t, geti, 0)
 			assert.Equal(t, ei, false)
 			for i := 1; i <= num; i++ {
 				rb.Insert(i, i)
@@ -139,7 +139,7 @@ func TestGet(t *testing.T) {
 				assert.Equal(t, geti, i)
 				assert.Equal(t, ei, true)
 				geti, ei = rb.Get(i + 1)
-				assert.Equal(t, geti, nil)
+				assert.Equal(t, geti, 0)
 				assert.Equal(t, ei, false)
 			}
 			for i := 1; i <= num; i++ {
@@ -156,7 +156,7 @@ func TestGet(t *testing.T) {
 	}
 }
 
-func checkBalance(t *testing.T, bp *bplusTree, root *Node) bool {
+func checkBalance(t *testing.T, bp *bplusTree[int, int], root *Node[int, int]) bool {
 	if root == nil {
 		return root == bp.root && bp.size == 0
 	}
@@ -219,8 +219,16 @@ func checkBalance(t *testing.T, bp *bplusTree, root *Node) bool {
 				return false
 			}
 		}
+		if len(root.counts) != len(root.childs) {
+			t.Logf("fail counts:%v", root)
+			return false
+		}
 		for i := 0; i < len(root.childs); i++ {
 			child := root.childs[i]
+			if root.counts[i] != child.count() {
+				t.Logf("fail counts:%v child:%v", root, child)
+				return false
+			}
 			if !checkBalance(t, bp, child) {
 				return false
 			}
@@ -229,9 +237,9 @@ func checkBalance(t *testing.T, bp *bplusTree, root *Node) bool {
 	return true
 }
 
-func checkOrder(t *testing.T, bp *bplusTree, root *Node) bool {
-	var leftarr []interface{} = make([]interface{}, 0)
-	var rightarr []interface{} = make([]interface{}, 0)
+func checkOrder(t *testing.T, bp *bplusTree[int, int], root *Node[int, int]) bool {
+	var leftarr []int = make([]int, 0)
+	var rightarr []int = make([]int, 0)
 	leftToRightseqence(root, &leftarr)
 	rightToLeftseqence(root, &rightarr)
 	if len(leftarr) != bp.size {
@@ -259,7 +267,7 @@ func checkOrder(t *testing.T, bp *bplusTree, root *Node) bool {
 	return true
 }
 
-func leftToRightseqence(root *Node, arr *[]interface{}) {
+func leftToRightseqence(root *Node[int, int], arr *[]int) {
 	if root == nil {
 		return
 	}
@@ -272,7 +280,7 @@ func leftToRightseqence(root *Node, arr *[]interface{}) {
 	}
 }
 
-func rightToLeftseqence(root *Node, arr *[]interface{}) {
+func rightToLeftseqence(root *Node[int, int], arr *[]int) {
 	if root == nil {
 		return
 	}
@@ -287,6 +295,24 @@ func rightToLeftseqence(root *Node, arr *[]interface{}) {
 	}
 }
 
+func TestAnyKey(t *testing.T) {
+	num := 1024
+	var tr tree.Tree[any, any] = New[any, any](tree.IntComparator, 3)
+	for i := num; i >= 1; i-- {
+		tr.Insert(i, i)
+	}
+	e := tr.Left()
+	for i := 1; i <= num; i++ {
+		assert.Equal(t, e.Key(), i)
+		assert.Equal(t, e.Value(), i)
+		e = e.Next()
+	}
+	assert.Equal(t, e, nil)
+	geti, ei := tr.Get(num + 1)
+	assert.Equal(t, geti, nil)
+	assert.Equal(t, ei, false)
+}
+
 func BenchmarkInsert(b *testing.B) {
 	testsizes := []int{100000, 500000, 1000000, 2000000}
 	testNames := []string{"10w", "50w", "100w", "200w"}
@@ -303,7 +329,7 @@ func BenchmarkInsert(b *testing.B) {
 			}
 		})
 		b.Run(fmt.Sprintf("bplustree-%s", name), func(b *testing.B) {
-			tr := New(tree.IntComparator, 32)
+			tr := New[int, interface{}](intcmp, 32)
 			b.ResetTimer()
 			for i := 0; i < b.N; i++ {
 				for j := 0; j < size; j++ {
@@ -333,7 +359,7 @@ func BenchmarkRemove(b *testing.B) {
 			}
 		})
 		b.Run(fmt.Sprintf("bplustree-%s", name), func(b *testing.B) {
-			tr := New(tree.IntComparator, 32)
+			tr := New[int, interface{}](intcmp, 32)
 			for j := 0; j < size; j++ {
 				tr.Insert(j, nil)
 			}
@@ -366,7 +392,7 @@ func BenchmarkGet(b *testing.B) {
 			}
 		})
 		b.Run(fmt.Sprintf("bplustree-%s", name), func(b *testing.B) {
-			tr := New(tree.IntComparator, 32)
+			tr := New[int, interface{}](intcmp, 32)
 			for j := 0; j < size; j++ {
 				tr.Insert(j, nil)
 			}
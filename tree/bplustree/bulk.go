@@ -0,0 +1,84 @@
+// Copyright (c) 2025 Tian ChunXing. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package bplustree
+
+import "github.com/mrtcx/plusdata/tree"
+
+// BulkLoad 由严格递增的keys、values自底向上构建b+树，O(N)
+// 先从左到右按填充率fillFactor(0, 1]生成叶子节点并串成链表，再逐层生成内部节点;
+// fillFactor为1时节点全部填满，适合只读的场景，之后有插入时建议留出空间
+func BulkLoad[K, V any](cmp tree.Comparator[K], order int, keys []K, values []V, fillFactor float64) (*bplusTree[K, V], error) {
+	if len(keys) != len(values) {
+		return nil, tree.ErrLengthMismatch
+	}
+	if !(fillFactor > 0 && fillFactor <= 1) {
+		return nil, tree.ErrFillFactor
+	}
+	for i := 1; i < len(keys); i++ {
+		if cmp(keys[i-1], keys[i]) >= 0 {
+			return nil, tree.ErrNotSorted
+		}
+	}
+	bp := New[K, V](cmp, order)
+	if len(keys) == 0 {
+		return bp, nil
+	}
+	minKeys, maxKeys := max(bp.minKeys(), 1), bp.maxKeys()
+	per := max(min(int(fillFactor*float64(maxKeys)), maxKeys), minKeys)
+
+	var level []*Node[K, V]
+	var maxs []K //每个节点子树中最大的key，作为父节点中的分隔key
+	var prev *Node[K, V]
+	start := 0
+	for _, size := range chunks(len(keys), per, minKeys, maxKeys) {
+		leaf := &Node[K, V]{
+			keys:  append([]K(nil), keys[start:start+size]...),
+			valus: append([]V(nil), values[start:start+size]...),
+			prev:  prev,
+		}
+		if prev != nil {
+			prev.next = leaf
+		}
+		level, maxs = append(level, leaf), append(maxs, leaf.keys[size-1])
+		prev, start = leaf, start+size
+	}
+	for len(level) > 1 {
+		var parents []*Node[K, V]
+		var pmaxs []K
+		start = 0
+		for _, size := range chunks(len(level), per+1, minKeys+1, maxKeys+1) {
+			node := &Node[K, V]{
+				keys:   append([]K(nil), maxs[start:start+size-1]...),
+				childs: append([]*Node[K, V](nil), level[start:start+size]...),
+				counts: make([]int, size),
+			}
+			for i, child := range node.childs {
+				node.counts[i] = child.count()
+			}
+			parents, pmaxs = append(parents, node), append(pmaxs, maxs[start+size-1])
+			start += size
+		}
+		level, maxs = parents, pmaxs
+	}
+	bp.root, bp.size = level[0], len(keys)
+	return bp, nil
+}
+
+// 把n个元素按每组per个切分，最后一组不足lo个时，与前一组合并(不超过hi)或者平分
+func chunks(n, per, lo, hi int) []int {
+	var sizes []int
+	for ; n > 0; n -= per {
+		sizes = append(sizes, min(n, per))
+	}
+	if k := len(sizes); k > 1 && sizes[k-1] < lo {
+		total := sizes[k-2] + sizes[k-1]
+		if total <= hi {
+			sizes = append(sizes[:k-2], total)
+		} else {
+			sizes[k-2], sizes[k-1] = total-total/2, total/2
+		}
+	}
+	return sizes
+}
@@ -0,0 +1,24 @@
+// Copyright (c) 2025 Tian ChunXing. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package disk
+
+import (
+	"fmt"
+	"path/filepath"
+	"testing"
+
+	"github.com/mrtcx/plusdata/internal/treetest"
+	"github.com/mrtcx/plusdata/tree"
+)
+
+func TestConformance(t *testing.T) {
+	dir, n := t.TempDir(), 0
+	treetest.Run(t, func() tree.Tree[int, int] {
+		n++
+		dt := openInt(t, filepath.Join(dir, fmt.Sprintf("%d.db", n)), Options{Order: 4})
+		t.Cleanup(func() { dt.Close() })
+		return dt
+	})
+}
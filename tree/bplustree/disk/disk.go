@@ -0,0 +1,526 @@
+// Copyright (c) 2025 Tian ChunXing. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package disk
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/mrtcx/plusdata/codec"
+	"github.com/mrtcx/plusdata/tree"
+)
+
+var _ tree.Tree[int, int] = (*diskTree[int, int])(nil)
+
+// Options 页大小和阶数只在创建文件时使用，打开已有的文件时以文件中记录的为准
+type Options struct {
+	PageSize  int // 页大小，默认4096
+	Order     int // 阶数，为0时根据定长的codec和页大小计算，变长的codec需要指定
+	CacheSize int // 缓存的页数，默认1024
+}
+
+type diskTree[K, V any] struct {
+	cmp   tree.Comparator[K]
+	file  *os.File
+	pager *pager[K, V]
+	pool  *bufferPool[K, V]
+	meta  meta
+	err   error
+}
+
+// tree.Tree的方法没有返回error，读写文件出错时通过panic(diskError)跳出，在end中记录到err
+type diskError struct {
+	err error
+}
+
+// Open 打开或者创建path对应的页文件
+// 修改先保存在缓存中，被淘汰、Flush或者Close时才写入文件，Close前需要Flush才能保证数据落盘
+func Open[K, V any](path string, cmp tree.Comparator[K], kc codec.Codec[K], vc codec.Codec[V], opts Options) (*diskTree[K, V], error) {
+	file, err := os.OpenFile(path, os.O_RDWR|os.O_CREATE, 0644)
+	if err != nil {
+		return nil, err
+	}
+	t, err := open(file, cmp, kc, vc, opts)
+	if err != nil {
+		file.Close()
+		return nil, err
+	}
+	return t, nil
+}
+
+func open[K, V any](file *os.File, cmp tree.Comparator[K], kc codec.Codec[K], vc codec.Codec[V], opts Options) (*diskTree[K, V], error) {
+	if opts.PageSize == 0 {
+		opts.PageSize = 4096
+	}
+	if opts.CacheSize <= 0 {
+		opts.CacheSize = 1024
+	}
+	t := &diskTree[K, V]{cmp: cmp, file: file}
+	t.pager = &pager[K, V]{file: file, kc: kc, vc: vc}
+	info, err := file.Stat()
+	if err != nil {
+		return nil, err
+	}
+	if info.Size() == 0 {
+		if opts.PageSize < _minPageSize {
+			return nil, ErrPageSize
+		}
+		if opts.Order == 0 {
+			opts.Order = fixedOrder(opts.PageSize, kc, vc)
+		}
+		if opts.Order < 3 {
+			return nil, ErrOrder
+		}
+		t.meta = meta{pageSize: opts.PageSize, order: opts.Order, pages: 1}
+		t.pager.pageSize = opts.PageSize
+		if err := t.pager.writePage(0, nil); err != nil {
+			return nil, err
+		}
+		if err := t.pager.writeMeta(t.meta); err != nil {
+			return nil, err
+		}
+	} else if t.meta, err = t.pager.readMeta(); err != nil {
+		return nil, err
+	}
+	t.pager.pageSize = t.meta.pageSize
+	t.pool = newBufferPool(t.pager, opts.CacheSize)
+	return t, nil
+}
+
+// 定长的codec下，叶子节点和内部节点都能放进一页的最大阶数
+func fixedOrder[K, V any](pageSize int, kc codec.Codec[K], vc codec.Codec[V]) int {
+	ks, ok1 := kc.(codec.FixedSizer)
+	vs, ok2 := vc.(codec.FixedSizer)
+	if !ok1 || !ok2 {
+		return 0
+	}
+	leafKeys := (pageSize - _nodeHeader) / (ks.Size() + vs.Size())
+	internalKeys := (pageSize - _nodeHeader - _childSize) / (ks.Size() + _childSize)
+	return min(leafKeys, internalKeys) + 1
+}
+
+// Err 返回读写文件时遇到的第一个错误，出错后树的所有操作都不再生效
+func (t *diskTree[K, V]) Err() error {
+	return t.err
+}
+
+// Flush 把缓存中修改过的节点和元信息写入文件并同步到磁盘
+func (t *diskTree[K, V]) Flush() error {
+	if t.err != nil {
+		return t.err
+	}
+	if err := t.pool.flush(); err != nil {
+		t.err = err
+		return err
+	}
+	if err := t.pager.writeMeta(t.meta); err != nil {
+		t.err = err
+		return err
+	}
+	if err := t.file.Sync(); err != nil {
+		t.err = err
+		return err
+	}
+	return nil
+}
+
+func (t *diskTree[K, V]) Close() error {
+	err := t.Flush()
+	if cerr := t.file.Close(); err == nil {
+		err = cerr
+	}
+	return err
+}
+
+// 每个操作结束时调用(defer)，记录panic出来的错误，并把缓存淘汰到容量以内
+func (t *diskTree[K, V]) end() {
+	if r := recover(); r != nil {
+		e, ok := r.(diskError)
+		if !ok {
+			panic(r)
+		}
+		t.err = e.err
+		return
+	}
+	if err := t.pool.evict(); err != nil {
+		t.err = err
+	}
+}
+
+func (t *diskTree[K, V]) check(err error) {
+	if err != nil {
+		panic(diskError{err: err})
+	}
+}
+
+func (t *diskTree[K, V]) get(id pageID) *node[K, V] {
+	n, err := t.pool.get(id)
+	t.check(err)
+	return n
+}
+
+// 优先从空闲页链表中分配
+func (t *diskTree[K, V]) alloc() *node[K, V] {
+	id := t.meta.free
+	if id != 0 {
+		next, err := t.pager.readFree(id)
+		t.check(err)
+		t.meta.free = next
+	} else {
+		id = t.meta.pages
+		t.meta.pages++
+	}
+	n := &node[K, V]{id: id}
+	t.pool.add(n)
+	return n
+}
+
+func (t *diskTree[K, V]) free(n *node[K, V]) {
+	t.pool.drop(n.id)
+	t.check(t.pager.writeFree(n.id, t.meta.free))
+	t.meta.free = n.id
+}
+
+func (t *diskTree[K, V]) Clean() {
+	if t.err != nil {
+		return
+	}
+	t.pool.reset()
+	t.meta.root, t.meta.size, t.meta.free, t.meta.pages = 0, 0, 0, 1
+	if err := t.file.Truncate(int64(t.meta.pageSize)); err != nil {
+		t.err = err
+		return
+	}
+	if err := t.pager.writeMeta(t.meta); err != nil {
+		t.err = err
+	}
+}
+
+func (t *diskTree[K, V]) Size() int {
+	return t.meta.size
+}
+
+func (t *diskTree[K, V]) Empty() bool {
+	return t.meta.size == 0
+}
+
+func (t *diskTree[K, V]) Insert(key K, val V) {
+	if t.err != nil {
+		return
+	}
+	defer t.end()
+	if t.meta.root == 0 {
+		leaf := t.alloc()
+		leaf.keys, leaf.values = []K{key}, []V{val}
+		t.meta.root, t.meta.size = leaf.id, 1
+		return
+	}
+	root := t.insert(t.get(t.meta.root), key, val)
+	if len(root.keys) > t.maxKeys() {
+		skey, sleft, sright := t.split(root)
+		newRoot := t.alloc()
+		newRoot.keys = []K{skey}
+		newRoot.childs = []pageID{sleft.id, sright.id}
+		newRoot.counts = []int{sleft.count(), sright.count()}
+		t.meta.root = newRoot.id
+	}
+}
+
+func (t *diskTree[K, V]) insert(root *node[K, V], key K, val V) *node[K, V] {
+	idx := t.binarySearchIdx(key, root)
+	root.dirty = true
+	if root.isLeaf() {
+		if idx < len(root.keys) && t.cmp(root.keys[idx], key) == 0 {
+			root.values[idx] = val
+			return root
+		}
+		t.meta.size++
+		root.keys = increaseSpace(root.keys, idx)
+		root.values = increaseSpace(root.values, idx)
+		root.keys[idx], root.values[idx] = key, val
+		return root
+	}
+	child := t.insert(t.get(root.childs[idx]), key, val)
+	if len(child.keys) > t.maxKeys() {
+		skey, sleft, sright := t.split(child)
+		root.keys = increaseSpace(root.keys, idx)
+		root.childs = increaseSpace(root.childs, idx)
+		root.counts = increaseSpace(root.counts, idx)
+		root.keys[idx] = skey
+		root.childs[idx], root.childs[idx+1] = sleft.id, sright.id
+		root.counts[idx+1] = sright.count()
+	}
+	root.counts[idx] = child.count()
+	return root
+}
+
+func (t *diskTree[K, V]) Remove(key K) {
+	if t.err != nil || t.meta.root == 0 {
+		return
+	}
+	defer t.end()
+	root := t.remove(t.get(t.meta.root), key)
+	if root.isLeaf() {
+		if len(root.keys) == 0 {
+			t.free(root)
+			t.meta.root = 0
+		}
+		return
+	}
+	if len(root.childs) < 2 {
+		t.meta.root = root.childs[0]
+		t.free(root)
+	}
+}
+
+func (t *diskTree[K, V]) remove(root *node[K, V], key K) *node[K, V] {
+	idx := t.binarySearchIdx(key, root)
+	if root.isLeaf() {
+		if idx < len(root.keys) && t.cmp(root.keys[idx], key) == 0 {
+			t.meta.size--
+			root.keys = decreaseSpace(root.keys, idx)
+			root.values = decreaseSpace(root.values, idx)
+			root.dirty = true
+		}
+		return root
+	}
+	child := t.remove(t.get(root.childs[idx]), key)
+	if root.counts[idx] != child.count() {
+		root.counts[idx] = child.count()
+		root.dirty = true
+	}
+	if len(child.keys) < t.minKeys() {
+		if !t.borrowFromRight(root, idx) &&
+			!t.mergeRight(root, idx) &&
+			!t.borrowFromLeft(root, idx) &&
+			!t.mergeLeft(root, idx) {
+			//只有在页中的数据不一致时才会出现：子节点不足半满，但父节点中没有兄弟节点
+			t.check(fmt.Errorf("%w: page %d has %d keys and no sibling in parent page %d", ErrCorrupt, child.id, len(child.keys), root.id))
+		}
+		root.dirty = true
+	}
+	return root
+}
+
+func (t *diskTree[K, V]) Get(key K) (value V, exist bool) {
+	if t.err != nil {
+		return value, false
+	}
+	defer t.end()
+	node, idx := t.leafIdx(key, false)
+	if node == nil || idx == len(node.keys) || t.cmp(node.keys[idx], key) != 0 {
+		return value, false
+	}
+	return node.values[idx], true
+}
+
+// 查找第一个>=key(upper为true时>key)的元素所在的叶子和位置，不存在时位置为最后一个叶子的len(keys)
+func (t *diskTree[K, V]) leafIdx(key K, upper bool) (*node[K, V], int) {
+	if t.meta.root == 0 {
+		return nil, 0
+	}
+	root := t.get(t.meta.root)
+	for {
+		idx := t.searchIdx(key, root, upper)
+		if root.isLeaf() {
+			// 分隔key不一定是左侧子树中的最大值(删除后不会更新)，叶子中可能没有>=key的元素
+			if idx == len(root.keys) && root.next != 0 {
+				return t.get(root.next), 0
+			}
+			return root, idx
+		}
+		root = t.get(root.childs[idx])
+	}
+}
+
+func (t *diskTree[K, V]) searchIdx(key K, root *node[K, V], upper bool) int {
+	if upper {
+		return t.binarySearchUpperIdx(key, root)
+	}
+	return t.binarySearchIdx(key, root)
+}
+
+func (t *diskTree[K, V]) binarySearchIdx(key K, root *node[K, V]) int {
+	l, r := 0, len(root.keys)
+	for l < r {
+		mid := (l + r) / 2
+		if t.cmp(key, root.keys[mid]) > 0 {
+			l = mid + 1
+		} else {
+			r = mid
+		}
+	}
+	return l
+}
+
+// 第一个>key的位置
+func (t *diskTree[K, V]) binarySearchUpperIdx(key K, root *node[K, V]) int {
+	l, r := 0, len(root.keys)
+	for l < r {
+		mid := (l + r) / 2
+		if t.cmp(key, root.keys[mid]) >= 0 {
+			l = mid + 1
+		} else {
+			r = mid
+		}
+	}
+	return l
+}
+
+func (t *diskTree[K, V]) split(n *node[K, V]) (key K, left *node[K, V], right *node[K, V]) {
+	pivot := len(n.keys) / 2
+	right = t.alloc()
+	if n.isLeaf() {
+		key = n.keys[pivot-1]
+		right.keys = append(right.keys, n.keys[pivot:]...)
+		right.values = append(right.values, n.values[pivot:]...)
+		n.keys, n.values = n.keys[:pivot:pivot], n.values[:pivot:pivot]
+		if n.next != 0 {
+			next := t.get(n.next)
+			next.prev, next.dirty = right.id, true
+		}
+		n.next, right.next, right.prev = right.id, n.next, n.id
+	} else {
+		key = n.keys[pivot]
+		right.keys = append(right.keys, n.keys[pivot+1:]...)
+		right.childs = append(right.childs, n.childs[pivot+1:]...)
+		right.counts = append(right.counts, n.counts[pivot+1:]...)
+		n.keys = n.keys[:pivot:pivot]
+		n.childs, n.counts = n.childs[:pivot+1:pivot+1], n.counts[:pivot+1:pivot+1]
+	}
+	n.dirty = true
+	return key, n, right
+}
+
+func (t *diskTree[K, V]) mergeRight(parent *node[K, V], idx int) bool {
+	if idx == len(parent.keys) {
+		return false
+	}
+	child, right := t.get(parent.childs[idx]), t.get(parent.childs[idx+1])
+	if len(child.keys)+len(right.keys) > t.maxKeys() {
+		return false
+	}
+	t.merge(parent, idx, child, right)
+	return true
+}
+
+func (t *diskTree[K, V]) mergeLeft(parent *node[K, V], idx int) bool {
+	if idx == 0 {
+		return false
+	}
+	left, child := t.get(parent.childs[idx-1]), t.get(parent.childs[idx])
+	if len(left.keys)+len(child.keys) > t.maxKeys() {
+		return false
+	}
+	t.merge(parent, idx-1, left, child)
+	return true
+}
+
+// 把childs[idx+1]合并到childs[idx]中，并释放childs[idx+1]的页
+func (t *diskTree[K, V]) merge(parent *node[K, V], idx int, left, right *node[K, V]) {
+	if left.isLeaf() {
+		left.keys = append(left.keys, right.keys...)
+		left.values = append(left.values, right.values...)
+		if right.next != 0 {
+			next := t.get(right.next)
+			next.prev, next.dirty = left.id, true
+		}
+		left.next = right.next
+	} else {
+		left.keys = append(left.keys, parent.keys[idx])
+		left.keys = append(left.keys, right.keys...)
+		left.childs = append(left.childs, right.childs...)
+		left.counts = append(left.counts, right.counts...)
+	}
+	left.dirty = true
+	parent.counts[idx] = left.count()
+	parent.keys = decreaseSpace(parent.keys, idx)
+	parent.childs = decreaseSpace(parent.childs, idx+1)
+	parent.counts = decreaseSpace(parent.counts, idx+1)
+	t.free(right)
+}
+
+func (t *diskTree[K, V]) borrowFromRight(parent *node[K, V], idx int) bool {
+	if idx == len(parent.keys) {
+		return false
+	}
+	child, right := t.get(parent.childs[idx]), t.get(parent.childs[idx+1])
+	if len(right.keys) <= t.minKeys() {
+		return false
+	}
+	if child.isLeaf() {
+		parent.keys[idx] = right.keys[0]
+		child.keys = append(child.keys, right.keys[0])
+		child.values = append(child.values, right.values[0])
+		right.keys = decreaseSpace(right.keys, 0)
+		right.values = decreaseSpace(right.values, 0)
+	} else {
+		child.keys = append(child.keys, parent.keys[idx])
+		child.childs = append(child.childs, right.childs[0])
+		child.counts = append(child.counts, right.counts[0])
+		parent.keys[idx] = right.keys[0]
+		right.keys = decreaseSpace(right.keys, 0)
+		right.childs = decreaseSpace(right.childs, 0)
+		right.counts = decreaseSpace(right.counts, 0)
+	}
+	child.dirty, right.dirty = true, true
+	parent.counts[idx], parent.counts[idx+1] = child.count(), right.count()
+	return true
+}
+
+func (t *diskTree[K, V]) borrowFromLeft(parent *node[K, V], idx int) bool {
+	if idx == 0 {
+		return false
+	}
+	left, child := t.get(parent.childs[idx-1]), t.get(parent.childs[idx])
+	if len(left.keys) <= t.minKeys() {
+		return false
+	}
+	lli := len(left.keys) - 1
+	if child.isLeaf() {
+		child.keys = increaseSpace(child.keys, 0)
+		child.values = increaseSpace(child.values, 0)
+		child.keys[0], child.values[0] = left.keys[lli], left.values[lli]
+		left.keys = decreaseSpace(left.keys, lli)
+		left.values = decreaseSpace(left.values, lli)
+		parent.keys[idx-1] = left.keys[lli-1]
+	} else {
+		child.keys = increaseSpace(child.keys, 0)
+		child.childs = increaseSpace(child.childs, 0)
+		child.counts = increaseSpace(child.counts, 0)
+		child.keys[0] = parent.keys[idx-1]
+		child.childs[0], child.counts[0] = left.childs[lli+1], left.counts[lli+1]
+		parent.keys[idx-1] = left.keys[lli]
+		left.keys = decreaseSpace(left.keys, lli)
+		left.childs = decreaseSpace(left.childs, lli+1)
+		left.counts = decreaseSpace(left.counts, lli+1)
+	}
+	child.dirty, left.dirty = true, true
+	parent.counts[idx-1], parent.counts[idx] = left.count(), child.count()
+	return true
+}
+
+func (t *diskTree[K, V]) maxKeys() int {
+	return t.meta.order - 1
+}
+
+func (t *diskTree[K, V]) minKeys() int {
+	return (t.meta.order+1)/2 - 1
+}
+
+func increaseSpace[T any](arr []T, idx int) []T {
+	var zero T
+	arr = append(arr, zero)
+	if idx != len(arr)-1 {
+		copy(arr[idx+1:], arr[idx:])
+	}
+	return arr
+}
+
+func decreaseSpace[T any](arr []T, idx int) []T {
+	copy(arr[idx:], arr[idx+1:])
+	arr = arr[:len(arr)-1]
+	return arr
+}
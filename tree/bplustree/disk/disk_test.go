@@ -0,0 +1,293 @@
+// Copyright (c) 2025 Tian ChunXing. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package disk
+
+import (
+	"errors"
+	"fmt"
+	"math/rand"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/mrtcx/plusdata/codec"
+	"github.com/mrtcx/plusdata/internal/assert"
+	"github.com/mrtcx/plusdata/tree"
+)
+
+var intcmp = tree.OrderedComparator[int]
+
+func openInt(t *testing.T, path string, opts Options) *diskTree[int, int] {
+	dt, err := Open[int, int](path, intcmp, codec.IntCodec{}, codec.IntCodec{}, opts)
+	assert.Equal(t, err, nil)
+	return dt
+}
+
+// 和map对比元素、顺序、Rank、Select以及每个节点的counts
+func checkTree(t *testing.T, dt *diskTree[int, int], model map[int]int) {
+	assert.Equal(t, dt.Err(), nil)
+	assert.Equal(t, dt.Size(), len(model))
+	keys := make([]int, 0, len(model))
+	for k := range model {
+		keys = append(keys, k)
+	}
+	sort.Ints(keys)
+	got := []int{}
+	dt.AscendGreaterOrEqual(-1<<62, func(key, value int) bool {
+		assert.Equal(t, value, model[key])
+		got = append(got, key)
+		return true
+	})
+	assert.Equal(t, fmt.Sprint(got), fmt.Sprint(keys))
+	for i, k := range keys {
+		v, ok := dt.Get(k)
+		assert.Equal(t, ok, true)
+		assert.Equal(t, v, model[k])
+		assert.Equal(t, dt.Rank(k), i)
+		assert.Equal(t, dt.Select(i).Key(), k)
+	}
+	if dt.meta.root != 0 {
+		assert.Equal(t, checkCount(t, dt, dt.meta.root), len(model))
+	}
+	assert.Equal(t, dt.Err(), nil)
+}
+
+func checkCount(t *testing.T, dt *diskTree[int, int], id pageID) int {
+	n, err := dt.pool.get(id)
+	assert.Equal(t, err, nil)
+	if n.isLeaf() {
+		return len(n.keys)
+	}
+	childs, counts := n.childs, n.counts
+	total := 0
+	for i := range childs {
+		assert.Equal(t, checkCount(t, dt, childs[i]), counts[i])
+		total += counts[i]
+	}
+	return total
+}
+
+func TestInsertRemove(t *testing.T) {
+	for _, opts := range []Options{
+		{Order: 3, CacheSize: 2},
+		{Order: 4, CacheSize: 8},
+		{Order: 8, CacheSize: 16},
+		{PageSize: 256},
+	} {
+		topts := opts
+		t.Run(fmt.Sprintf("%+v", topts), func(t *testing.T) {
+			dt := openInt(t, filepath.Join(t.TempDir(), "tree"), topts)
+			defer dt.Close()
+			model := map[int]int{}
+			for i := 0; i < 3000; i++ {
+				k := rand.Intn(500)
+				if rand.Intn(3) == 0 {
+					dt.Remove(k)
+					delete(model, k)
+				} else {
+					dt.Insert(k, i)
+					model[k] = i
+				}
+			}
+			checkTree(t, dt, model)
+			for k := range model {
+				dt.Remove(k)
+				delete(model, k)
+			}
+			checkTree(t, dt, model)
+			assert.Equal(t, dt.meta.root, pageID(0))
+		})
+	}
+}
+
+func TestReopen(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "tree")
+	opts := Options{PageSize: 512, CacheSize: 4}
+	dt := openInt(t, path, opts)
+	model := map[int]int{}
+	for i := 0; i < 2000; i++ {
+		dt.Insert(i, i*10)
+		model[i] = i * 10
+	}
+	assert.Equal(t, dt.Close(), nil)
+
+	dt = openInt(t, path, Options{CacheSize: 4})
+	assert.Equal(t, dt.meta.pageSize, 512)
+	checkTree(t, dt, model)
+	for i := 0; i < 2000; i += 2 {
+		dt.Remove(i)
+		delete(model, i)
+	}
+	assert.Equal(t, dt.Close(), nil)
+
+	dt = openInt(t, path, opts)
+	checkTree(t, dt, model)
+	// 删除释放的页会被重新使用
+	pages := dt.meta.pages
+	for i := 0; i < 2000; i += 2 {
+		dt.Insert(i, i*10)
+		model[i] = i * 10
+	}
+	assert.Equal(t, dt.meta.pages <= pages+1, true)
+	assert.Equal(t, dt.Close(), nil)
+
+	dt = openInt(t, path, opts)
+	defer dt.Close()
+	checkTree(t, dt, model)
+}
+
+func TestRange(t *testing.T) {
+	dt := openInt(t, filepath.Join(t.TempDir(), "tree"), Options{Order: 4, CacheSize: 2})
+	defer dt.Close()
+	keys := []int{}
+	for i := 0; i < 200; i += 2 {
+		dt.Insert(i, i)
+		keys = append(keys, i)
+	}
+	bounds := []tree.Bound{tree.ClosedOpen, tree.Closed, tree.OpenClosed, tree.Open}
+	for _, b := range bounds {
+		for _, r := range [][2]int{{-5, 300}, {0, 198}, {1, 2}, {10, 10}, {51, 149}, {100, 0}} {
+			lo, hi := r[0], r[1]
+			want := []int{}
+			for _, k := range keys {
+				if (k > lo || k == lo && b.LoInclusive()) && (k < hi || k == hi && b.HiInclusive()) {
+					want = append(want, k)
+				}
+			}
+			got := []int{}
+			dt.AscendRange(lo, hi, func(key, value int) bool {
+				got = append(got, key)
+				return true
+			}, b)
+			assert.Equal(t, fmt.Sprint(got), fmt.Sprint(want))
+			got = got[:0]
+			dt.DescendRange(lo, hi, func(key, value int) bool {
+				got = append([]int{key}, got...)
+				return true
+			}, b)
+			assert.Equal(t, fmt.Sprint(got), fmt.Sprint(want))
+		}
+	}
+	cnt := 0
+	dt.AscendGreaterOrEqual(50, func(key, value int) bool {
+		cnt++
+		return cnt < 10
+	})
+	assert.Equal(t, cnt, 10)
+	assert.Equal(t, dt.pool.lru.Len() <= 2, true)
+}
+
+func TestElement(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "tree")
+	dt := openInt(t, path, Options{Order: 3, CacheSize: 1})
+	assert.Equal(t, dt.Left(), nil)
+	assert.Equal(t, dt.Right(), nil)
+	for i := 1; i <= 100; i++ {
+		dt.Insert(i*2, i)
+	}
+	assert.Equal(t, dt.Left().Key(), 2)
+	assert.Equal(t, dt.Right().Key(), 200)
+	assert.Equal(t, dt.Find(3), nil)
+	assert.Equal(t, dt.Prev(2), nil)
+	assert.Equal(t, dt.Next(200), nil)
+	assert.Equal(t, dt.Prev(3).Key(), 2)
+	assert.Equal(t, dt.Next(3).Key(), 4)
+
+	i := 0
+	for e := dt.Left(); e != nil; e = e.Next() {
+		i++
+		assert.Equal(t, e.Key(), i*2)
+		e.SetValue(-i)
+	}
+	assert.Equal(t, i, 100)
+	for e := dt.Right(); e != nil; e = e.Prev() {
+		assert.Equal(t, e.Value(), -i)
+		i--
+	}
+	assert.Equal(t, dt.Close(), nil)
+
+	dt = openInt(t, path, Options{})
+	defer dt.Close()
+	assert.Equal(t, dt.Find(50).Value(), -25)
+}
+
+func TestClean(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "tree")
+	dt := openInt(t, path, Options{Order: 4})
+	for i := 0; i < 1000; i++ {
+		dt.Insert(i, i)
+	}
+	dt.Clean()
+	checkTree(t, dt, map[int]int{})
+	dt.Insert(1, 1)
+	assert.Equal(t, dt.Close(), nil)
+
+	dt = openInt(t, path, Options{})
+	defer dt.Close()
+	checkTree(t, dt, map[int]int{1: 1})
+	assert.Equal(t, dt.meta.pages, pageID(2))
+}
+
+func TestStringCodec(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "tree")
+	_, err := Open[string, string](path, tree.OrderedComparator[string], codec.StringCodec{}, codec.StringCodec{}, Options{})
+	assert.Equal(t, err, ErrOrder)
+
+	dt, err := Open[string, string](path, tree.OrderedComparator[string], codec.StringCodec{}, codec.StringCodec{}, Options{Order: 8, CacheSize: 4})
+	assert.Equal(t, err, nil)
+	for i := 0; i < 500; i++ {
+		dt.Insert(fmt.Sprint(i), strings.Repeat("v", i%20))
+	}
+	assert.Equal(t, dt.Close(), nil)
+
+	dt, err = Open[string, string](path, tree.OrderedComparator[string], codec.StringCodec{}, codec.StringCodec{}, Options{})
+	assert.Equal(t, err, nil)
+	for i := 0; i < 500; i++ {
+		v, ok := dt.Get(fmt.Sprint(i))
+		assert.Equal(t, ok, true)
+		assert.Equal(t, v, strings.Repeat("v", i%20))
+	}
+	// 节点超过页大小时记录错误，之后的操作不再生效
+	dt.Insert("big", strings.Repeat("v", 8192))
+	assert.Equal(t, dt.Flush(), ErrPageOverflow)
+	assert.Equal(t, dt.Err(), ErrPageOverflow)
+	dt.Insert("x", "x")
+	_, ok := dt.Get("x")
+	assert.Equal(t, ok, false)
+	dt.Close()
+}
+
+// 父节点中缺少兄弟节点时，删除返回ErrCorrupt，不会panic
+func TestRemoveCorrupt(t *testing.T) {
+	dt := openInt(t, filepath.Join(t.TempDir(), "tree"), Options{Order: 4})
+	defer dt.Close()
+	for i := 0; i < 8; i++ {
+		dt.Insert(i, i)
+	}
+	root := dt.get(dt.meta.root)
+	assert.Greater(t, len(root.childs), 1)
+	child := dt.get(root.childs[0])
+	child.keys, child.values = child.keys[:dt.minKeys()], child.values[:dt.minKeys()]
+	root.childs, root.counts, root.keys = root.childs[:1], []int{dt.minKeys()}, root.keys[:0]
+	dt.Remove(child.keys[0])
+	assert.Equal(t, errors.Is(dt.Err(), ErrCorrupt), true)
+	assert.Equal(t, strings.Contains(dt.Err().Error(), "no sibling"), true)
+}
+
+func TestOpenError(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "tree")
+	assert.Equal(t, os.WriteFile(path, []byte("not a tree"), 0644), nil)
+	_, err := Open[int, int](path, intcmp, codec.IntCodec{}, codec.IntCodec{}, Options{})
+	assert.Equal(t, err, ErrCorrupt)
+
+	_, err = Open[int, int](filepath.Join(dir, "small"), intcmp, codec.IntCodec{}, codec.IntCodec{}, Options{PageSize: 64})
+	assert.Equal(t, err, ErrPageSize)
+
+	_, err = Open[int, int](filepath.Join(dir, "order"), intcmp, codec.IntCodec{}, codec.IntCodec{}, Options{Order: 2})
+	assert.Equal(t, err, ErrOrder)
+}
@@ -0,0 +1,106 @@
+// Copyright (c) 2025 Tian ChunXing. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package disk
+
+import "github.com/mrtcx/plusdata/tree"
+
+// element 保存key和value的副本，节点随时可能被淘汰出缓存，所以不持有节点，
+// Next、Prev和SetValue都会按key重新查找
+type element[K, V any] struct {
+	t     *diskTree[K, V]
+	key   K
+	value V
+}
+
+func (e *element[K, V]) Key() K {
+	return e.key
+}
+
+func (e *element[K, V]) Value() V {
+	return e.value
+}
+
+func (e *element[K, V]) SetValue(value V) {
+	e.value = value
+	e.t.Insert(e.key, value)
+}
+
+func (e *element[K, V]) Next() tree.Element[K, V] {
+	return e.t.Next(e.key)
+}
+
+func (e *element[K, V]) Prev() tree.Element[K, V] {
+	return e.t.Prev(e.key)
+}
+
+func (t *diskTree[K, V]) newElement(n *node[K, V], idx int) tree.Element[K, V] {
+	if n == nil || idx < 0 || idx >= len(n.keys) {
+		return nil
+	}
+	return &element[K, V]{t: t, key: n.keys[idx], value: n.values[idx]}
+}
+
+func (t *diskTree[K, V]) Find(key K) tree.Element[K, V] {
+	if t.err != nil {
+		return nil
+	}
+	defer t.end()
+	n, idx := t.leafIdx(key, false)
+	if n == nil || idx == len(n.keys) || t.cmp(n.keys[idx], key) != 0 {
+		return nil
+	}
+	return t.newElement(n, idx)
+}
+
+func (t *diskTree[K, V]) Left() tree.Element[K, V] {
+	if t.err != nil || t.meta.root == 0 {
+		return nil
+	}
+	defer t.end()
+	n := t.get(t.meta.root)
+	for !n.isLeaf() {
+		n = t.get(n.childs[0])
+	}
+	return t.newElement(n, 0)
+}
+
+func (t *diskTree[K, V]) Right() tree.Element[K, V] {
+	if t.err != nil || t.meta.root == 0 {
+		return nil
+	}
+	defer t.end()
+	n := t.get(t.meta.root)
+	for !n.isLeaf() {
+		n = t.get(n.childs[len(n.childs)-1])
+	}
+	return t.newElement(n, len(n.keys)-1)
+}
+
+func (t *diskTree[K, V]) Prev(key K) tree.Element[K, V] {
+	if t.err != nil {
+		return nil
+	}
+	defer t.end()
+	n, idx := t.leafIdx(key, false)
+	if n == nil {
+		return nil
+	}
+	if idx > 0 {
+		return t.newElement(n, idx-1)
+	}
+	if n.prev == 0 {
+		return nil
+	}
+	n = t.get(n.prev)
+	return t.newElement(n, len(n.keys)-1)
+}
+
+func (t *diskTree[K, V]) Next(key K) tree.Element[K, V] {
+	if t.err != nil {
+		return nil
+	}
+	defer t.end()
+	return t.newElement(t.leafIdx(key, true))
+}
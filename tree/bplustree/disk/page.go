@@ -0,0 +1,258 @@
+// Copyright (c) 2025 Tian ChunXing. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package disk
+
+import (
+	"encoding/binary"
+	"errors"
+	"io"
+	"os"
+
+	"github.com/mrtcx/plusdata/codec"
+)
+
+var (
+	ErrCorrupt      = errors.New("disk: page is corrupt")
+	ErrPageOverflow = errors.New("disk: node does not fit in a page, use a smaller order")
+	ErrOrder        = errors.New("disk: order must be at least 3, or 0 with fixed size codecs")
+	ErrPageSize     = errors.New("disk: page size must be at least 128")
+)
+
+// 页号，第0页为元信息页，所以0也表示空
+type pageID uint64
+
+const (
+	_magic       = "PDBPTREE"
+	_version     = 1
+	_metaSize    = 8 + 4*3 + 8*4
+	_nodeHeader  = 32 // 节点页头部的最大长度: 类型、key个数、叶子的前后页号
+	_childSize   = 20 // 内部节点中每个子节点的页号和数量，uvarint编码的最大长度
+	_minPageSize = 128
+)
+
+const (
+	pageLeaf byte = iota + 1
+	pageInternal
+	pageFree
+)
+
+// 元信息页:  magic | version | pageSize | order | root | size | free | pages
+type meta struct {
+	pageSize int
+	order    int
+	root     pageID
+	size     int
+	free     pageID // 空闲页链表的头，空闲页中记录下一个空闲页
+	pages    pageID // 文件中的页数，没有空闲页时从末尾分配
+}
+
+type node[K, V any] struct {
+	id     pageID
+	keys   []K
+	values []V
+	childs []pageID
+	counts []int //counts[i]为子树childs[i]中叶子节点key的数量
+	next   pageID
+	prev   pageID
+	dirty  bool
+}
+
+func (n *node[K, V]) isLeaf() bool {
+	return len(n.childs) == 0
+}
+
+// 子树中叶子节点key的数量
+func (n *node[K, V]) count() int {
+	if n.isLeaf() {
+		return len(n.keys)
+	}
+	cnt := 0
+	for _, c := range n.counts {
+		cnt += c
+	}
+	return cnt
+}
+
+// pager 负责页的读写和编解码，不做缓存
+type pager[K, V any] struct {
+	file     *os.File
+	pageSize int
+	kc       codec.Codec[K]
+	vc       codec.Codec[V]
+	buf      []byte
+}
+
+func (p *pager[K, V]) readMeta() (meta, error) {
+	buf := make([]byte, _metaSize)
+	if _, err := p.file.ReadAt(buf, 0); err == io.EOF || err == io.ErrUnexpectedEOF {
+		return meta{}, ErrCorrupt
+	} else if err != nil {
+		return meta{}, err
+	}
+	if string(buf[:8]) != _magic || binary.LittleEndian.Uint32(buf[8:]) != _version {
+		return meta{}, ErrCorrupt
+	}
+	m := meta{
+		pageSize: int(binary.LittleEndian.Uint32(buf[12:])),
+		order:    int(binary.LittleEndian.Uint32(buf[16:])),
+		root:     pageID(binary.LittleEndian.Uint64(buf[20:])),
+		size:     int(binary.LittleEndian.Uint64(buf[28:])),
+		free:     pageID(binary.LittleEndian.Uint64(buf[36:])),
+		pages:    pageID(binary.LittleEndian.Uint64(buf[44:])),
+	}
+	if m.pageSize < _minPageSize || m.order < 3 || m.pages == 0 {
+		return meta{}, ErrCorrupt
+	}
+	return m, nil
+}
+
+func (p *pager[K, V]) writeMeta(m meta) error {
+	buf := make([]byte, 0, _metaSize)
+	buf = append(buf, _magic...)
+	buf = binary.LittleEndian.AppendUint32(buf, _version)
+	buf = binary.LittleEndian.AppendUint32(buf, uint32(m.pageSize))
+	buf = binary.LittleEndian.AppendUint32(buf, uint32(m.order))
+	buf = binary.LittleEndian.AppendUint64(buf, uint64(m.root))
+	buf = binary.LittleEndian.AppendUint64(buf, uint64(m.size))
+	buf = binary.LittleEndian.AppendUint64(buf, uint64(m.free))
+	buf = binary.LittleEndian.AppendUint64(buf, uint64(m.pages))
+	_, err := p.file.WriteAt(buf, 0)
+	return err
+}
+
+func (p *pager[K, V]) readPage(id pageID) ([]byte, error) {
+	if p.buf == nil {
+		p.buf = make([]byte, p.pageSize)
+	}
+	if _, err := p.file.ReadAt(p.buf, int64(id)*int64(p.pageSize)); err != nil {
+		return nil, err
+	}
+	return p.buf, nil
+}
+
+func (p *pager[K, V]) writePage(id pageID, buf []byte) error {
+	if len(buf) > p.pageSize {
+		return ErrPageOverflow
+	}
+	// 补齐整页，文件末尾的页也是完整的
+	for len(buf) < p.pageSize {
+		buf = append(buf, 0)
+	}
+	_, err := p.file.WriteAt(buf, int64(id)*int64(p.pageSize))
+	return err
+}
+
+// 叶子页:  leaf | n | prev | next | (key, value) * n
+// 内部页:  internal | n | (child, count) * (n+1) | key * n
+func (p *pager[K, V]) write(n *node[K, V]) error {
+	buf := make([]byte, 0, p.pageSize)
+	if n.isLeaf() {
+		buf = append(buf, pageLeaf)
+		buf = binary.AppendUvarint(buf, uint64(len(n.keys)))
+		buf = binary.AppendUvarint(buf, uint64(n.prev))
+		buf = binary.AppendUvarint(buf, uint64(n.next))
+		for i := range n.keys {
+			buf = p.kc.Append(buf, n.keys[i])
+			buf = p.vc.Append(buf, n.values[i])
+		}
+	} else {
+		buf = append(buf, pageInternal)
+		buf = binary.AppendUvarint(buf, uint64(len(n.keys)))
+		for i := range n.childs {
+			buf = binary.AppendUvarint(buf, uint64(n.childs[i]))
+			buf = binary.AppendUvarint(buf, uint64(n.counts[i]))
+		}
+		for i := range n.keys {
+			buf = p.kc.Append(buf, n.keys[i])
+		}
+	}
+	return p.writePage(n.id, buf)
+}
+
+func (p *pager[K, V]) read(id pageID) (*node[K, V], error) {
+	buf, err := p.readPage(id)
+	if err != nil {
+		return nil, err
+	}
+	d := decoder{buf: buf[1:]}
+	n := &node[K, V]{id: id}
+	cnt := int(d.uvarint())
+	switch buf[0] {
+	case pageLeaf:
+		n.prev, n.next = pageID(d.uvarint()), pageID(d.uvarint())
+		if d.err || cnt > len(d.buf) {
+			return nil, ErrCorrupt
+		}
+		n.keys, n.values = make([]K, cnt), make([]V, cnt)
+		for i := 0; i < cnt; i++ {
+			n.keys[i] = decode(&d, p.kc)
+			n.values[i] = decode(&d, p.vc)
+		}
+	case pageInternal:
+		if d.err || cnt+1 > len(d.buf) {
+			return nil, ErrCorrupt
+		}
+		n.childs, n.counts = make([]pageID, cnt+1), make([]int, cnt+1)
+		for i := 0; i <= cnt; i++ {
+			n.childs[i], n.counts[i] = pageID(d.uvarint()), int(d.uvarint())
+		}
+		n.keys = make([]K, cnt)
+		for i := 0; i < cnt; i++ {
+			n.keys[i] = decode(&d, p.kc)
+		}
+	default:
+		return nil, ErrCorrupt
+	}
+	if d.err {
+		return nil, ErrCorrupt
+	}
+	return n, nil
+}
+
+// 空闲页:  free | next
+func (p *pager[K, V]) writeFree(id, next pageID) error {
+	buf := binary.AppendUvarint([]byte{pageFree}, uint64(next))
+	return p.writePage(id, buf)
+}
+
+func (p *pager[K, V]) readFree(id pageID) (pageID, error) {
+	buf, err := p.readPage(id)
+	if err != nil {
+		return 0, err
+	}
+	d := decoder{buf: buf[1:]}
+	next := pageID(d.uvarint())
+	if buf[0] != pageFree || d.err {
+		return 0, ErrCorrupt
+	}
+	return next, nil
+}
+
+// 顺序解码页中的数据，出错后记录在err中，最后统一检查
+type decoder struct {
+	buf []byte
+	err bool
+}
+
+func (d *decoder) uvarint() uint64 {
+	v, n := binary.Uvarint(d.buf)
+	if n <= 0 {
+		d.err = true
+		return 0
+	}
+	d.buf = d.buf[n:]
+	return v
+}
+
+func decode[T any](d *decoder, c codec.Codec[T]) T {
+	v, n := c.Decode(d.buf)
+	if n <= 0 || d.err {
+		d.err = true
+		var zero T
+		return zero
+	}
+	d.buf = d.buf[n:]
+	return v
+}
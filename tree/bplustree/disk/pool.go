@@ -0,0 +1,86 @@
+// Copyright (c) 2025 Tian ChunXing. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package disk
+
+import "container/list"
+
+// bufferPool 缓存解码后的节点，按LRU淘汰，淘汰和Flush时写回修改过的节点
+// 一次树操作中用到的节点不会被淘汰，操作结束后再淘汰到capacity以内
+type bufferPool[K, V any] struct {
+	pager    *pager[K, V]
+	capacity int
+	lru      *list.List //头部为最近使用的节点
+	frames   map[pageID]*list.Element
+}
+
+func newBufferPool[K, V any](p *pager[K, V], capacity int) *bufferPool[K, V] {
+	return &bufferPool[K, V]{
+		pager:    p,
+		capacity: capacity,
+		lru:      list.New(),
+		frames:   make(map[pageID]*list.Element),
+	}
+}
+
+func (bp *bufferPool[K, V]) get(id pageID) (*node[K, V], error) {
+	if e, ok := bp.frames[id]; ok {
+		bp.lru.MoveToFront(e)
+		return e.Value.(*node[K, V]), nil
+	}
+	n, err := bp.pager.read(id)
+	if err != nil {
+		return nil, err
+	}
+	bp.frames[id] = bp.lru.PushFront(n)
+	return n, nil
+}
+
+// 新分配的节点
+func (bp *bufferPool[K, V]) add(n *node[K, V]) {
+	n.dirty = true
+	bp.frames[n.id] = bp.lru.PushFront(n)
+}
+
+// 释放的节点，不需要写回
+func (bp *bufferPool[K, V]) drop(id pageID) {
+	if e, ok := bp.frames[id]; ok {
+		bp.lru.Remove(e)
+		delete(bp.frames, id)
+	}
+}
+
+func (bp *bufferPool[K, V]) evict() error {
+	for bp.lru.Len() > bp.capacity {
+		e := bp.lru.Back()
+		n := e.Value.(*node[K, V])
+		if n.dirty {
+			if err := bp.pager.write(n); err != nil {
+				return err
+			}
+			n.dirty = false
+		}
+		bp.lru.Remove(e)
+		delete(bp.frames, n.id)
+	}
+	return nil
+}
+
+func (bp *bufferPool[K, V]) flush() error {
+	for e := bp.lru.Front(); e != nil; e = e.Next() {
+		n := e.Value.(*node[K, V])
+		if n.dirty {
+			if err := bp.pager.write(n); err != nil {
+				return err
+			}
+			n.dirty = false
+		}
+	}
+	return nil
+}
+
+func (bp *bufferPool[K, V]) reset() {
+	bp.lru.Init()
+	bp.frames = make(map[pageID]*list.Element)
+}
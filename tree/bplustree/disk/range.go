@@ -0,0 +1,78 @@
+// Copyright (c) 2025 Tian ChunXing. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package disk
+
+import "github.com/mrtcx/plusdata/tree"
+
+// AscendRange 正序遍历区间[lo, hi)内的元素, fn返回false时停止, bound可指定区间开闭
+func (t *diskTree[K, V]) AscendRange(lo, hi K, fn func(key K, value V) bool, bound ...tree.Bound) {
+	b := tree.RangeBound(bound)
+	t.ascend(lo, b.LoInclusive(), func(key K) bool {
+		less := t.cmp(key, hi)
+		return less < 0 || less == 0 && b.HiInclusive()
+	}, fn)
+}
+
+// DescendRange 倒序遍历区间[lo, hi)内的元素, fn返回false时停止, bound可指定区间开闭
+func (t *diskTree[K, V]) DescendRange(lo, hi K, fn func(key K, value V) bool, bound ...tree.Bound) {
+	b := tree.RangeBound(bound)
+	t.descend(hi, b.HiInclusive(), func(key K) bool {
+		less := t.cmp(key, lo)
+		return less > 0 || less == 0 && b.LoInclusive()
+	}, fn)
+}
+
+// AscendGreaterOrEqual 正序遍历key>=lo的元素
+func (t *diskTree[K, V]) AscendGreaterOrEqual(lo K, fn func(key K, value V) bool) {
+	t.ascend(lo, true, nil, fn)
+}
+
+// DescendLessOrEqual 倒序遍历key<=hi的元素
+func (t *diskTree[K, V]) DescendLessOrEqual(hi K, fn func(key K, value V) bool) {
+	t.descend(hi, true, nil, fn)
+}
+
+// 沿叶子链表遍历，每遍历完一个叶子淘汰一次缓存，遍历整棵树也只占用缓存大小的内存
+func (t *diskTree[K, V]) ascend(lo K, include bool, inRange func(key K) bool, fn func(key K, value V) bool) {
+	if t.err != nil {
+		return
+	}
+	defer t.end()
+	n, idx := t.leafIdx(lo, !include)
+	for n != nil {
+		for ; idx < len(n.keys); idx++ {
+			if inRange != nil && !inRange(n.keys[idx]) || !fn(n.keys[idx], n.values[idx]) {
+				return
+			}
+		}
+		if n.next == 0 {
+			return
+		}
+		t.check(t.pool.evict())
+		n, idx = t.get(n.next), 0
+	}
+}
+
+func (t *diskTree[K, V]) descend(hi K, include bool, inRange func(key K) bool, fn func(key K, value V) bool) {
+	if t.err != nil {
+		return
+	}
+	defer t.end()
+	n, idx := t.leafIdx(hi, include)
+	idx--
+	for n != nil {
+		for ; idx >= 0; idx-- {
+			if inRange != nil && !inRange(n.keys[idx]) || !fn(n.keys[idx], n.values[idx]) {
+				return
+			}
+		}
+		if n.prev == 0 {
+			return
+		}
+		t.check(t.pool.evict())
+		n = t.get(n.prev)
+		idx = len(n.keys) - 1
+	}
+}
@@ -0,0 +1,44 @@
+// Copyright (c) 2025 Tian ChunXing. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package disk
+
+import "github.com/mrtcx/plusdata/tree"
+
+// Rank 返回树中小于key的元素个数，即key在顺序遍历中的下标
+func (t *diskTree[K, V]) Rank(key K) int {
+	if t.err != nil || t.meta.root == 0 {
+		return 0
+	}
+	defer t.end()
+	rank := 0
+	for n := t.get(t.meta.root); ; {
+		idx := t.binarySearchIdx(key, n)
+		if n.isLeaf() {
+			return rank + idx
+		}
+		for i := 0; i < idx; i++ {
+			rank += n.counts[i]
+		}
+		n = t.get(n.childs[idx])
+	}
+}
+
+// Select 返回顺序遍历中下标为k的元素(从0开始)，k越界时返回nil
+func (t *diskTree[K, V]) Select(k int) tree.Element[K, V] {
+	if t.err != nil || k < 0 || k >= t.meta.size {
+		return nil
+	}
+	defer t.end()
+	n := t.get(t.meta.root)
+	for !n.isLeaf() {
+		idx := 0
+		for k >= n.counts[idx] {
+			k -= n.counts[idx]
+			idx++
+		}
+		n = t.get(n.childs[idx])
+	}
+	return t.newElement(n, k)
+}
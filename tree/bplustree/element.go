@@ -6,88 +6,88 @@ package bplustree
 
 import "github.com/mrtcx/plusdata/tree"
 
-type element struct {
-	node *Node
+type element[K, V any] struct {
+	node *Node[K, V]
 	idx  int
 }
 
-func (e *element) Key() interface{} {
+func (e *element[K, V]) Key() K {
 	return e.node.keys[e.idx]
 }
 
-func (e *element) Value() interface{} {
+func (e *element[K, V]) Value() V {
 	return e.node.valus[e.idx]
 }
 
-func (e *element) SetValue(value interface{}) {
+func (e *element[K, V]) SetValue(value V) {
 	e.node.valus[e.idx] = value
 }
 
-func (e *element) Next() tree.Element {
+func (e *element[K, V]) Next() tree.Element[K, V] {
 	if e.idx == len(e.node.keys)-1 {
 		if e.node.next == nil {
 			return nil
 		}
-		return &element{node: e.node.next, idx: 0}
+		return &element[K, V]{node: e.node.next, idx: 0}
 	}
-	return &element{
+	return &element[K, V]{
 		node: e.node,
 		idx:  e.idx + 1,
 	}
 }
 
-func (e *element) Prev() tree.Element {
+func (e *element[K, V]) Prev() tree.Element[K, V] {
 	if e.idx == 0 {
 		if e.node.prev == nil {
 			return nil
 		}
-		return &element{
+		return &element[K, V]{
 			node: e.node.prev,
 			idx:  len(e.node.prev.keys) - 1,
 		}
 	}
-	return &element{
+	return &element[K, V]{
 		node: e.node,
 		idx:  e.idx - 1,
 	}
 }
 
-func (bp *bplusTree) Find(key interface{}) tree.Element {
+func (bp *bplusTree[K, V]) Find(key K) tree.Element[K, V] {
 	node, idx := bp.getNodeIdx(key)
 	if node == nil {
 		return nil
 	}
-	return &element{node: node, idx: idx}
+	return &element[K, V]{node: node, idx: idx}
 }
 
-func (bp *bplusTree) Left() tree.Element {
+func (bp *bplusTree[K, V]) Left() tree.Element[K, V] {
 	mleft := bp.mostLeft()
 	if mleft == nil {
 		return nil
 	}
-	return &element{node: mleft, idx: 0}
+	return &element[K, V]{node: mleft, idx: 0}
 }
 
-func (bp *bplusTree) Right() tree.Element {
+func (bp *bplusTree[K, V]) Right() tree.Element[K, V] {
 	mright := bp.mostRight()
 	if mright == nil {
 		return nil
 	}
-	return &element{node: mright, idx: len(mright.keys) - 1}
+	return &element[K, V]{node: mright, idx: len(mright.keys) - 1}
 }
 
-func (bp *bplusTree) Prev(key interface{}) tree.Element {
+func (bp *bplusTree[K, V]) Prev(key K) tree.Element[K, V] {
 	node, idx := bp.prevNodeIdx(key)
 	if node == nil {
 		return nil
 	}
-	return &element{node: node, idx: idx}
+	return &element[K, V]{node: node, idx: idx}
 }
 
-func (bp *bplusTree) Next(key interface{}) tree.Element {
+func (bp *bplusTree[K, V]) Next(key K) tree.Element[K, V] {
 	node, idx := bp.nextNodeIdx(key)
 	if node == nil {
 		return nil
 	}
-	return &element{node: node, idx: idx}
+	return &element[K, V]{node: node, idx: idx}
 }
@@ -0,0 +1,58 @@
+// Copyright (c) 2025 Tian ChunXing. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package bplustree
+
+import "github.com/mrtcx/plusdata/tree"
+
+var _ tree.MultiTree[int, int] = (*bplusTree[int, int])(nil)
+
+// InsertDup 插入到所有key相同的元素之后，相同的key可能跨越多个叶子
+func (bp *bplusTree[K, V]) InsertDup(key K, value V) {
+	bp.put(key, value, true)
+}
+
+func (bp *bplusTree[K, V]) RemoveOne(key K, match func(value V) bool) bool {
+	rank := bp.Rank(key)
+	node, idx := bp.leafIdx(key, false)
+	for node != nil {
+		for ; idx < len(node.keys); idx++ {
+			if bp.cmp(node.keys[idx], key) != 0 {
+				return false
+			}
+			if match(node.valus[idx]) {
+				bp.removeAt(rank)
+				return true
+			}
+			rank++
+		}
+		node, idx = node.next, 0
+	}
+	return false
+}
+
+func (bp *bplusTree[K, V]) RemoveAll(key K) int {
+	rank, cnt := bp.Rank(key), bp.Count(key)
+	for i := 0; i < cnt; i++ {
+		bp.removeAt(rank)
+	}
+	return cnt
+}
+
+func (bp *bplusTree[K, V]) Count(key K) int {
+	return bp.rank(key, true) - bp.rank(key, false)
+}
+
+func (bp *bplusTree[K, V]) EqualRange(key K) (first, last tree.Element[K, V]) {
+	node, idx := bp.getNodeIdx(key)
+	if node == nil {
+		return nil, nil
+	}
+	first = &element[K, V]{node: node, idx: idx}
+	node, idx = bp.leafIdx(key, true)
+	if idx == 0 {
+		return first, &element[K, V]{node: node.prev, idx: len(node.prev.keys) - 1}
+	}
+	return first, &element[K, V]{node: node, idx: idx - 1}
+}
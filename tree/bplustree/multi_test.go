@@ -0,0 +1,116 @@
+// Copyright (c) 2025 Tian ChunXing. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package bplustree
+
+import (
+	"fmt"
+	"math/rand"
+	"sort"
+	"testing"
+
+	"github.com/mrtcx/plusdata/internal/assert"
+	"github.com/mrtcx/plusdata/tree"
+)
+
+func TestMulti(t *testing.T) {
+	nums := []int{1, 2, 8, 64, 1024}
+	orders := []int{3, 4, 5}
+	for _, num := range nums {
+		tnum := num
+		for _, order := range orders {
+			torder := order
+			t.Run(fmt.Sprintf("[num:%d order:%d]", tnum, torder), func(t *testing.T) {
+				testMulti(t, New[int, int](intcmp, torder), tnum)
+			})
+		}
+	}
+}
+
+// 与按key稳定排序的切片对比，value各不相同
+func testMulti(t *testing.T, tr tree.MultiTree[int, int], num int) {
+	var pairs [][2]int
+	lower := func(key int) int {
+		return sort.Search(len(pairs), func(i int) bool { return pairs[i][0] >= key })
+	}
+	upper := func(key int) int {
+		return sort.Search(len(pairs), func(i int) bool { return pairs[i][0] > key })
+	}
+	maxKey := 1 + num/4
+	for i := 0; i < 8*num; i++ {
+		key := rand.Intn(maxKey)
+		switch op := rand.Intn(8); {
+		case op < 5:
+			tr.InsertDup(key, i)
+			idx := upper(key)
+			pairs = append(pairs[:idx], append([][2]int{{key, i}}, pairs[idx:]...)...)
+		case op < 7:
+			lo, hi := lower(key), upper(key)
+			if lo == hi {
+				assert.Equal(t, tr.RemoveOne(key, func(int) bool { return true }), false)
+				break
+			}
+			idx := lo + rand.Intn(hi-lo)
+			value := pairs[idx][1]
+			assert.Equal(t, tr.RemoveOne(key, func(v int) bool { return v == value }), true)
+			pairs = append(pairs[:idx], pairs[idx+1:]...)
+		default:
+			lo, hi := lower(key), upper(key)
+			assert.Equal(t, tr.RemoveAll(key), hi-lo)
+			pairs = append(pairs[:lo], pairs[hi:]...)
+		}
+		if i%(1+num/8) != 0 {
+			continue
+		}
+		assert.Equal(t, tr.Size(), len(pairs))
+		var got [][2]int
+		for e := tr.Left(); e != nil; e = e.Next() {
+			got = append(got, [2]int{e.Key(), e.Value()})
+		}
+		assert.Equal(t, fmt.Sprint(got), fmt.Sprint(pairs))
+		got = got[:0]
+		for e := tr.Right(); e != nil; e = e.Prev() {
+			got = append([][2]int{{e.Key(), e.Value()}}, got...)
+		}
+		assert.Equal(t, fmt.Sprint(got), fmt.Sprint(pairs))
+		for key := -1; key <= maxKey; key++ {
+			lo, hi := lower(key), upper(key)
+			assert.Equal(t, tr.Count(key), hi-lo)
+			assert.Equal(t, tr.Rank(key), lo)
+			first, last := tr.EqualRange(key)
+			if lo == hi {
+				assert.Equal(t, first, nil)
+				assert.Equal(t, last, nil)
+				_, exist := tr.Get(key)
+				assert.Equal(t, exist, false)
+				continue
+			}
+			assert.Equal(t, first.Value(), pairs[lo][1])
+			assert.Equal(t, last.Value(), pairs[hi-1][1])
+			_, exist := tr.Get(key)
+			assert.Equal(t, exist, true)
+			if next := tr.Next(key); hi < len(pairs) {
+				assert.Equal(t, next.Value(), pairs[hi][1])
+			} else {
+				assert.Equal(t, next, nil)
+			}
+			if prev := tr.Prev(key); lo > 0 {
+				assert.Equal(t, prev.Value(), pairs[lo-1][1])
+			} else {
+				assert.Equal(t, prev, nil)
+			}
+			var values []int
+			tr.AscendRange(key, key, func(k, v int) bool {
+				values = append(values, v)
+				return true
+			}, tree.Closed)
+			assert.Equal(t, len(values), hi-lo)
+			tr.DescendRange(key, key, func(k, v int) bool {
+				values = append(values, v)
+				return true
+			}, tree.Closed)
+			assert.Equal(t, len(values), 2*(hi-lo))
+		}
+	}
+}
@@ -0,0 +1,62 @@
+// Copyright (c) 2025 Tian ChunXing. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package bplustree
+
+import "github.com/mrtcx/plusdata/tree"
+
+var _ tree.Navigable[int, int] = (*bplusTree[int, int])(nil)
+
+// Floor 最后一个<=key的元素，存在重复key时为相等元素中的最后一个
+func (bp *bplusTree[K, V]) Floor(key K) tree.Element[K, V] {
+	return bp.Select(bp.rank(key, true) - 1)
+}
+
+// Ceiling 第一个>=key的元素，存在重复key时为相等元素中的第一个
+func (bp *bplusTree[K, V]) Ceiling(key K) tree.Element[K, V] {
+	return bp.Select(bp.rank(key, false))
+}
+
+func (bp *bplusTree[K, V]) Lower(key K) tree.Element[K, V] {
+	return bp.Prev(key)
+}
+
+func (bp *bplusTree[K, V]) Higher(key K) tree.Element[K, V] {
+	return bp.Next(key)
+}
+
+func (bp *bplusTree[K, V]) PollFirst() (K, V, bool) {
+	return bp.poll(0)
+}
+
+func (bp *bplusTree[K, V]) PollLast() (K, V, bool) {
+	return bp.poll(bp.size - 1)
+}
+
+func (bp *bplusTree[K, V]) poll(k int) (K, V, bool) {
+	e := bp.Select(k)
+	if e == nil {
+		var zeroK K
+		var zeroV V
+		return zeroK, zeroV, false
+	}
+	key, value := e.Key(), e.Value()
+	bp.removeAt(k)
+	return key, value, true
+}
+
+// HeadMap key<to的元素的视图，视图中的修改直接作用于树
+func (bp *bplusTree[K, V]) HeadMap(to K) tree.Navigable[K, V] {
+	return tree.NewHeadMap[K, V](bp, bp.cmp, to)
+}
+
+// TailMap key>=from的元素的视图
+func (bp *bplusTree[K, V]) TailMap(from K) tree.Navigable[K, V] {
+	return tree.NewTailMap[K, V](bp, bp.cmp, from)
+}
+
+// SubMap 区间[from, to)内的元素的视图，bound可指定区间开闭
+func (bp *bplusTree[K, V]) SubMap(from, to K, bound ...tree.Bound) tree.Navigable[K, V] {
+	return tree.NewSubMap[K, V](bp, bp.cmp, from, to, bound...)
+}
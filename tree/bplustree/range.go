@@ -0,0 +1,65 @@
+// Copyright (c) 2025 Tian ChunXing. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package bplustree
+
+import "github.com/mrtcx/plusdata/tree"
+
+// AscendRange 正序遍历区间[lo, hi)内的元素, fn返回false时停止, bound可指定区间开闭
+func (bp *bplusTree[K, V]) AscendRange(lo, hi K, fn func(key K, value V) bool, bound ...tree.Bound) {
+	b := tree.RangeBound(bound)
+	bp.ascend(lo, b.LoInclusive(), func(key K) bool {
+		less := bp.cmp(key, hi)
+		return less < 0 || less == 0 && b.HiInclusive()
+	}, fn)
+}
+
+// DescendRange 倒序遍历区间[lo, hi)内的元素, fn返回false时停止, bound可指定区间开闭
+func (bp *bplusTree[K, V]) DescendRange(lo, hi K, fn func(key K, value V) bool, bound ...tree.Bound) {
+	b := tree.RangeBound(bound)
+	bp.descend(hi, b.HiInclusive(), func(key K) bool {
+		less := bp.cmp(key, lo)
+		return less > 0 || less == 0 && b.LoInclusive()
+	}, fn)
+}
+
+// AscendGreaterOrEqual 正序遍历key>=lo的元素
+func (bp *bplusTree[K, V]) AscendGreaterOrEqual(lo K, fn func(key K, value V) bool) {
+	bp.ascend(lo, true, nil, fn)
+}
+
+// DescendLessOrEqual 倒序遍历key<=hi的元素
+func (bp *bplusTree[K, V]) DescendLessOrEqual(hi K, fn func(key K, value V) bool) {
+	bp.descend(hi, true, nil, fn)
+}
+
+// 定位到起点所在的叶子后沿叶子链表遍历
+func (bp *bplusTree[K, V]) ascend(lo K, include bool, inRange func(key K) bool, fn func(key K, value V) bool) {
+	node, idx := bp.leafIdx(lo, !include)
+	for node != nil {
+		for ; idx < len(node.keys); idx++ {
+			if inRange != nil && !inRange(node.keys[idx]) || !fn(node.keys[idx], node.valus[idx]) {
+				return
+			}
+		}
+		node, idx = node.next, 0
+	}
+}
+
+// 定位到第一个>hi(不包含hi时>=hi)的位置，从它的前一个元素开始倒序遍历
+func (bp *bplusTree[K, V]) descend(hi K, include bool, inRange func(key K) bool, fn func(key K, value V) bool) {
+	node, idx := bp.leafIdx(hi, include)
+	idx--
+	for node != nil {
+		for ; idx >= 0; idx-- {
+			if inRange != nil && !inRange(node.keys[idx]) || !fn(node.keys[idx], node.valus[idx]) {
+				return
+			}
+		}
+		node = node.prev
+		if node != nil {
+			idx = len(node.keys) - 1
+		}
+	}
+}
@@ -0,0 +1,45 @@
+// Copyright (c) 2025 Tian ChunXing. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package bplustree
+
+import "github.com/mrtcx/plusdata/tree"
+
+// Rank 返回树中小于key的元素个数，即key在顺序遍历中的下标
+func (bp *bplusTree[K, V]) Rank(key K) int {
+	return bp.rank(key, false)
+}
+
+// 小于key(upper为true时小于等于key)的元素个数
+func (bp *bplusTree[K, V]) rank(key K, upper bool) int {
+	rank := 0
+	for root := bp.root; root != nil; {
+		idx := bp.searchIdx(key, root, upper)
+		if root.isLeaf() {
+			return rank + idx
+		}
+		for i := 0; i < idx; i++ {
+			rank += root.counts[i]
+		}
+		root = root.childs[idx]
+	}
+	return rank
+}
+
+// Select 返回顺序遍历中下标为k的元素(从0开始)，k越界时返回nil
+func (bp *bplusTree[K, V]) Select(k int) tree.Element[K, V] {
+	if k < 0 || k >= bp.size {
+		return nil
+	}
+	root := bp.root
+	for !root.isLeaf() {
+		idx := 0
+		for k >= root.counts[idx] {
+			k -= root.counts[idx]
+			idx++
+		}
+		root = root.childs[idx]
+	}
+	return &element[K, V]{node: root, idx: k}
+}
@@ -0,0 +1,53 @@
+// Copyright (c) 2025 Tian ChunXing. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package btree
+
+import (
+	"testing"
+
+	"github.com/mrtcx/plusdata/codec"
+	"github.com/mrtcx/plusdata/internal/assert"
+	"github.com/mrtcx/plusdata/internal/treetest"
+	"github.com/mrtcx/plusdata/tree"
+)
+
+func TestEncodeCodec(t *testing.T) {
+	strcmp := tree.OrderedComparator[string]
+	tr := New[string, []int](strcmp, 4)
+	tr.Insert("a", []int{1})
+	_, err := tr.MarshalBinary()
+	assert.Equal(t, err, codec.ErrNoCodec)
+	tr.SetCodec(nil, intsCodec{})
+	tr.Insert("b", []int{2, 3})
+	data, err := tr.MarshalBinary()
+	assert.Equal(t, err, nil)
+	tr2 := New[string, []int](strcmp, 4)
+	tr2.SetCodec(codec.StringCodec{}, intsCodec{})
+	assert.Equal(t, tr2.UnmarshalBinary(data), nil)
+	assert.Equal(t, treetest.Dump[string, []int](tr2), "[a:[1] b:[2 3]]")
+}
+
+// []int编码为个数加每个元素
+type intsCodec struct{}
+
+func (intsCodec) Append(buf []byte, v []int) []byte {
+	buf = codec.IntCodec{}.Append(buf, len(v))
+	for _, i := range v {
+		buf = codec.IntCodec{}.Append(buf, i)
+	}
+	return buf
+}
+
+func (intsCodec) Decode(buf []byte) ([]int, int) {
+	l, n := codec.IntCodec{}.Decode(buf)
+	if n <= 0 || l < 0 || len(buf) < 8+8*l {
+		return nil, 0
+	}
+	v := make([]int, l)
+	for i := range v {
+		v[i], _ = codec.IntCodec{}.Decode(buf[8+8*i:])
+	}
+	return v, 8 + 8*l
+}
@@ -5,64 +5,83 @@
 package btree
 
 import (
+	"github.com/mrtcx/plusdata/codec"
 	"github.com/mrtcx/plusdata/tree"
 )
 
-type bTree struct {
-	root  *Node
-	cmp   tree.Comparator
+type bTree[K, V any] struct {
+	root  *Node[K, V]
+	cmp   tree.Comparator[K]
 	size  int
 	order int
+	kc    codec.Codec[K]
+	vc    codec.Codec[V]
 }
 
-type Node struct {
-	keys   []interface{}
-	values []interface{}
-	childs []*Node
+type Node[K, V any] struct {
+	keys   []K
+	values []V
+	childs []*Node[K, V]
+	counts []int //counts[i]为子树childs[i]中key的数量
 }
 
-func (node *Node) isLeaf() bool {
+func (node *Node[K, V]) isLeaf() bool {
 	return len(node.childs) == 0
 }
 
-func New(cmp tree.Comparator, order int) *bTree {
-	return &bTree{
+// 子树中key的数量
+func (node *Node[K, V]) count() int {
+	cnt := len(node.keys)
+	for _, c := range node.counts {
+		cnt += c
+	}
+	return cnt
+}
+
+func New[K, V any](cmp tree.Comparator[K], order int) *bTree[K, V] {
+	return &bTree[K, V]{
 		cmp:   cmp,
 		order: order,
 	}
 }
 
-func (bp *bTree) Clean() {
+// NewAny interface{}版本的构造函数，与泛型之前的New用法相同，返回的树实现tree.AnyTree
+func NewAny(cmp tree.AnyComparator, order int) *bTree[any, any] {
+	return New[any, any](cmp, order)
+}
+
+func (bp *bTree[K, V]) Clean() {
 	bp.root, bp.size = nil, 0
 }
 
-func (bp *bTree) Size() int {
+func (bp *bTree[K, V]) Size() int {
 	return bp.size
 }
 
-func (bp *bTree) Empty() bool {
+func (bp *bTree[K, V]) Empty() bool {
 	return bp.size == 0
 }
 
-func (bp *bTree) Insert(key, val interface{}) {
+func (bp *bTree[K, V]) Insert(key K, val V) {
 	root := bp.insert(bp.root, key, val)
 	if len(root.keys) > int(bp.maxKeys()) {
 		skey, sval, sleft, sright := split(root)
-		root = &Node{
-			keys:   []interface{}{skey},
-			values: []interface{}{sval},
-			childs: []*Node{sleft, sright},
+		root = &Node[K, V]{
+			keys:   []K{skey},
+			values: []V{sval},
+			childs: []*Node[K, V]{sleft, sright},
+			counts: []int{sleft.count(), sright.count()},
 		}
 	}
 	bp.root = root
 }
 
-func (bp *bTree) insert(root *Node, key, val interface{}) *Node {
+func (bp *bTree[K, V]) insert(root *Node[K, V], key K, val V) *Node[K, V] {
 	if root == nil {
 		bp.size++
-		return &Node{
-			keys:   []interface{}{key},
-			values: []interface{}{val},
+		return &Node[K, V]{
+			keys:   []K{key},
+			values: []V{val},
 		}
 	}
 	idx := bp.binarySearchIdx(key, root)
@@ -82,16 +101,19 @@ func (bp *bTree) insert(root *Node, key, val interface{}) *Node {
 			skey, sval, sleft, sright := split(retNode)
 			root.keys = increaseSpace(root.keys, idx)
 			root.values = increaseSpace(root.values, idx)
-			root.childs = increaseSpace2(root.childs, idx)
+			root.childs = increaseSpace(root.childs, idx)
+			root.counts = increaseSpace(root.counts, idx)
 			root.keys[idx] = skey
 			root.values[idx] = sval
 			root.childs[idx], root.childs[idx+1] = sleft, sright
+			root.counts[idx+1] = sright.count()
 		}
+		root.counts[idx] = root.childs[idx].count()
 		return root
 	}
 }
 
-func (bp *bTree) Remove(key interface{}) {
+func (bp *bTree[K, V]) Remove(key K) {
 	if bp.root == nil {
 		return
 	}
@@ -105,7 +127,7 @@ func (bp *bTree) Remove(key interface{}) {
 	}
 }
 
-func (bp *bTree) remove(root *Node, key interface{}) *Node {
+func (bp *bTree[K, V]) remove(root *Node[K, V], key K) *Node[K, V] {
 	if root == nil {
 		return nil
 	}
@@ -125,6 +147,7 @@ func (bp *bTree) remove(root *Node, key interface{}) *Node {
 	}
 	if !root.isLeaf() {
 		retNode := bp.remove(root.childs[idx], key)
+		root.counts[idx] = retNode.count()
 		if len(retNode.keys) < bp.minKeys() {
 			minkeys, maxkeys := bp.minKeys(), bp.maxKeys()
 			if !borrowFromRight(root, idx, minkeys) &&
@@ -138,15 +161,16 @@ func (bp *bTree) remove(root *Node, key interface{}) *Node {
 	return root
 }
 
-func (bp *bTree) Get(key interface{}) (interface{}, bool) {
+func (bp *bTree[K, V]) Get(key K) (V, bool) {
 	node, idx := bp.findNodeIdx(key)
 	if node == nil {
-		return nil, false
+		var zero V
+		return zero, false
 	}
 	return node.values[idx], true
 }
 
-func (bp *bTree) findNodeIdx(key interface{}) (*Node, int) {
+func (bp *bTree[K, V]) findNodeIdx(key K) (*Node[K, V], int) {
 	root := bp.root
 	if root == nil {
 		return nil, 0
@@ -163,12 +187,12 @@ func (bp *bTree) findNodeIdx(key interface{}) (*Node, int) {
 	}
 }
 
-func (bp *bTree) findPrevNodeIdx(key interface{}) (*Node, int) {
+func (bp *bTree[K, V]) findPrevNodeIdx(key K) (*Node[K, V], int) {
 	root := bp.root
 	if root == nil {
 		return nil, 0
 	}
-	var lparent *Node
+	var lparent *Node[K, V]
 	var lparentIdx int
 	for {
 		idx := bp.binarySearchIdx(key, root)
@@ -192,12 +216,12 @@ func (bp *bTree) findPrevNodeIdx(key interface{}) (*Node, int) {
 	}
 }
 
-func (bp *bTree) findNextNodeIdx(key interface{}) (*Node, int) {
+func (bp *bTree[K, V]) findNextNodeIdx(key K) (*Node[K, V], int) {
 	root := bp.root
 	if root == nil {
 		return nil, 0
 	}
-	var rparent *Node
+	var rparent *Node[K, V]
 	var rparentIdx int
 	for {
 		idx := bp.binarySearchIdx(key, root)
@@ -223,7 +247,7 @@ func (bp *bTree) findNextNodeIdx(key interface{}) (*Node, int) {
 	}
 }
 
-func (bp *bTree) binarySearchIdx(key interface{}, root *Node) int {
+func (bp *bTree[K, V]) binarySearchIdx(key K, root *Node[K, V]) int {
 	if len(root.keys) <= 4 {
 		idx := 0
 		for ; idx < len(root.keys) && bp.cmp(key, root.keys[idx]) > 0; idx++ {
@@ -246,24 +270,26 @@ func (bp *bTree) binarySearchIdx(key interface{}, root *Node) int {
 	}
 }
 
-func split(node *Node) (key interface{}, val interface{}, left *Node, right *Node) {
+func split[K, V any](node *Node[K, V]) (key K, val V, left *Node[K, V], right *Node[K, V]) {
 	pivot := len(node.keys) / 2
 	key = node.keys[pivot]
 	val = node.values[pivot]
-	right = &Node{}
+	right = &Node[K, V]{}
 	right.keys = append(right.keys, node.keys[pivot+1:]...)
 	right.values = append(right.values, node.values[pivot+1:]...)
 	node.keys = node.keys[:pivot]
 	node.values = node.values[:pivot]
 	if !node.isLeaf() {
 		right.childs = append(right.childs, node.childs[pivot+1:]...)
+		right.counts = append(right.counts, node.counts[pivot+1:]...)
 		node.childs = node.childs[:pivot+1]
+		node.counts = node.counts[:pivot+1]
 	}
 	left = node
 	return key, val, left, right
 }
 
-func mergeRight(parnet *Node, idx int, maxKeys int) bool {
+func mergeRight[K, V any](parnet *Node[K, V], idx int, maxKeys int) bool {
 	if idx == len(parnet.keys) {
 		return false
 	}
@@ -278,15 +304,18 @@ func mergeRight(parnet *Node, idx int, maxKeys int) bool {
 	idxChild.values = append(idxChild.values, idxRightChild.values...)
 	if !idxChild.isLeaf() {
 		idxChild.childs = append(idxChild.childs, idxRightChild.childs...)
+		idxChild.counts = append(idxChild.counts, idxRightChild.counts...)
 	}
 	parnet.childs[idx+1] = idxChild
+	parnet.counts[idx+1] = idxChild.count()
 	parnet.keys = decreaseSpace(parnet.keys, idx)
 	parnet.values = decreaseSpace(parnet.values, idx)
-	parnet.childs = decreaseSpace2(parnet.childs, idx)
+	parnet.childs = decreaseSpace(parnet.childs, idx)
+	parnet.counts = decreaseSpace(parnet.counts, idx)
 	return true
 }
 
-func mergeLeft(parnet *Node, idx int, maxKeys int) bool {
+func mergeLeft[K, V any](parnet *Node[K, V], idx int, maxKeys int) bool {
 	if idx == 0 {
 		return false
 	}
@@ -301,15 +330,18 @@ func mergeLeft(parnet *Node, idx int, maxKeys int) bool {
 	idxLeftChild.values = append(idxLeftChild.values, idxChild.values...)
 	if !idxChild.isLeaf() {
 		idxLeftChild.childs = append(idxLeftChild.childs, idxChild.childs...)
+		idxLeftChild.counts = append(idxLeftChild.counts, idxChild.counts...)
 	}
 	parnet.childs[idx] = idxLeftChild
+	parnet.counts[idx] = idxLeftChild.count()
 	parnet.keys = decreaseSpace(parnet.keys, idx-1)
 	parnet.values = decreaseSpace(parnet.values, idx-1)
-	parnet.childs = decreaseSpace2(parnet.childs, idx-1)
+	parnet.childs = decreaseSpace(parnet.childs, idx-1)
+	parnet.counts = decreaseSpace(parnet.counts, idx-1)
 	return true
 }
 
-func borrowFromRight(parent *Node, idx int, minKeys int) bool {
+func borrowFromRight[K, V any](parent *Node[K, V], idx int, minKeys int) bool {
 	if idx == len(parent.keys) {
 		return false
 	}
@@ -327,12 +359,15 @@ func borrowFromRight(parent *Node, idx int, minKeys int) bool {
 	iRightChild.values = decreaseSpace(iRightChild.values, 0)
 	if !iChild.isLeaf() {
 		iChild.childs = append(iChild.childs, iRightChild.childs[0])
-		iRightChild.childs = decreaseSpace2(iRightChild.childs, 0)
+		iChild.counts = append(iChild.counts, iRightChild.counts[0])
+		iRightChild.childs = decreaseSpace(iRightChild.childs, 0)
+		iRightChild.counts = decreaseSpace(iRightChild.counts, 0)
 	}
+	parent.counts[idx], parent.counts[idx+1] = iChild.count(), iRightChild.count()
 	return true
 }
 
-func borrowFromLeft(parent *Node, idx int, minKeys int) bool {
+func borrowFromLeft[K, V any](parent *Node[K, V], idx int, minKeys int) bool {
 	if idx == 0 {
 		return false
 	}
@@ -351,58 +386,49 @@ func borrowFromLeft(parent *Node, idx int, minKeys int) bool {
 	iLeftChild.keys = decreaseSpace(iLeftChild.keys, lli)
 	iLeftChild.values = decreaseSpace(iLeftChild.values, lli)
 	if !iChild.isLeaf() {
-		iChild.childs = increaseSpace2(iChild.childs, 0)
+		iChild.childs = increaseSpace(iChild.childs, 0)
+		iChild.counts = increaseSpace(iChild.counts, 0)
 		iChild.childs[0] = iLeftChild.childs[lli+1]
-		iLeftChild.childs = decreaseSpace2(iLeftChild.childs, lli+1)
+		iChild.counts[0] = iLeftChild.counts[lli+1]
+		iLeftChild.childs = decreaseSpace(iLeftChild.childs, lli+1)
+		iLeftChild.counts = decreaseSpace(iLeftChild.counts, lli+1)
 	}
+	parent.counts[idx-1], parent.counts[idx] = iLeftChild.count(), iChild.count()
 	return true
 }
 
-func (bp *bTree) maxKeys() int {
+func (bp *bTree[K, V]) maxKeys() int {
 	return bp.order
 }
 
-func (bp *bTree) minKeys() int {
+func (bp *bTree[K, V]) minKeys() int {
 	return (bp.order+1)/2 - 1
 }
 
-func mostRight(root *Node) *Node {
+func mostRight[K, V any](root *Node[K, V]) *Node[K, V] {
 	for !root.isLeaf() {
 		root = root.childs[len(root.childs)-1]
 	}
 	return root
 }
 
-func mostLeft(root *Node) *Node {
+func mostLeft[K, V any](root *Node[K, V]) *Node[K, V] {
 	for !root.isLeaf() {
 		root = root.childs[0]
 	}
 	return root
 }
 
-func increaseSpace(arr []interface{}, idx int) []interface{} {
-	arr = append(arr, nil)
-	if idx != len(arr)-1 {
-		copy(arr[idx+1:], arr[idx:])
-	}
-	return arr
-}
-
-func increaseSpace2(arr []*Node, idx int) []*Node {
-	arr = append(arr, nil)
+func increaseSpace[T any](arr []T, idx int) []T {
+	var zero T
+	arr = append(arr, zero)
 	if idx != len(arr)-1 {
 		copy(arr[idx+1:], arr[idx:])
 	}
 	return arr
 }
 
-func decreaseSpace(arr []interface{}, idx int) []interface{} {
-	copy(arr[idx:], arr[idx+1:])
-	arr = arr[:len(arr)-1]
-	return arr
-}
-
-func decreaseSpace2(arr []*Node, idx int) []*Node {
+func decreaseSpace[T any](arr []T, idx int) []T {
 	copy(arr[idx:], arr[idx+1:])
 	arr = arr[:len(arr)-1]
 	return arr
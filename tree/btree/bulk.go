@@ -0,0 +1,83 @@
+// Copyright (c) 2025 Tian ChunXing. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package btree
+
+import "github.com/mrtcx/plusdata/tree"
+
+// BulkLoad 由严格递增的keys、values自底向上构建b树，O(N)
+// 叶子节点按填充率fillFactor(0, 1]从左到右生成，相邻叶子之间留出一个key作为父节点的分隔key，再逐层向上生成;
+// fillFactor为1时节点全部填满，适合只读的场景，之后有插入时建议留出空间
+func BulkLoad[K, V any](cmp tree.Comparator[K], order int, keys []K, values []V, fillFactor float64) (*bTree[K, V], error) {
+	if len(keys) != len(values) {
+		return nil, tree.ErrLengthMismatch
+	}
+	if !(fillFactor > 0 && fillFactor <= 1) {
+		return nil, tree.ErrFillFactor
+	}
+	for i := 1; i < len(keys); i++ {
+		if cmp(keys[i-1], keys[i]) >= 0 {
+			return nil, tree.ErrNotSorted
+		}
+	}
+	bp := New[K, V](cmp, order)
+	if len(keys) == 0 {
+		return bp, nil
+	}
+	minKeys, maxKeys := max(bp.minKeys(), 1), bp.maxKeys()
+	per := max(min(int(fillFactor*float64(maxKeys)), maxKeys), minKeys)
+
+	// 每组为一个节点的key加上其后的一个分隔key，末尾补一个虚拟的分隔key，所以按len(keys)+1切分
+	var level []*Node[K, V]
+	var seps []int //每个节点之后的分隔key的下标
+	start := 0
+	for _, size := range chunks(len(keys)+1, per+1, minKeys+1, maxKeys+1) {
+		level = append(level, &Node[K, V]{
+			keys:   append([]K(nil), keys[start:start+size-1]...),
+			values: append([]V(nil), values[start:start+size-1]...),
+		})
+		seps = append(seps, start+size-1)
+		start += size
+	}
+	for len(level) > 1 {
+		var parents []*Node[K, V]
+		var pseps []int
+		start = 0
+		for _, size := range chunks(len(level), per+1, minKeys+1, maxKeys+1) {
+			node := &Node[K, V]{
+				childs: append([]*Node[K, V](nil), level[start:start+size]...),
+				counts: make([]int, size),
+			}
+			for i, child := range node.childs {
+				node.counts[i] = child.count()
+			}
+			for _, sep := range seps[start : start+size-1] {
+				node.keys = append(node.keys, keys[sep])
+				node.values = append(node.values, values[sep])
+			}
+			parents, pseps = append(parents, node), append(pseps, seps[start+size-1])
+			start += size
+		}
+		level, seps = parents, pseps
+	}
+	bp.root, bp.size = level[0], len(keys)
+	return bp, nil
+}
+
+// 把n个元素按每组per个切分，最后一组不足lo个时，与前一组合并(不超过hi)或者平分
+func chunks(n, per, lo, hi int) []int {
+	var sizes []int
+	for ; n > 0; n -= per {
+		sizes = append(sizes, min(n, per))
+	}
+	if k := len(sizes); k > 1 && sizes[k-1] < lo {
+		total := sizes[k-2] + sizes[k-1]
+		if total <= hi {
+			sizes = append(sizes[:k-2], total)
+		} else {
+			sizes[k-2], sizes[k-1] = total-total/2, total/2
+		}
+	}
+	return sizes
+}
@@ -0,0 +1,66 @@
+// Copyright (c) 2025 Tian ChunXing. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package btree
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/mrtcx/plusdata/internal/assert"
+	"github.com/mrtcx/plusdata/tree"
+)
+
+func TestBulkLoad(t *testing.T) {
+	nums := []int{0, 1, 2, 3, 4, 5, 8, 9, 17, 100, 1024 + 1}
+	orders := []int{3, 4, 5, 8}
+	fills := []float64{0.1, 0.5, 0.7, 1}
+	for _, num := range nums {
+		tnum := num
+		for _, order := range orders {
+			torder := order
+			for _, fill := range fills {
+				tfill := fill
+				t.Run(fmt.Sprintf("[num:%d order:%d fill:%v]", tnum, torder, tfill), func(t *testing.T) {
+					keys, values := make([]int, tnum), make([]int, tnum)
+					for i := range keys {
+						keys[i], values[i] = 2*i+1, 2*i+1
+					}
+					bp, err := BulkLoad(intcmp, torder, keys, values, tfill)
+					assert.Equal(t, err, nil)
+					assert.Equal(t, bp.Size(), tnum)
+					assert.Equal(t, checkBalance(t, bp, bp.root), true)
+					assert.Equal(t, checkOrder(t, bp, bp.root), true)
+					for i, key := range keys {
+						assert.Equal(t, bp.Rank(key), i)
+						assert.Equal(t, bp.Select(i).Key(), key)
+					}
+					// 构建完成后可以继续插入和删除
+					for i := 0; i <= 2*tnum; i += 2 {
+						bp.Insert(i, i)
+					}
+					assert.Equal(t, checkBalance(t, bp, bp.root), true)
+					for i := 0; i <= 2*tnum; i += 3 {
+						bp.Remove(i)
+					}
+					assert.Equal(t, checkBalance(t, bp, bp.root), true)
+					assert.Equal(t, checkOrder(t, bp, bp.root), true)
+				})
+			}
+		}
+	}
+}
+
+func TestBulkLoadError(t *testing.T) {
+	_, err := BulkLoad(intcmp, 4, []int{1, 3, 2}, []int{1, 3, 2}, 1)
+	assert.Equal(t, err, tree.ErrNotSorted)
+	_, err = BulkLoad(intcmp, 4, []int{1, 2, 2}, []int{1, 2, 2}, 1)
+	assert.Equal(t, err, tree.ErrNotSorted)
+	_, err = BulkLoad(intcmp, 4, []int{1, 2}, []int{1}, 1)
+	assert.Equal(t, err, tree.ErrLengthMismatch)
+	_, err = BulkLoad(intcmp, 4, []int{1, 2}, []int{1, 2}, 0)
+	assert.Equal(t, err, tree.ErrFillFactor)
+	_, err = BulkLoad(intcmp, 4, []int{1, 2}, []int{1, 2}, 1.5)
+	assert.Equal(t, err, tree.ErrFillFactor)
+}
@@ -0,0 +1,22 @@
+// Copyright (c) 2025 Tian ChunXing. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package btree
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/mrtcx/plusdata/internal/treetest"
+	"github.com/mrtcx/plusdata/tree"
+)
+
+func TestConformance(t *testing.T) {
+	for _, order := range []int{3, 4, 5} {
+		torder := order
+		t.Run(fmt.Sprintf("[order:%d]", torder), func(t *testing.T) {
+			treetest.Run(t, func() tree.Tree[int, int] { return New[int, int](intcmp, torder) })
+		})
+	}
+}
@@ -6,41 +6,41 @@ package btree
 
 import "github.com/mrtcx/plusdata/tree"
 
-type element struct {
-	btree *bTree
-	node  *Node
+type element[K, V any] struct {
+	btree *bTree[K, V]
+	node  *Node[K, V]
 	idx   int
 }
 
-func (e *element) Key() interface{} {
+func (e *element[K, V]) Key() K {
 	return e.node.keys[e.idx]
 }
 
-func (e *element) Value() interface{} {
+func (e *element[K, V]) Value() V {
 	return e.node.values[e.idx]
 }
 
-func (e *element) SetValue(value interface{}) {
+func (e *element[K, V]) SetValue(value V) {
 	e.node.values[e.idx] = value
 }
 
-func (e *element) Next() tree.Element {
+func (e *element[K, V]) Next() tree.Element[K, V] {
 	return e.btree.Next(e.node.keys[e.idx])
 }
 
-func (e *element) Prev() tree.Element {
+func (e *element[K, V]) Prev() tree.Element[K, V] {
 	return e.btree.Prev(e.node.keys[e.idx])
 }
 
-func (bp *bTree) Find(key interface{}) tree.Element {
+func (bp *bTree[K, V]) Find(key K) tree.Element[K, V] {
 	node, idx := bp.findNodeIdx(key)
 	if node == nil {
 		return nil
 	}
-	return &element{btree: bp, node: node, idx: idx}
+	return &element[K, V]{btree: bp, node: node, idx: idx}
 }
 
-func (bp *bTree) Left() tree.Element {
+func (bp *bTree[K, V]) Left() tree.Element[K, V] {
 	if bp.root == nil {
 		return nil
 	}
@@ -48,10 +48,10 @@ func (bp *bTree) Left() tree.Element {
 	if mleft == nil {
 		return nil
 	}
-	return &element{btree: bp, node: mleft, idx: 0}
+	return &element[K, V]{btree: bp, node: mleft, idx: 0}
 }
 
-func (bp *bTree) Right() tree.Element {
+func (bp *bTree[K, V]) Right() tree.Element[K, V] {
 	if bp.root == nil {
 		return nil
 	}
@@ -59,21 +59,21 @@ func (bp *bTree) Right() tree.Element {
 	if mright == nil {
 		return nil
 	}
-	return &element{btree: bp, node: mright, idx: len(mright.keys) - 1}
+	return &element[K, V]{btree: bp, node: mright, idx: len(mright.keys) - 1}
 }
 
-func (bp *bTree) Prev(key interface{}) tree.Element {
+func (bp *bTree[K, V]) Prev(key K) tree.Element[K, V] {
 	node, idx := bp.findPrevNodeIdx(key)
 	if node == nil {
 		return nil
 	}
-	return &element{btree: bp, node: node, idx: idx}
+	return &element[K, V]{btree: bp, node: node, idx: idx}
 }
 
-func (bp *bTree) Next(key interface{}) tree.Element {
+func (bp *bTree[K, V]) Next(key K) tree.Element[K, V] {
 	node, idx := bp.findNextNodeIdx(key)
 	if node == nil {
 		return nil
 	}
-	return &element{btree: bp, node: node, idx: idx}
+	return &element[K, V]{btree: bp, node: node, idx: idx}
 }
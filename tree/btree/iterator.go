@@ -0,0 +1,142 @@
+// Copyright (c) 2025 Tian ChunXing. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package btree
+
+import "github.com/mrtcx/plusdata/tree"
+
+var _ tree.Iterable[int, int] = (*bTree[int, int])(nil)
+
+// Iterator 栈中保存从根到当前节点的路径，栈顶的idx为当前key的下标，其余为下降到的子节点下标
+type Iterator[K, V any] struct {
+	btree *bTree[K, V]
+	stack []position[K, V]
+}
+
+func (bp *bTree[K, V]) Iterator() tree.Iterator[K, V] {
+	return &Iterator[K, V]{btree: bp, stack: make([]position[K, V], 0, 32)}
+}
+
+func (it *Iterator[K, V]) Seek(key K) bool {
+	it.stack = it.stack[:0]
+	for root := it.btree.root; root != nil; root = root.childs[it.stack[len(it.stack)-1].idx] {
+		idx := it.btree.binarySearchIdx(key, root)
+		it.stack = append(it.stack, position[K, V]{node: root, idx: idx})
+		if idx < len(root.keys) && it.btree.cmp(root.keys[idx], key) == 0 {
+			return true
+		}
+		if root.isLeaf() {
+			if idx == len(root.keys) {
+				it.upNext()
+			}
+			break
+		}
+	}
+	return it.Valid()
+}
+
+func (it *Iterator[K, V]) First() bool {
+	it.stack = it.stack[:0]
+	it.pushLeft(it.btree.root)
+	return it.Valid()
+}
+
+func (it *Iterator[K, V]) Last() bool {
+	it.stack = it.stack[:0]
+	it.pushRight(it.btree.root)
+	return it.Valid()
+}
+
+func (it *Iterator[K, V]) Next() bool {
+	if !it.Valid() {
+		return false
+	}
+	top := &it.stack[len(it.stack)-1]
+	if !top.node.isLeaf() {
+		top.idx++
+		it.pushLeft(top.node.childs[top.idx])
+		return true
+	}
+	if top.idx++; top.idx == len(top.node.keys) {
+		it.upNext()
+	}
+	return it.Valid()
+}
+
+func (it *Iterator[K, V]) Prev() bool {
+	if !it.Valid() {
+		return false
+	}
+	top := &it.stack[len(it.stack)-1]
+	if !top.node.isLeaf() {
+		it.pushRight(top.node.childs[top.idx])
+		return true
+	}
+	if top.idx == 0 {
+		it.upPrev()
+	} else {
+		top.idx--
+	}
+	return it.Valid()
+}
+
+func (it *Iterator[K, V]) Valid() bool {
+	return len(it.stack) > 0
+}
+
+func (it *Iterator[K, V]) Key() K {
+	top := it.stack[len(it.stack)-1]
+	return top.node.keys[top.idx]
+}
+
+func (it *Iterator[K, V]) Value() V {
+	top := it.stack[len(it.stack)-1]
+	return top.node.values[top.idx]
+}
+
+// 当前节点已访问完，向上回溯到第一个childs[idx]右边还有key的祖先
+func (it *Iterator[K, V]) upNext() {
+	it.stack = it.stack[:len(it.stack)-1]
+	for len(it.stack) > 0 {
+		top := it.stack[len(it.stack)-1]
+		if top.idx < len(top.node.keys) {
+			return
+		}
+		it.stack = it.stack[:len(it.stack)-1]
+	}
+}
+
+// 向上回溯到第一个childs[idx]左边还有key的祖先
+func (it *Iterator[K, V]) upPrev() {
+	it.stack = it.stack[:len(it.stack)-1]
+	for len(it.stack) > 0 {
+		top := &it.stack[len(it.stack)-1]
+		if top.idx > 0 {
+			top.idx--
+			return
+		}
+		it.stack = it.stack[:len(it.stack)-1]
+	}
+}
+
+func (it *Iterator[K, V]) pushLeft(root *Node[K, V]) {
+	for root != nil {
+		it.stack = append(it.stack, position[K, V]{node: root, idx: 0})
+		if root.isLeaf() {
+			break
+		}
+		root = root.childs[0]
+	}
+}
+
+func (it *Iterator[K, V]) pushRight(root *Node[K, V]) {
+	for root != nil {
+		if root.isLeaf() {
+			it.stack = append(it.stack, position[K, V]{node: root, idx: len(root.keys) - 1})
+			break
+		}
+		it.stack = append(it.stack, position[K, V]{node: root, idx: len(root.keys)})
+		root = root.childs[len(root.childs)-1]
+	}
+}
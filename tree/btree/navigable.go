@@ -0,0 +1,98 @@
+// Copyright (c) 2025 Tian ChunXing. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package btree
+
+import "github.com/mrtcx/plusdata/tree"
+
+var _ tree.Navigable[int, int] = (*bTree[int, int])(nil)
+
+// Floor 最后一个<=key的元素
+func (bp *bTree[K, V]) Floor(key K) tree.Element[K, V] {
+	var floor *Node[K, V]
+	var floorIdx int
+	for root := bp.root; root != nil; {
+		idx := bp.binarySearchIdx(key, root)
+		if idx < len(root.keys) && bp.cmp(root.keys[idx], key) == 0 {
+			floor, floorIdx = root, idx
+			break
+		}
+		if idx > 0 {
+			floor, floorIdx = root, idx-1
+		}
+		if root.isLeaf() {
+			break
+		}
+		root = root.childs[idx]
+	}
+	if floor == nil {
+		return nil
+	}
+	return &element[K, V]{btree: bp, node: floor, idx: floorIdx}
+}
+
+// Ceiling 第一个>=key的元素
+func (bp *bTree[K, V]) Ceiling(key K) tree.Element[K, V] {
+	var ceiling *Node[K, V]
+	var ceilingIdx int
+	for root := bp.root; root != nil; {
+		idx := bp.binarySearchIdx(key, root)
+		if idx < len(root.keys) {
+			ceiling, ceilingIdx = root, idx
+			if bp.cmp(root.keys[idx], key) == 0 {
+				break
+			}
+		}
+		if root.isLeaf() {
+			break
+		}
+		root = root.childs[idx]
+	}
+	if ceiling == nil {
+		return nil
+	}
+	return &element[K, V]{btree: bp, node: ceiling, idx: ceilingIdx}
+}
+
+func (bp *bTree[K, V]) Lower(key K) tree.Element[K, V] {
+	return bp.Prev(key)
+}
+
+func (bp *bTree[K, V]) Higher(key K) tree.Element[K, V] {
+	return bp.Next(key)
+}
+
+func (bp *bTree[K, V]) PollFirst() (K, V, bool) {
+	return bp.poll(bp.Left())
+}
+
+func (bp *bTree[K, V]) PollLast() (K, V, bool) {
+	return bp.poll(bp.Right())
+}
+
+func (bp *bTree[K, V]) poll(e tree.Element[K, V]) (K, V, bool) {
+	if e == nil {
+		var zeroK K
+		var zeroV V
+		return zeroK, zeroV, false
+	}
+	key, value := e.Key(), e.Value()
+	bp.Remove(key)
+	return key, value, true
+}
+
+// HeadMap key<to的元素的视图，视图中的修改直接作用于树
+func (bp *bTree[K, V]) HeadMap(to K) tree.Navigable[K, V] {
+	return tree.NewHeadMap[K, V](bp, bp.cmp, to)
+}
+
+// TailMap key>=from的元素的视图
+func (bp *bTree[K, V]) TailMap(from K) tree.Navigable[K, V] {
+	return tree.NewTailMap[K, V](bp, bp.cmp, from)
+}
+
+// SubMap 区间[from, to)内的元素的视图，bound可指定区间开闭
+func (bp *bTree[K, V]) SubMap(from, to K, bound ...tree.Bound) tree.Navigable[K, V] {
+	return tree.NewSubMap[K, V](bp, bp.cmp, from, to, bound...)
+}
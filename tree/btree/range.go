@@ -0,0 +1,120 @@
+// Copyright (c) 2025 Tian ChunXing. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package btree
+
+import "github.com/mrtcx/plusdata/tree"
+
+// 遍历栈中的位置，idx为节点中下一个要访问的key
+type position[K, V any] struct {
+	node *Node[K, V]
+	idx  int
+}
+
+// AscendRange 正序遍历区间[lo, hi)内的元素, fn返回false时停止, bound可指定区间开闭
+func (bp *bTree[K, V]) AscendRange(lo, hi K, fn func(key K, value V) bool, bound ...tree.Bound) {
+	b := tree.RangeBound(bound)
+	bp.ascend(lo, b.LoInclusive(), func(key K) bool {
+		less := bp.cmp(key, hi)
+		return less < 0 || less == 0 && b.HiInclusive()
+	}, fn)
+}
+
+// DescendRange 倒序遍历区间[lo, hi)内的元素, fn返回false时停止, bound可指定区间开闭
+func (bp *bTree[K, V]) DescendRange(lo, hi K, fn func(key K, value V) bool, bound ...tree.Bound) {
+	b := tree.RangeBound(bound)
+	bp.descend(hi, b.HiInclusive(), func(key K) bool {
+		less := bp.cmp(key, lo)
+		return less > 0 || less == 0 && b.LoInclusive()
+	}, fn)
+}
+
+// AscendGreaterOrEqual 正序遍历key>=lo的元素
+func (bp *bTree[K, V]) AscendGreaterOrEqual(lo K, fn func(key K, value V) bool) {
+	bp.ascend(lo, true, nil, fn)
+}
+
+// DescendLessOrEqual 倒序遍历key<=hi的元素
+func (bp *bTree[K, V]) DescendLessOrEqual(hi K, fn func(key K, value V) bool) {
+	bp.descend(hi, true, nil, fn)
+}
+
+// 栈中保存每层节点下一个要访问的位置，访问完keys[idx]后把childs[idx+1]的左链入栈，整体O(logN + k)
+func (bp *bTree[K, V]) ascend(lo K, include bool, inRange func(key K) bool, fn func(key K, value V) bool) {
+	stack := make([]position[K, V], 0, 32)
+	for root := bp.root; root != nil; {
+		idx := bp.binarySearchIdx(lo, root)
+		if idx < len(root.keys) && bp.cmp(root.keys[idx], lo) == 0 {
+			if include {
+				stack = append(stack, position[K, V]{node: root, idx: idx})
+				break
+			}
+			idx++
+		}
+		stack = append(stack, position[K, V]{node: root, idx: idx})
+		if root.isLeaf() {
+			break
+		}
+		root = root.childs[idx]
+	}
+	for len(stack) > 0 {
+		top := len(stack) - 1
+		node, idx := stack[top].node, stack[top].idx
+		if idx == len(node.keys) {
+			stack = stack[:top]
+			continue
+		}
+		if inRange != nil && !inRange(node.keys[idx]) || !fn(node.keys[idx], node.values[idx]) {
+			return
+		}
+		stack[top].idx++
+		if !node.isLeaf() {
+			for root := node.childs[idx+1]; root != nil; {
+				stack = append(stack, position[K, V]{node: root, idx: 0})
+				if root.isLeaf() {
+					break
+				}
+				root = root.childs[0]
+			}
+		}
+	}
+}
+
+// 与ascend对称，访问完keys[idx]后把childs[idx]的右链入栈
+func (bp *bTree[K, V]) descend(hi K, include bool, inRange func(key K) bool, fn func(key K, value V) bool) {
+	stack := make([]position[K, V], 0, 32)
+	for root := bp.root; root != nil; {
+		idx := bp.binarySearchIdx(hi, root)
+		if include && idx < len(root.keys) && bp.cmp(root.keys[idx], hi) == 0 {
+			stack = append(stack, position[K, V]{node: root, idx: idx})
+			break
+		}
+		stack = append(stack, position[K, V]{node: root, idx: idx - 1})
+		if root.isLeaf() {
+			break
+		}
+		root = root.childs[idx]
+	}
+	for len(stack) > 0 {
+		top := len(stack) - 1
+		node, idx := stack[top].node, stack[top].idx
+		if idx < 0 {
+			stack = stack[:top]
+			continue
+		}
+		if inRange != nil && !inRange(node.keys[idx]) || !fn(node.keys[idx], node.values[idx]) {
+			return
+		}
+		stack[top].idx--
+		if !node.isLeaf() {
+			for root := node.childs[idx]; root != nil; {
+				stack = append(stack, position[K, V]{node: root, idx: len(root.keys) - 1})
+				if root.isLeaf() {
+					break
+				}
+				root = root.childs[len(root.childs)-1]
+			}
+		}
+	}
+}
@@ -0,0 +1,48 @@
+// Copyright (c) 2025 Tian ChunXing. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package btree
+
+import "github.com/mrtcx/plusdata/tree"
+
+// Rank 返回树中小于key的元素个数，即key在顺序遍历中的下标
+func (bp *bTree[K, V]) Rank(key K) int {
+	rank := 0
+	for root := bp.root; root != nil; {
+		idx := bp.binarySearchIdx(key, root)
+		rank += idx
+		for i := 0; i < idx && i < len(root.counts); i++ {
+			rank += root.counts[i]
+		}
+		if root.isLeaf() {
+			break
+		}
+		if idx < len(root.keys) && bp.cmp(root.keys[idx], key) == 0 {
+			return rank + root.counts[idx]
+		}
+		root = root.childs[idx]
+	}
+	return rank
+}
+
+// Select 返回顺序遍历中下标为k的元素(从0开始)，k越界时返回nil
+func (bp *bTree[K, V]) Select(k int) tree.Element[K, V] {
+	if k < 0 || k >= bp.size {
+		return nil
+	}
+	root := bp.root
+	for !root.isLeaf() {
+		idx := 0
+		for k >= root.counts[idx] {
+			k -= root.counts[idx]
+			if k == 0 {
+				return &element[K, V]{btree: bp, node: root, idx: idx}
+			}
+			k--
+			idx++
+		}
+		root = root.childs[idx]
+	}
+	return &element[K, V]{btree: bp, node: root, idx: k}
+}
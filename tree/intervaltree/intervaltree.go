@@ -0,0 +1,95 @@
+// Copyright (c) 2025 Tian ChunXing. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+// Package intervaltree 区间树，基于带聚合的红黑树(rbtree.NewAggregate)，聚合值为子树中区间右端点的最大值，
+// 查询与给定区间重叠的区间时，可以跳过最大右端点不超过查询左端点的子树
+package intervaltree
+
+import (
+	"github.com/mrtcx/plusdata/tree"
+	"github.com/mrtcx/plusdata/tree/rbtree"
+)
+
+// Interval 左闭右开区间[Lo, Hi)，Lo>=Hi时为空区间
+type Interval[K any] struct {
+	Lo, Hi K
+}
+
+// 子树中区间右端点的最大值，ok为false时子树为空
+type maxHi[K any] struct {
+	hi K
+	ok bool
+}
+
+// 区间为key、右端点最大值为聚合值的红黑树
+type aggTree[K, V any] interface {
+	tree.MultiTree[Interval[K], V]
+	tree.AggregateTree[Interval[K], V, maxHi[K]]
+}
+
+type intervalTree[K, V any] struct {
+	rb  aggTree[K, V]
+	cmp tree.Comparator[K]
+}
+
+// New cmp为端点的比较函数，区间按左端点排序，左端点相同时按右端点排序，区间相同时按添加的顺序排列
+func New[K, V any](cmp tree.Comparator[K]) *intervalTree[K, V] {
+	it := &intervalTree[K, V]{cmp: cmp}
+	it.rb = rbtree.NewAggregate(it.compare, tree.Aggregator[Interval[K], V, maxHi[K]]{
+		Identity: maxHi[K]{},
+		Combine:  it.combine,
+		Lift:     func(iv Interval[K], value V) maxHi[K] { return maxHi[K]{hi: iv.Hi, ok: true} },
+	})
+	return it
+}
+
+func (it *intervalTree[K, V]) Clean() {
+	it.rb.Clean()
+}
+
+func (it *intervalTree[K, V]) Size() int {
+	return it.rb.Size()
+}
+
+func (it *intervalTree[K, V]) Empty() bool {
+	return it.rb.Empty()
+}
+
+// 区间的顺序，先比较左端点，再比较右端点
+func (it *intervalTree[K, V]) compare(a, b Interval[K]) int {
+	if less := it.cmp(a.Lo, b.Lo); less != 0 {
+		return less
+	}
+	return it.cmp(a.Hi, b.Hi)
+}
+
+func (it *intervalTree[K, V]) combine(a, b maxHi[K]) maxHi[K] {
+	if !a.ok || b.ok && it.cmp(b.hi, a.hi) > 0 {
+		return b
+	}
+	return a
+}
+
+// Insert 添加区间，相同的区间重复添加时都保留，排在已有的区间之后；空区间不与任何区间重叠，不会被添加
+func (it *intervalTree[K, V]) Insert(iv Interval[K], value V) {
+	if it.cmp(iv.Lo, iv.Hi) >= 0 {
+		return
+	}
+	it.rb.InsertDup(iv, value)
+}
+
+// Remove 删除与iv相同的全部区间，返回删除的个数
+func (it *intervalTree[K, V]) Remove(iv Interval[K]) int {
+	return it.rb.RemoveAll(iv)
+}
+
+// RemoveOne 删除第一个与iv相同且match返回true的区间
+func (it *intervalTree[K, V]) RemoveOne(iv Interval[K], match func(value V) bool) bool {
+	return it.rb.RemoveOne(iv, match)
+}
+
+// Count 与iv相同的区间个数
+func (it *intervalTree[K, V]) Count(iv Interval[K]) int {
+	return it.rb.Count(iv)
+}
@@ -0,0 +1,90 @@
+// Copyright (c) 2025 Tian ChunXing. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package intervaltree
+
+import (
+	"fmt"
+	"math/rand"
+	"testing"
+
+	"github.com/mrtcx/plusdata/internal/assert"
+	"github.com/mrtcx/plusdata/tree"
+)
+
+var intcmp = tree.OrderedComparator[int]
+
+func TestInsertRemove(t *testing.T) {
+	nums := []int{1, 2, 8, 64, 1024}
+	for _, num := range nums {
+		tnum := num
+		t.Run(fmt.Sprintf("[num:%d]", tnum), func(t *testing.T) {
+			it := New[int, int](intcmp)
+			exist := map[Interval[int]][]int{} //相同区间的value按添加的顺序
+			size := 0
+			for i := 0; i < 8*tnum; i++ {
+				lo := rand.Intn(tnum)
+				iv := Interval[int]{Lo: lo, Hi: lo + 1 + rand.Intn(4)}
+				switch rand.Intn(6) {
+				case 0:
+					assert.Equal(t, it.Remove(iv), len(exist[iv]))
+					size -= len(exist[iv])
+					delete(exist, iv)
+				case 1:
+					values := exist[iv]
+					if len(values) == 0 {
+						assert.Equal(t, it.RemoveOne(iv, func(int) bool { return true }), false)
+						continue
+					}
+					k := rand.Intn(len(values))
+					target := values[k]
+					assert.Equal(t, it.RemoveOne(iv, func(value int) bool { return value == target }), true)
+					exist[iv] = append(values[:k:k], values[k+1:]...)
+					size--
+				default:
+					it.Insert(iv, i)
+					exist[iv] = append(exist[iv], i)
+					size++
+				}
+				if i%(1+tnum/8) == 0 {
+					checkTree(t, it, size)
+				}
+			}
+			checkTree(t, it, size)
+			for iv, values := range exist {
+				assert.Equal(t, it.Count(iv), len(values))
+				v, ok := it.Get(iv)
+				assert.Equal(t, ok, len(values) > 0)
+				if len(values) > 0 {
+					assert.Equal(t, v, values[0])
+				}
+			}
+			_, ok := it.Get(Interval[int]{Lo: -1, Hi: 0})
+			assert.Equal(t, ok, false)
+			it.Clean()
+			assert.Equal(t, it.Empty(), true)
+		})
+	}
+}
+
+// 检查区间的顺序和个数，相同的区间按添加的顺序(value递增)
+func checkTree(t *testing.T, it *intervalTree[int, int], size int) {
+	assert.Equal(t, it.Size(), size)
+	n := 0
+	var prev *Interval[int]
+	prevValue := 0
+	it.Ascend(func(iv Interval[int], value int) bool {
+		if prev != nil {
+			less := it.compare(*prev, iv)
+			assert.Equal(t, less <= 0, true)
+			if less == 0 {
+				assert.Greater(t, value, prevValue)
+			}
+		}
+		prev, prevValue = &iv, value
+		n++
+		return true
+	})
+	assert.Equal(t, n, size)
+}
@@ -0,0 +1,62 @@
+// Copyright (c) 2025 Tian ChunXing. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package intervaltree
+
+// Get 获取区间的值，区间需要完全相同，存在相同的区间时返回最先添加的
+func (it *intervalTree[K, V]) Get(iv Interval[K]) (V, bool) {
+	first, _ := it.rb.EqualRange(iv)
+	if first == nil {
+		var zero V
+		return zero, false
+	}
+	return first.Value(), true
+}
+
+// Ascend 按区间的顺序遍历全部区间，fn返回false停止
+func (it *intervalTree[K, V]) Ascend(fn func(iv Interval[K], value V) bool) {
+	it.rb.AscendFilter(func(maxHi[K]) bool { return true }, fn)
+}
+
+// Overlapping 按区间的顺序遍历与[lo, hi)重叠的区间，fn返回false停止；lo>=hi时查询区间为空，不与任何区间重叠
+func (it *intervalTree[K, V]) Overlapping(lo, hi K, fn func(iv Interval[K], value V) bool) {
+	if it.cmp(lo, hi) >= 0 {
+		return
+	}
+	it.search(lo, hi, false, fn)
+}
+
+// Stabbing 按区间的顺序遍历包含point的区间(Lo<=point<Hi)，fn返回false停止
+func (it *intervalTree[K, V]) Stabbing(point K, fn func(iv Interval[K], value V) bool) {
+	it.search(point, point, true, fn)
+}
+
+// AnyOverlap 返回第一个与[lo, hi)重叠的区间，复杂度O(logN)
+// 按顺序第一个右端点>lo的区间如果左端点>=hi，之后的区间左端点更大，也不会重叠
+func (it *intervalTree[K, V]) AnyOverlap(lo, hi K) (Interval[K], V, bool) {
+	if it.cmp(lo, hi) < 0 {
+		e := it.rb.SearchPrefix(func(agg maxHi[K]) bool { return agg.ok && it.cmp(agg.hi, lo) > 0 })
+		if e != nil && it.beforeHi(e.Key().Lo, hi, false) {
+			return e.Key(), e.Value(), true
+		}
+	}
+	var zeroIv Interval[K]
+	var zeroV V
+	return zeroIv, zeroV, false
+}
+
+// 顺序遍历与[lo, hi)重叠的区间(hiIncl为true时为[lo, hi])，子树的最大右端点<=lo时整个子树都不重叠，
+// 区间的左端点超过hi时，之后的区间左端点更大，停止遍历
+func (it *intervalTree[K, V]) search(lo, hi K, hiIncl bool, fn func(iv Interval[K], value V) bool) {
+	it.rb.AscendFilter(func(agg maxHi[K]) bool {
+		return agg.ok && it.cmp(agg.hi, lo) > 0
+	}, func(iv Interval[K], value V) bool {
+		return it.beforeHi(iv.Lo, hi, hiIncl) && fn(iv, value)
+	})
+}
+
+func (it *intervalTree[K, V]) beforeHi(key, hi K, hiIncl bool) bool {
+	less := it.cmp(key, hi)
+	return less < 0 || hiIncl && less == 0
+}
@@ -0,0 +1,140 @@
+// Copyright (c) 2025 Tian ChunXing. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package intervaltree
+
+import (
+	"fmt"
+	"math/rand"
+	"sort"
+	"testing"
+
+	"github.com/mrtcx/plusdata/internal/assert"
+)
+
+func TestQuery(t *testing.T) {
+	nums := []int{0, 1, 8, 64, 1024}
+	for _, num := range nums {
+		tnum := num
+		t.Run(fmt.Sprintf("[num:%d]", tnum), func(t *testing.T) {
+			it := New[int, int](intcmp)
+			var ivs []Interval[int]
+			for i := 0; i < tnum; i++ {
+				lo := rand.Intn(4 * tnum)
+				iv := Interval[int]{Lo: lo, Hi: lo + rand.Intn(32) - 2} //包含空区间和相同的区间
+				it.Insert(iv, iv.Hi-iv.Lo)
+				if iv.Lo < iv.Hi {
+					ivs = append(ivs, iv)
+				}
+			}
+			sort.SliceStable(ivs, func(i, j int) bool {
+				return it.compare(ivs[i], ivs[j]) < 0
+			})
+			for i := 0; i < 256; i++ {
+				lo := rand.Intn(4*tnum+40) - 4
+				hi := lo + rand.Intn(40)
+				var wanted []Interval[int]
+				for _, iv := range ivs {
+					if lo < hi && iv.Lo < hi && lo < iv.Hi { //lo>=hi时为空区间
+						wanted = append(wanted, iv)
+					}
+				}
+				var got []Interval[int]
+				it.Overlapping(lo, hi, func(iv Interval[int], value int) bool {
+					assert.Equal(t, value, iv.Hi-iv.Lo)
+					got = append(got, iv)
+					return true
+				})
+				expectIntervals(t, got, wanted)
+
+				_, _, ok := it.AnyOverlap(lo, hi)
+				assert.Equal(t, ok, len(wanted) > 0)
+				if ok {
+					iv, _, _ := it.AnyOverlap(lo, hi)
+					assert.Equal(t, iv.Lo < hi && lo < iv.Hi, true)
+				}
+
+				wanted = wanted[:0]
+				for _, iv := range ivs {
+					if iv.Lo <= lo && lo < iv.Hi {
+						wanted = append(wanted, iv)
+					}
+				}
+				got = got[:0]
+				it.Stabbing(lo, func(iv Interval[int], value int) bool {
+					got = append(got, iv)
+					return true
+				})
+				expectIntervals(t, got, wanted)
+			}
+		})
+	}
+}
+
+func TestQueryStop(t *testing.T) {
+	it := New[int, string](intcmp)
+	it.Insert(Interval[int]{Lo: 1, Hi: 5}, "a")
+	it.Insert(Interval[int]{Lo: 2, Hi: 3}, "b")
+	it.Insert(Interval[int]{Lo: 4, Hi: 8}, "c")
+	it.Insert(Interval[int]{Lo: 5, Hi: 6}, "d")
+	it.Insert(Interval[int]{Lo: 3, Hi: 3}, "empty")
+	assert.Equal(t, it.Size(), 4)
+	var got []string
+	it.Overlapping(2, 5, func(iv Interval[int], value string) bool {
+		got = append(got, value)
+		return len(got) < 2
+	})
+	assert.Equal(t, len(got), 2)
+	assert.Equal(t, got[0], "a")
+	assert.Equal(t, got[1], "b")
+	got = got[:0]
+	it.Stabbing(5, func(iv Interval[int], value string) bool {
+		got = append(got, value)
+		return true
+	})
+	assert.Equal(t, len(got), 2) //[1, 5)不包含5
+	assert.Equal(t, got[0], "c")
+	assert.Equal(t, got[1], "d")
+	_, _, ok := it.AnyOverlap(8, 10)
+	assert.Equal(t, ok, false)
+
+	//查询区间为空时不与任何区间重叠
+	for _, q := range [][2]int{{5, 2}, {3, 3}} {
+		n := 0
+		it.Overlapping(q[0], q[1], func(iv Interval[int], value string) bool {
+			n++
+			return true
+		})
+		assert.Equal(t, n, 0)
+		_, _, ok = it.AnyOverlap(q[0], q[1])
+		assert.Equal(t, ok, false)
+	}
+
+	//相同的区间都保留，按添加的顺序遍历
+	it.Insert(Interval[int]{Lo: 2, Hi: 3}, "b2")
+	assert.Equal(t, it.Size(), 5)
+	assert.Equal(t, it.Count(Interval[int]{Lo: 2, Hi: 3}), 2)
+	got = got[:0]
+	it.Stabbing(2, func(iv Interval[int], value string) bool {
+		got = append(got, value)
+		return true
+	})
+	assert.Equal(t, fmt.Sprint(got), "[a b b2]")
+	value, _ := it.Get(Interval[int]{Lo: 2, Hi: 3})
+	assert.Equal(t, value, "b")
+	assert.Equal(t, it.RemoveOne(Interval[int]{Lo: 2, Hi: 3}, func(value string) bool { return value == "b" }), true)
+	value, _ = it.Get(Interval[int]{Lo: 2, Hi: 3})
+	assert.Equal(t, value, "b2")
+	assert.Equal(t, it.Remove(Interval[int]{Lo: 2, Hi: 3}), 1)
+	it.Remove(Interval[int]{Lo: 1, Hi: 5})
+	_, _, ok = it.AnyOverlap(0, 2)
+	assert.Equal(t, ok, false)
+}
+
+func expectIntervals(t *testing.T, got, wanted []Interval[int]) {
+	assert.Equal(t, len(got), len(wanted))
+	for i := 0; i < len(got) && i < len(wanted); i++ {
+		assert.Equal(t, got[i], wanted[i])
+	}
+}
@@ -0,0 +1,370 @@
+// Copyright (c) 2025 Tian ChunXing. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package tree
+
+// Navigable 有序映射的导航操作(类似Java的NavigableMap)，存在重复key时Floor返回相等key中的最后一个，
+// Ceiling返回相等key中的第一个
+type Navigable[K, V any] interface {
+	Tree[K, V]
+	Floor(key K) Element[K, V]   //最后一个<=key的元素
+	Ceiling(key K) Element[K, V] //第一个>=key的元素
+	Lower(key K) Element[K, V]   //最后一个<key的元素，同Prev
+	Higher(key K) Element[K, V]  //第一个>key的元素，同Next
+	PollFirst() (K, V, bool)     //删除并返回最左端的元素，为空时返回false
+	PollLast() (K, V, bool)      //删除并返回最右端的元素，为空时返回false
+	HeadMap(to K) Navigable[K, V]
+	TailMap(from K) Navigable[K, V]
+	SubMap(from, to K, bound ...Bound) Navigable[K, V]
+}
+
+// NewHeadMap key<to的元素的视图，通常通过树的HeadMap方法使用
+func NewHeadMap[K, V any](t Navigable[K, V], cmp Comparator[K], to K) Navigable[K, V] {
+	return &subMap[K, V]{t: t, cmp: cmp, hi: to, hasHi: true}
+}
+
+// NewTailMap key>=from的元素的视图，通常通过树的TailMap方法使用
+func NewTailMap[K, V any](t Navigable[K, V], cmp Comparator[K], from K) Navigable[K, V] {
+	return &subMap[K, V]{t: t, cmp: cmp, lo: from, hasLo: true, loIncl: true}
+}
+
+// NewSubMap 区间[from, to)内的元素的视图，bound可指定区间开闭，通常通过树的SubMap方法使用
+func NewSubMap[K, V any](t Navigable[K, V], cmp Comparator[K], from, to K, bound ...Bound) Navigable[K, V] {
+	b := RangeBound(bound)
+	return &subMap[K, V]{
+		t: t, cmp: cmp,
+		lo: from, hasLo: true, loIncl: b.LoInclusive(),
+		hi: to, hasHi: true, hiIncl: b.HiInclusive(),
+	}
+}
+
+// subMap 树中一个区间的视图，不复制元素，所有操作直接作用于底层的树，树的修改在视图中立即可见
+// 区间外的key不会被插入和删除；Size为O(logN)，Clean为O(klogN)
+type subMap[K, V any] struct {
+	t              Navigable[K, V]
+	cmp            Comparator[K]
+	lo, hi         K
+	hasLo, hasHi   bool
+	loIncl, hiIncl bool
+}
+
+// key是否在区间左端之外
+func (m *subMap[K, V]) tooLow(key K) bool {
+	if !m.hasLo {
+		return false
+	}
+	less := m.cmp(key, m.lo)
+	return less < 0 || less == 0 && !m.loIncl
+}
+
+// key是否在区间右端之外
+func (m *subMap[K, V]) tooHigh(key K) bool {
+	if !m.hasHi {
+		return false
+	}
+	less := m.cmp(key, m.hi)
+	return less > 0 || less == 0 && !m.hiIncl
+}
+
+func (m *subMap[K, V]) inRange(key K) bool {
+	return !m.tooLow(key) && !m.tooHigh(key)
+}
+
+// 与[lo, hi]取交集，得到新的视图
+func (m *subMap[K, V]) narrow(lo K, hasLo, loIncl bool, hi K, hasHi, hiIncl bool) *subMap[K, V] {
+	n := *m
+	if hasLo && (!n.hasLo || n.cmp(lo, n.lo) > 0 || n.cmp(lo, n.lo) == 0 && !loIncl) {
+		n.lo, n.hasLo, n.loIncl = lo, true, loIncl
+	}
+	if hasHi && (!n.hasHi || n.cmp(hi, n.hi) < 0 || n.cmp(hi, n.hi) == 0 && !hiIncl) {
+		n.hi, n.hasHi, n.hiIncl = hi, true, hiIncl
+	}
+	return &n
+}
+
+func (m *subMap[K, V]) wrap(e Element[K, V]) Element[K, V] {
+	if e == nil || !m.inRange(e.Key()) {
+		return nil
+	}
+	return &subMapElement[K, V]{m: m, e: e}
+}
+
+// 树中排在区间左端之前的元素个数
+func (m *subMap[K, V]) loRank() int {
+	if !m.hasLo {
+		return 0
+	}
+	if m.loIncl {
+		return m.t.Rank(m.lo)
+	}
+	return m.rankAfter(m.lo)
+}
+
+// 树中排在区间右端及之前的元素个数
+func (m *subMap[K, V]) hiRank() int {
+	if !m.hasHi {
+		return m.t.Size()
+	}
+	if m.hiIncl {
+		return m.rankAfter(m.hi)
+	}
+	return m.t.Rank(m.hi)
+}
+
+// 树中<=key的元素个数
+func (m *subMap[K, V]) rankAfter(key K) int {
+	e := m.t.Higher(key)
+	if e == nil {
+		return m.t.Size()
+	}
+	return m.t.Rank(e.Key())
+}
+
+func (m *subMap[K, V]) Size() int {
+	if size := m.hiRank() - m.loRank(); size > 0 {
+		return size
+	}
+	return 0
+}
+
+func (m *subMap[K, V]) Empty() bool {
+	return m.Left() == nil
+}
+
+// Clean 删除区间内的全部元素
+func (m *subMap[K, V]) Clean() {
+	for {
+		if _, _, ok := m.PollFirst(); !ok {
+			return
+		}
+	}
+}
+
+// Insert 区间外的key不会被插入
+func (m *subMap[K, V]) Insert(key K, value V) {
+	if m.inRange(key) {
+		m.t.Insert(key, value)
+	}
+}
+
+func (m *subMap[K, V]) Remove(key K) {
+	if m.inRange(key) {
+		m.t.Remove(key)
+	}
+}
+
+func (m *subMap[K, V]) Get(key K) (V, bool) {
+	if !m.inRange(key) {
+		var zero V
+		return zero, false
+	}
+	return m.t.Get(key)
+}
+
+func (m *subMap[K, V]) Find(key K) Element[K, V] {
+	if !m.inRange(key) {
+		return nil
+	}
+	return m.wrap(m.t.Find(key))
+}
+
+func (m *subMap[K, V]) Left() Element[K, V] {
+	switch {
+	case !m.hasLo:
+		return m.wrap(m.t.Left())
+	case m.loIncl:
+		return m.wrap(m.t.Ceiling(m.lo))
+	default:
+		return m.wrap(m.t.Higher(m.lo))
+	}
+}
+
+func (m *subMap[K, V]) Right() Element[K, V] {
+	switch {
+	case !m.hasHi:
+		return m.wrap(m.t.Right())
+	case m.hiIncl:
+		return m.wrap(m.t.Floor(m.hi))
+	default:
+		return m.wrap(m.t.Lower(m.hi))
+	}
+}
+
+func (m *subMap[K, V]) Prev(key K) Element[K, V] {
+	if m.tooHigh(key) {
+		return m.Right()
+	}
+	return m.wrap(m.t.Lower(key))
+}
+
+func (m *subMap[K, V]) Next(key K) Element[K, V] {
+	if m.tooLow(key) {
+		return m.Left()
+	}
+	return m.wrap(m.t.Higher(key))
+}
+
+func (m *subMap[K, V]) Floor(key K) Element[K, V] {
+	if m.tooHigh(key) {
+		return m.Right()
+	}
+	return m.wrap(m.t.Floor(key))
+}
+
+func (m *subMap[K, V]) Ceiling(key K) Element[K, V] {
+	if m.tooLow(key) {
+		return m.Left()
+	}
+	return m.wrap(m.t.Ceiling(key))
+}
+
+func (m *subMap[K, V]) Lower(key K) Element[K, V] {
+	return m.Prev(key)
+}
+
+func (m *subMap[K, V]) Higher(key K) Element[K, V] {
+	return m.Next(key)
+}
+
+// PollFirst 存在重复key时，删除的是相等key中的一个
+func (m *subMap[K, V]) PollFirst() (K, V, bool) {
+	if !m.hasLo {
+		if e := m.Left(); e == nil {
+			var zeroK K
+			var zeroV V
+			return zeroK, zeroV, false
+		}
+		return m.t.PollFirst()
+	}
+	return m.poll(m.Left())
+}
+
+// PollLast 存在重复key时，删除的是相等key中的一个
+func (m *subMap[K, V]) PollLast() (K, V, bool) {
+	if !m.hasHi {
+		if e := m.Right(); e == nil {
+			var zeroK K
+			var zeroV V
+			return zeroK, zeroV, false
+		}
+		return m.t.PollLast()
+	}
+	return m.poll(m.Right())
+}
+
+func (m *subMap[K, V]) poll(e Element[K, V]) (K, V, bool) {
+	if e == nil {
+		var zeroK K
+		var zeroV V
+		return zeroK, zeroV, false
+	}
+	key, value := e.Key(), e.Value()
+	m.t.Remove(key)
+	return key, value, true
+}
+
+func (m *subMap[K, V]) AscendRange(lo, hi K, fn func(key K, value V) bool, bound ...Bound) {
+	b := RangeBound(bound)
+	m.narrow(lo, true, b.LoInclusive(), hi, true, b.HiInclusive()).ascend(fn)
+}
+
+func (m *subMap[K, V]) DescendRange(lo, hi K, fn func(key K, value V) bool, bound ...Bound) {
+	b := RangeBound(bound)
+	m.narrow(lo, true, b.LoInclusive(), hi, true, b.HiInclusive()).descend(fn)
+}
+
+func (m *subMap[K, V]) AscendGreaterOrEqual(lo K, fn func(key K, value V) bool) {
+	var zero K
+	m.narrow(lo, true, true, zero, false, false).ascend(fn)
+}
+
+func (m *subMap[K, V]) DescendLessOrEqual(hi K, fn func(key K, value V) bool) {
+	var zero K
+	m.narrow(zero, false, false, hi, true, true).descend(fn)
+}
+
+// 正序遍历整个区间，区间总是有左端(由参数或视图给出)
+func (m *subMap[K, V]) ascend(fn func(key K, value V) bool) {
+	m.t.AscendGreaterOrEqual(m.lo, func(key K, value V) bool {
+		if m.tooLow(key) {
+			return true
+		}
+		if m.tooHigh(key) {
+			return false
+		}
+		return fn(key, value)
+	})
+}
+
+func (m *subMap[K, V]) descend(fn func(key K, value V) bool) {
+	m.t.DescendLessOrEqual(m.hi, func(key K, value V) bool {
+		if m.tooHigh(key) {
+			return true
+		}
+		if m.tooLow(key) {
+			return false
+		}
+		return fn(key, value)
+	})
+}
+
+// Rank 视图中小于key的元素个数
+func (m *subMap[K, V]) Rank(key K) int {
+	if m.tooLow(key) {
+		return 0
+	}
+	if m.tooHigh(key) {
+		return m.Size()
+	}
+	return m.t.Rank(key) - m.loRank()
+}
+
+// Select 视图中下标为k的元素
+func (m *subMap[K, V]) Select(k int) Element[K, V] {
+	if k < 0 {
+		return nil
+	}
+	return m.wrap(m.t.Select(m.loRank() + k))
+}
+
+func (m *subMap[K, V]) HeadMap(to K) Navigable[K, V] {
+	var zero K
+	return m.narrow(zero, false, false, to, true, false)
+}
+
+func (m *subMap[K, V]) TailMap(from K) Navigable[K, V] {
+	var zero K
+	return m.narrow(from, true, true, zero, false, false)
+}
+
+func (m *subMap[K, V]) SubMap(from, to K, bound ...Bound) Navigable[K, V] {
+	b := RangeBound(bound)
+	return m.narrow(from, true, b.LoInclusive(), to, true, b.HiInclusive())
+}
+
+// subMapElement 视图中的元素，向前向后遍历不会越过区间
+type subMapElement[K, V any] struct {
+	m *subMap[K, V]
+	e Element[K, V]
+}
+
+func (e *subMapElement[K, V]) Key() K {
+	return e.e.Key()
+}
+
+func (e *subMapElement[K, V]) Value() V {
+	return e.e.Value()
+}
+
+func (e *subMapElement[K, V]) SetValue(value V) {
+	e.e.SetValue(value)
+}
+
+func (e *subMapElement[K, V]) Next() Element[K, V] {
+	return e.m.wrap(e.e.Next())
+}
+
+func (e *subMapElement[K, V]) Prev() Element[K, V] {
+	return e.m.wrap(e.e.Prev())
+}
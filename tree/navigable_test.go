@@ -0,0 +1,336 @@
+// Copyright (c) 2025 Tian ChunXing. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package tree_test
+
+import (
+	"fmt"
+	"math/rand"
+	"sort"
+	"testing"
+
+	"github.com/mrtcx/plusdata/internal/assert"
+	"github.com/mrtcx/plusdata/tree"
+	"github.com/mrtcx/plusdata/tree/avltree"
+	"github.com/mrtcx/plusdata/tree/bplustree"
+	"github.com/mrtcx/plusdata/tree/btree"
+	"github.com/mrtcx/plusdata/tree/rbtree"
+	"github.com/mrtcx/plusdata/tree/scapegoat"
+	"github.com/mrtcx/plusdata/tree/skiplist"
+	"github.com/mrtcx/plusdata/tree/splaytree"
+	"github.com/mrtcx/plusdata/tree/treap"
+)
+
+var intcmp = tree.OrderedComparator[int]
+
+var navigables = map[string]func() tree.Navigable[int, int]{
+	"avltree":   func() tree.Navigable[int, int] { return avltree.New[int, int](intcmp) },
+	"rbtree":    func() tree.Navigable[int, int] { return rbtree.New[int, int](intcmp) },
+	"skiplist":  func() tree.Navigable[int, int] { return skiplist.New[int, int](intcmp) },
+	"btree":     func() tree.Navigable[int, int] { return btree.New[int, int](intcmp, 4) },
+	"bplustree": func() tree.Navigable[int, int] { return bplustree.New[int, int](intcmp, 4) },
+	"treap":     func() tree.Navigable[int, int] { return treap.New[int, int](intcmp) },
+	"splaytree": func() tree.Navigable[int, int] { return splaytree.New[int, int](intcmp) },
+	"scapegoat": func() tree.Navigable[int, int] { return scapegoat.New[int, int](intcmp) },
+}
+
+func TestNavigable(t *testing.T) {
+	nums := []int{0, 1, 8, 64, 1024}
+	for name, newTree := range navigables {
+		for _, num := range nums {
+			tname, tnum, tr := name, num, newTree()
+			t.Run(fmt.Sprintf("[%s][num:%d]", tname, tnum), func(t *testing.T) {
+				exist := map[int]bool{}
+				for i := 0; i < 4*tnum; i++ {
+					key := rand.Intn(tnum)
+					if rand.Intn(3) == 0 {
+						tr.Remove(key)
+						delete(exist, key)
+					} else {
+						tr.Insert(key, key)
+						exist[key] = true
+					}
+				}
+				expectNavigable(t, tr, sortedKeys(exist), -1, tnum)
+			})
+		}
+	}
+}
+
+func TestNavigableDup(t *testing.T) {
+	for name, newTree := range navigables {
+		tname, tr := name, newTree()
+		multi, ok := tr.(tree.MultiTree[int, int])
+		if !ok {
+			continue
+		}
+		t.Run(fmt.Sprintf("[%s]", tname), func(t *testing.T) {
+			first, last := map[int]int{}, map[int]int{}
+			for i := 0; i < 1024; i++ {
+				key := rand.Intn(64)
+				multi.InsertDup(key, i)
+				if _, ok := first[key]; !ok {
+					first[key] = i
+				}
+				last[key] = i
+			}
+			for key := 0; key < 64; key++ {
+				if _, ok := first[key]; !ok {
+					continue
+				}
+				assert.Equal(t, tr.Floor(key).Value(), last[key])
+				assert.Equal(t, tr.Ceiling(key).Value(), first[key])
+				assert.Equal(t, tr.Floor(key).Next() == nil || tr.Floor(key).Next().Key() > key, true)
+				assert.Equal(t, tr.Ceiling(key).Prev() == nil || tr.Ceiling(key).Prev().Key() < key, true)
+			}
+		})
+	}
+}
+
+func TestPoll(t *testing.T) {
+	for name, newTree := range navigables {
+		tname, tr := name, newTree()
+		t.Run(fmt.Sprintf("[%s]", tname), func(t *testing.T) {
+			_, _, ok := tr.PollFirst()
+			assert.Equal(t, ok, false)
+			_, _, ok = tr.PollLast()
+			assert.Equal(t, ok, false)
+			keys := rand.Perm(1024)
+			for _, key := range keys {
+				tr.Insert(key, key)
+			}
+			sort.Ints(keys)
+			for len(keys) > 0 {
+				if rand.Intn(2) == 0 {
+					key, value, ok := tr.PollFirst()
+					assert.Equal(t, ok, true)
+					assert.Equal(t, key, keys[0])
+					assert.Equal(t, value, keys[0])
+					keys = keys[1:]
+				} else {
+					key, value, ok := tr.PollLast()
+					assert.Equal(t, ok, true)
+					assert.Equal(t, key, keys[len(keys)-1])
+					assert.Equal(t, value, keys[len(keys)-1])
+					keys = keys[:len(keys)-1]
+				}
+				assert.Equal(t, tr.Size(), len(keys))
+			}
+			expectNavigable(t, tr, nil, -1, 1)
+		})
+	}
+}
+
+func TestSubMap(t *testing.T) {
+	bounds := []tree.Bound{tree.ClosedOpen, tree.Closed, tree.OpenClosed, tree.Open}
+	for name, newTree := range navigables {
+		for _, b := range bounds {
+			tname, tb, tr := name, b, newTree()
+			t.Run(fmt.Sprintf("[%s][bound:%d]", tname, tb), func(t *testing.T) {
+				exist := map[int]bool{}
+				for i := 0; i < 256; i++ {
+					key := rand.Intn(128)
+					tr.Insert(key, key)
+					exist[key] = true
+				}
+				from, to := rand.Intn(140)-6, rand.Intn(140)-6
+				view := tr.SubMap(from, to, tb)
+				inRange := func(key int) bool {
+					return (key > from || key == from && tb.LoInclusive()) && (key < to || key == to && tb.HiInclusive())
+				}
+				expectNavigable(t, view, filterKeys(exist, inRange), -8, 136)
+
+				// 视图中的修改作用于树，树的修改在视图中可见
+				for i := 0; i < 256; i++ {
+					key := rand.Intn(128)
+					switch rand.Intn(4) {
+					case 0:
+						view.Remove(key)
+						if inRange(key) {
+							delete(exist, key)
+						}
+					case 1:
+						view.Insert(key, key)
+						if inRange(key) {
+							exist[key] = true
+						}
+					case 2:
+						tr.Remove(key)
+						delete(exist, key)
+					default:
+						tr.Insert(key, key)
+						exist[key] = true
+					}
+				}
+				expectNavigable(t, view, filterKeys(exist, inRange), -8, 136)
+				expectNavigable(t, tr, sortedKeys(exist), -8, 136)
+
+				keys := filterKeys(exist, inRange)
+				if len(keys) > 0 {
+					key, _, ok := view.PollFirst()
+					assert.Equal(t, ok, true)
+					assert.Equal(t, key, keys[0])
+					delete(exist, key)
+				}
+				if len(keys) > 1 {
+					key, _, ok := view.PollLast()
+					assert.Equal(t, ok, true)
+					assert.Equal(t, key, keys[len(keys)-1])
+					delete(exist, key)
+				}
+				expectNavigable(t, tr, sortedKeys(exist), -8, 136)
+
+				view.Clean()
+				for key := range exist {
+					if inRange(key) {
+						delete(exist, key)
+					}
+				}
+				expectNavigable(t, view, nil, -8, 136)
+				expectNavigable(t, tr, sortedKeys(exist), -8, 136)
+			})
+		}
+	}
+}
+
+func TestHeadTailMap(t *testing.T) {
+	for name, newTree := range navigables {
+		tname, tr := name, newTree()
+		t.Run(fmt.Sprintf("[%s]", tname), func(t *testing.T) {
+			exist := map[int]bool{}
+			for i := 0; i < 256; i++ {
+				key := rand.Intn(128)
+				tr.Insert(key, key)
+				exist[key] = true
+			}
+			for i := 0; i < 16; i++ {
+				from, to := rand.Intn(140)-6, rand.Intn(140)-6
+				expectNavigable(t, tr.HeadMap(to), filterKeys(exist, func(key int) bool { return key < to }), -8, 136)
+				expectNavigable(t, tr.TailMap(from), filterKeys(exist, func(key int) bool { return key >= from }), -8, 136)
+
+				// 嵌套的视图为两个区间的交集
+				a, b := rand.Intn(140)-6, rand.Intn(140)-6
+				nested := tr.TailMap(from).HeadMap(to).SubMap(a, b, tree.Closed)
+				expectNavigable(t, nested, filterKeys(exist, func(key int) bool {
+					return key >= from && key < to && key >= a && key <= b
+				}), -8, 136)
+			}
+			head := tr.HeadMap(64)
+			_, _, ok := head.PollLast()
+			for ok {
+				_, _, ok = head.PollLast()
+			}
+			expectNavigable(t, tr, filterKeys(exist, func(key int) bool { return key >= 64 }), -8, 136)
+			tail := tr.TailMap(64)
+			_, _, ok = tail.PollLast()
+			for ok {
+				_, _, ok = tail.PollLast()
+			}
+			assert.Equal(t, tr.Size(), 0)
+		})
+	}
+}
+
+// 在[lo, hi]中逐个key比较nav与有序的keys
+func expectNavigable(t *testing.T, nav tree.Navigable[int, int], keys []int, lo, hi int) {
+	assert.Equal(t, nav.Size(), len(keys))
+	assert.Equal(t, nav.Empty(), len(keys) == 0)
+	expectKey := func(e tree.Element[int, int], idx int) {
+		if idx < 0 || idx >= len(keys) {
+			assert.Equal(t, e, nil)
+			return
+		}
+		assert.NotEqual(t, e, nil)
+		assert.Equal(t, e.Key(), keys[idx])
+		assert.Equal(t, e.Value(), keys[idx])
+	}
+	expectKey(nav.Left(), 0)
+	expectKey(nav.Right(), len(keys)-1)
+	for key := lo; key <= hi; key++ {
+		idx := sort.SearchInts(keys, key)
+		upper := sort.SearchInts(keys, key+1)
+		expectKey(nav.Floor(key), upper-1)
+		expectKey(nav.Ceiling(key), idx)
+		expectKey(nav.Lower(key), idx-1)
+		expectKey(nav.Higher(key), upper)
+		expectKey(nav.Prev(key), idx-1)
+		expectKey(nav.Next(key), upper)
+		if idx < upper {
+			expectKey(nav.Find(key), idx)
+		} else {
+			assert.Equal(t, nav.Find(key), nil)
+		}
+		_, ok := nav.Get(key)
+		assert.Equal(t, ok, idx < upper)
+		assert.Equal(t, nav.Rank(key), idx)
+	}
+	for k := -1; k <= len(keys); k++ {
+		expectKey(nav.Select(k), k)
+	}
+
+	var asc, desc []int
+	for e := nav.Left(); e != nil; e = e.Next() {
+		asc = append(asc, e.Key())
+	}
+	for e := nav.Right(); e != nil; e = e.Prev() {
+		desc = append(desc, e.Key())
+	}
+	expectKeys(t, asc, keys)
+	expectKeys(t, desc, reversed(keys))
+
+	asc, desc = nil, nil
+	nav.AscendGreaterOrEqual(lo, func(key, value int) bool {
+		asc = append(asc, key)
+		return true
+	})
+	nav.DescendLessOrEqual(hi, func(key, value int) bool {
+		desc = append(desc, key)
+		return true
+	})
+	expectKeys(t, asc, keys)
+	expectKeys(t, desc, reversed(keys))
+
+	a, b := lo+rand.Intn(hi-lo+1), lo+rand.Intn(hi-lo+1)
+	asc, desc = nil, nil
+	nav.AscendRange(a, b, func(key, value int) bool {
+		asc = append(asc, key)
+		return true
+	})
+	nav.DescendRange(a, b, func(key, value int) bool {
+		desc = append(desc, key)
+		return true
+	}, tree.Closed)
+	expectKeys(t, asc, keys[sort.SearchInts(keys, a):max(sort.SearchInts(keys, a), sort.SearchInts(keys, b))])
+	expectKeys(t, desc, reversed(keys[sort.SearchInts(keys, a):max(sort.SearchInts(keys, a), sort.SearchInts(keys, b+1))]))
+}
+
+func expectKeys(t *testing.T, got, wanted []int) {
+	assert.Equal(t, len(got), len(wanted))
+	for i := 0; i < len(got) && i < len(wanted); i++ {
+		assert.Equal(t, got[i], wanted[i])
+	}
+}
+
+func sortedKeys(exist map[int]bool) []int {
+	return filterKeys(exist, func(key int) bool { return true })
+}
+
+func filterKeys(exist map[int]bool, keep func(key int) bool) []int {
+	var keys []int
+	for key := range exist {
+		if keep(key) {
+			keys = append(keys, key)
+		}
+	}
+	sort.Ints(keys)
+	return keys
+}
+
+func reversed(keys []int) []int {
+	res := make([]int, 0, len(keys))
+	for i := len(keys) - 1; i >= 0; i-- {
+		res = append(res, keys[i])
+	}
+	return res
+}
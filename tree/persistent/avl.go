@@ -0,0 +1,100 @@
+// Copyright (c) 2025 Tian ChunXing. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package persistent
+
+import "github.com/mrtcx/plusdata/tree"
+
+// avl 路径复制的AVL树，h为高度，nil的高度为0
+type avl[K, V any] struct{}
+
+func height[K, V any](n *node[K, V]) int8 {
+	if n == nil {
+		return 0
+	}
+	return n.h
+}
+
+func (avl[K, V]) fix(n *node[K, V]) {
+	n.update()
+	n.h = max(height(n.lchild), height(n.rchild)) + 1
+}
+
+func (a avl[K, V]) insert(root *node[K, V], key K, value V, cmp tree.Comparator[K]) *node[K, V] {
+	if root == nil {
+		return &node[K, V]{key: key, value: value, size: 1, h: 1}
+	}
+	root = root.clone()
+	less := cmp(key, root.key)
+	if less == 0 {
+		root.value = value
+		return root
+	} else if less < 0 {
+		root.lchild = a.insert(root.lchild, key, value, cmp)
+	} else {
+		root.rchild = a.insert(root.rchild, key, value, cmp)
+	}
+	return a.maintain(root)
+}
+
+func (a avl[K, V]) remove(root *node[K, V], key K, cmp tree.Comparator[K]) *node[K, V] {
+	less := cmp(key, root.key)
+	if less == 0 && (root.lchild == nil || root.rchild == nil) {
+		if root.lchild == nil {
+			return root.rchild
+		}
+		return root.lchild
+	}
+	root = root.clone()
+	if less == 0 {
+		// 用后继替换后，从右子树中删除后继
+		succ := root.rchild
+		for succ.lchild != nil {
+			succ = succ.lchild
+		}
+		root.key, root.value = succ.key, succ.value
+		root.rchild = a.remove(root.rchild, succ.key, cmp)
+	} else if less < 0 {
+		root.lchild = a.remove(root.lchild, key, cmp)
+	} else {
+		root.rchild = a.remove(root.rchild, key, cmp)
+	}
+	return a.maintain(root)
+}
+
+// root已经复制，失衡一侧的子节点可能与旧版本共享，旋转前复制
+func (a avl[K, V]) maintain(root *node[K, V]) *node[K, V] {
+	diff := height(root.lchild) - height(root.rchild)
+	if diff > 1 {
+		if height(root.lchild.lchild) < height(root.lchild.rchild) {
+			root.lchild = a.leftRotate(root.lchild.clone())
+		}
+		return a.rightRotate(root)
+	} else if diff < -1 {
+		if height(root.rchild.rchild) < height(root.rchild.lchild) {
+			root.rchild = a.rightRotate(root.rchild.clone())
+		}
+		return a.leftRotate(root)
+	}
+	a.fix(root)
+	return root
+}
+
+func (a avl[K, V]) leftRotate(root *node[K, V]) *node[K, V] {
+	temp := root.rchild.clone()
+	root.rchild = temp.lchild
+	temp.lchild = root
+	a.fix(root)
+	a.fix(temp)
+	return temp
+}
+
+func (a avl[K, V]) rightRotate(root *node[K, V]) *node[K, V] {
+	temp := root.lchild.clone()
+	root.lchild = temp.rchild
+	temp.rchild = root
+	a.fix(root)
+	a.fix(temp)
+	return temp
+}
@@ -0,0 +1,21 @@
+// Copyright (c) 2025 Tian ChunXing. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package persistent
+
+import (
+	"testing"
+
+	"github.com/mrtcx/plusdata/internal/treetest"
+	"github.com/mrtcx/plusdata/tree"
+)
+
+func TestConformance(t *testing.T) {
+	t.Run("avl", func(t *testing.T) {
+		treetest.Run(t, func() tree.Tree[int, int] { return NewAVL[int, int](intcmp) })
+	})
+	t.Run("rb", func(t *testing.T) {
+		treetest.Run(t, func() tree.Tree[int, int] { return NewRB[int, int](intcmp) })
+	})
+}
@@ -0,0 +1,65 @@
+// Copyright (c) 2025 Tian ChunXing. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package persistent
+
+import "github.com/mrtcx/plusdata/tree"
+
+// element 可变树的元素按key在树的当前版本中查找value和前驱后继，树修改后仍然有效；
+// 其他版本和快照的元素固定在所属的版本
+type element[K, V any] struct {
+	v    *Version[K, V]
+	node *node[K, V]
+	rank int
+}
+
+func (v *Version[K, V]) element(node *node[K, V], rank int) tree.Element[K, V] {
+	if node == nil {
+		return nil
+	}
+	return &element[K, V]{v: v, node: node, rank: rank}
+}
+
+func (e *element[K, V]) Key() K {
+	return e.node.key
+}
+
+// Value 可变树中key已被删除时返回最后一次读取或设置的value
+func (e *element[K, V]) Value() V {
+	if e.v.owner != nil {
+		e.refresh()
+	}
+	return e.node.value
+}
+
+// 在可变树的当前版本中重新查找节点，key已被删除时保留原来的节点
+func (e *element[K, V]) refresh() {
+	if node, _ := e.v.owner.cur.findNode(e.node.key); node != nil {
+		e.node = node
+	}
+}
+
+// SetValue 节点不可修改，可变树的元素通过Insert生成新版本，其他版本和快照的元素会panic
+func (e *element[K, V]) SetValue(value V) {
+	if e.v.owner == nil {
+		panic("persistent: version is read-only")
+	}
+	e.v.owner.Insert(e.node.key, value)
+	e.refresh()
+}
+
+// Next 可变树中为当前版本中大于key的最小元素，其他版本中按rank取所在版本的后继
+func (e *element[K, V]) Next() tree.Element[K, V] {
+	if e.v.owner != nil {
+		return e.v.owner.cur.Next(e.node.key)
+	}
+	return e.v.Select(e.rank + 1)
+}
+
+func (e *element[K, V]) Prev() tree.Element[K, V] {
+	if e.v.owner != nil {
+		return e.v.owner.cur.Prev(e.node.key)
+	}
+	return e.v.Select(e.rank - 1)
+}
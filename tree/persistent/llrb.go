@@ -0,0 +1,161 @@
+// Copyright (c) 2025 Tian ChunXing. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package persistent
+
+import "github.com/mrtcx/plusdata/tree"
+
+// llrb 路径复制的左倾红黑树，红色节点只能是左孩子，nil为黑色。
+// rbtree删除时在共享的nilNode上记录双黑，持久化后多个版本会同时修改它，所以这里用左倾红黑树，
+// 删除时自顶向下把红色推到要删除的一侧，不需要nil哨兵
+type llrb[K, V any] struct{}
+
+const (
+	black int8 = 0
+	red   int8 = 1
+)
+
+func isRed[K, V any](n *node[K, V]) bool {
+	return n != nil && n.h == red
+}
+
+func (rb llrb[K, V]) insert(root *node[K, V], key K, value V, cmp tree.Comparator[K]) *node[K, V] {
+	root = rb.insertAt(root, key, value, cmp)
+	root.h = black
+	return root
+}
+
+func (rb llrb[K, V]) insertAt(root *node[K, V], key K, value V, cmp tree.Comparator[K]) *node[K, V] {
+	if root == nil {
+		return &node[K, V]{key: key, value: value, size: 1, h: red}
+	}
+	root = root.clone()
+	less := cmp(key, root.key)
+	if less == 0 {
+		root.value = value
+		return root
+	} else if less < 0 {
+		root.lchild = rb.insertAt(root.lchild, key, value, cmp)
+	} else {
+		root.rchild = rb.insertAt(root.rchild, key, value, cmp)
+	}
+	return rb.fixUp(root)
+}
+
+func (rb llrb[K, V]) remove(root *node[K, V], key K, cmp tree.Comparator[K]) *node[K, V] {
+	root = root.clone()
+	if !isRed(root.lchild) && !isRed(root.rchild) {
+		root.h = red
+	}
+	root = rb.removeAt(root, key, cmp)
+	if root != nil {
+		root.h = black
+	}
+	return root
+}
+
+// root已经复制，返回的根也是新节点
+func (rb llrb[K, V]) removeAt(root *node[K, V], key K, cmp tree.Comparator[K]) *node[K, V] {
+	if cmp(key, root.key) < 0 {
+		if !isRed(root.lchild) && !isRed(root.lchild.lchild) {
+			root = rb.moveRedLeft(root)
+		}
+		root.lchild = rb.removeAt(root.lchild.clone(), key, cmp)
+	} else {
+		if isRed(root.lchild) {
+			root = rb.rightRota(root)
+		}
+		if cmp(key, root.key) == 0 && root.rchild == nil {
+			return nil
+		}
+		if !isRed(root.rchild) && !isRed(root.rchild.lchild) {
+			root = rb.moveRedRight(root)
+		}
+		if cmp(key, root.key) == 0 {
+			succ := root.rchild
+			for succ.lchild != nil {
+				succ = succ.lchild
+			}
+			root.key, root.value = succ.key, succ.value
+			root.rchild = rb.removeMin(root.rchild.clone())
+		} else {
+			root.rchild = rb.removeAt(root.rchild.clone(), key, cmp)
+		}
+	}
+	return rb.fixUp(root)
+}
+
+func (rb llrb[K, V]) removeMin(root *node[K, V]) *node[K, V] {
+	if root.lchild == nil {
+		return nil
+	}
+	if !isRed(root.lchild) && !isRed(root.lchild.lchild) {
+		root = rb.moveRedLeft(root)
+	}
+	root.lchild = rb.removeMin(root.lchild.clone())
+	return rb.fixUp(root)
+}
+
+// 修复右倾的红链接和连续的红色左链接，左右孩子都为红色时分裂
+func (rb llrb[K, V]) fixUp(root *node[K, V]) *node[K, V] {
+	if isRed(root.rchild) && !isRed(root.lchild) {
+		root = rb.leftRota(root)
+	}
+	if isRed(root.lchild) && isRed(root.lchild.lchild) {
+		root = rb.rightRota(root)
+	}
+	if isRed(root.lchild) && isRed(root.rchild) {
+		rb.flip(root)
+	}
+	root.update()
+	return root
+}
+
+// 把红色从root或右孩子借给左孩子
+func (rb llrb[K, V]) moveRedLeft(root *node[K, V]) *node[K, V] {
+	rb.flip(root)
+	if isRed(root.rchild.lchild) {
+		root.rchild = rb.rightRota(root.rchild)
+		root = rb.leftRota(root)
+		rb.flip(root)
+	}
+	return root
+}
+
+func (rb llrb[K, V]) moveRedRight(root *node[K, V]) *node[K, V] {
+	rb.flip(root)
+	if isRed(root.lchild.lchild) {
+		root = rb.rightRota(root)
+		rb.flip(root)
+	}
+	return root
+}
+
+// 翻转root和两个孩子的颜色，孩子复制后再修改
+func (llrb[K, V]) flip(root *node[K, V]) {
+	root.lchild, root.rchild = root.lchild.clone(), root.rchild.clone()
+	root.h ^= red
+	root.lchild.h ^= red
+	root.rchild.h ^= red
+}
+
+func (llrb[K, V]) leftRota(root *node[K, V]) *node[K, V] {
+	temp := root.rchild.clone()
+	root.rchild = temp.lchild
+	temp.lchild = root
+	temp.h, root.h = root.h, red
+	root.update()
+	temp.update()
+	return temp
+}
+
+func (llrb[K, V]) rightRota(root *node[K, V]) *node[K, V] {
+	temp := root.lchild.clone()
+	root.lchild = temp.rchild
+	temp.rchild = root
+	temp.h, root.h = root.h, red
+	root.update()
+	temp.update()
+	return temp
+}
@@ -0,0 +1,96 @@
+// Copyright (c) 2025 Tian ChunXing. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+// Package persistent 路径复制的持久化AVL树和红黑树
+//
+// Version为不可变的树，Insert和Remove只复制根到修改位置路径上的O(logN)个节点，返回新的版本，
+// 其余节点与旧版本共享。节点发布后不再修改，任意版本都可以不加锁地在多个goroutine中读取。
+// Tree是基于Version的可变树，实现tree.Tree，Snapshot以O(1)返回当前版本的只读视图。
+package persistent
+
+import "github.com/mrtcx/plusdata/tree"
+
+type node[K, V any] struct {
+	key            K
+	value          V
+	lchild, rchild *node[K, V]
+	size           int  //子树中节点的数量
+	h              int8 //AVL树中为高度，红黑树中为颜色
+}
+
+// 修改节点前先复制，新节点只属于当前正在生成的版本，可以直接修改
+func (n *node[K, V]) clone() *node[K, V] {
+	c := *n
+	return &c
+}
+
+func (n *node[K, V]) update() {
+	n.size = sizeOf(n.lchild) + sizeOf(n.rchild) + 1
+}
+
+func sizeOf[K, V any](n *node[K, V]) int {
+	if n == nil {
+		return 0
+	}
+	return n.size
+}
+
+// balancer 平衡的策略，返回新的根，不修改参数中的任何节点
+type balancer[K, V any] interface {
+	insert(root *node[K, V], key K, value V, cmp tree.Comparator[K]) *node[K, V]
+	remove(root *node[K, V], key K, cmp tree.Comparator[K]) *node[K, V] //key一定存在
+}
+
+// Version 不可变的树，零值不可用，通过EmptyAVL或EmptyRB创建
+type Version[K, V any] struct {
+	root  *node[K, V]
+	cmp   tree.Comparator[K]
+	bal   balancer[K, V]
+	owner *Tree[K, V] //可变树的当前版本指向所属的树，元素的SetValue通过它写入；其他版本为nil
+}
+
+// EmptyAVL 空的持久化AVL树
+func EmptyAVL[K, V any](cmp tree.Comparator[K]) *Version[K, V] {
+	return &Version[K, V]{cmp: cmp, bal: avl[K, V]{}}
+}
+
+// EmptyRB 空的持久化红黑树(左倾红黑树)
+func EmptyRB[K, V any](cmp tree.Comparator[K]) *Version[K, V] {
+	return &Version[K, V]{cmp: cmp, bal: llrb[K, V]{}}
+}
+
+func (v *Version[K, V]) derive(root *node[K, V]) *Version[K, V] {
+	return &Version[K, V]{root: root, cmp: v.cmp, bal: v.bal, owner: v.owner}
+}
+
+// Insert 返回插入后的新版本，key存在时替换value，v本身不变
+func (v *Version[K, V]) Insert(key K, value V) *Version[K, V] {
+	return v.derive(v.bal.insert(v.root, key, value, v.cmp))
+}
+
+// Remove 返回删除后的新版本，key不存在时返回v
+func (v *Version[K, V]) Remove(key K) *Version[K, V] {
+	if node, _ := v.findNode(key); node == nil {
+		return v
+	}
+	return v.derive(v.bal.remove(v.root, key, v.cmp))
+}
+
+// Clean 返回平衡方式相同的空版本
+func (v *Version[K, V]) Clean() *Version[K, V] {
+	return v.derive(nil)
+}
+
+func (v *Version[K, V]) Size() int {
+	return sizeOf(v.root)
+}
+
+func (v *Version[K, V]) Empty() bool {
+	return v.root == nil
+}
+
+// Frozen 以tree.Tree的只读视图访问这个版本
+func (v *Version[K, V]) Frozen() tree.Tree[K, V] {
+	return &frozen[K, V]{Version: &Version[K, V]{root: v.root, cmp: v.cmp, bal: v.bal}}
+}
@@ -0,0 +1,311 @@
+// Copyright (c) 2025 Tian ChunXing. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package persistent
+
+import (
+	"fmt"
+	"math/rand"
+	"sort"
+	"sync"
+	"testing"
+
+	"github.com/mrtcx/plusdata/internal/assert"
+	"github.com/mrtcx/plusdata/tree"
+)
+
+var intcmp = tree.OrderedComparator[int]
+
+var empties = map[string]func() *Version[int, int]{
+	"avl": func() *Version[int, int] { return EmptyAVL[int, int](intcmp) },
+	"rb":  func() *Version[int, int] { return EmptyRB[int, int](intcmp) },
+}
+
+func TestBalance(t *testing.T) {
+	nums := []int{1, 2, 8, 1024}
+	for name, empty := range empties {
+		for _, num := range nums {
+			tname, tnum, v := name, num, empty()
+			t.Run(fmt.Sprintf("[%s][num:%d]", tname, tnum), func(t *testing.T) {
+				for i := 0; i < tnum; i++ {
+					v = v.Insert(i, i)
+					checkVersion(t, v)
+				}
+				for i := 0; i < 4*tnum; i++ {
+					key := rand.Intn(2 * tnum)
+					if rand.Intn(2) == 0 {
+						v = v.Remove(key)
+					} else {
+						v = v.Insert(key, key)
+					}
+					checkVersion(t, v)
+				}
+				for i := 0; i < 2*tnum; i++ {
+					v = v.Remove(i)
+					checkVersion(t, v)
+				}
+				assert.Equal(t, v.Empty(), true)
+			})
+		}
+	}
+}
+
+// 每次修改后保存版本，最后逐个检查旧版本的内容没有变化
+func TestPersistence(t *testing.T) {
+	for name, empty := range empties {
+		tname, v := name, empty()
+		t.Run(fmt.Sprintf("[%s]", tname), func(t *testing.T) {
+			versions := []*Version[int, int]{v}
+			exists := []map[int]int{{}}
+			for i := 0; i < 512; i++ {
+				key := rand.Intn(128)
+				exist := map[int]int{}
+				for k, val := range exists[len(exists)-1] {
+					exist[k] = val
+				}
+				if rand.Intn(3) == 0 {
+					v = v.Remove(key)
+					delete(exist, key)
+				} else {
+					v = v.Insert(key, i)
+					exist[key] = i
+				}
+				versions = append(versions, v)
+				exists = append(exists, exist)
+			}
+			for i := range versions {
+				expectVersion(t, versions[i], exists[i])
+			}
+		})
+	}
+}
+
+func TestRemoveMissing(t *testing.T) {
+	for _, empty := range empties {
+		v := empty().Insert(1, 1).Insert(3, 3)
+		assert.Equal(t, v.Remove(2), v)
+		assert.Equal(t, v.Remove(1).Size(), 1)
+		assert.Equal(t, v.Size(), 2)
+		assert.Equal(t, v.Clean().Empty(), true)
+	}
+}
+
+func TestSnapshot(t *testing.T) {
+	for name, newTree := range map[string]func() *Tree[int, int]{
+		"avl": func() *Tree[int, int] { return NewAVL[int, int](intcmp) },
+		"rb":  func() *Tree[int, int] { return NewRB[int, int](intcmp) },
+	} {
+		tname, tr := name, newTree()
+		t.Run(fmt.Sprintf("[%s]", tname), func(t *testing.T) {
+			exist := map[int]int{}
+			for i := 0; i < 256; i++ {
+				key := rand.Intn(128)
+				tr.Insert(key, i)
+				exist[key] = i
+			}
+			snap, frozenExist := tr.Snapshot(), map[int]int{}
+			for k, val := range exist {
+				frozenExist[k] = val
+			}
+			version := tr.Version()
+
+			for i := 0; i < 256; i++ {
+				key := rand.Intn(128)
+				switch rand.Intn(3) {
+				case 0:
+					tr.Remove(key)
+					delete(exist, key)
+				case 1:
+					if e := tr.Find(key); e != nil {
+						e.SetValue(-i)
+						exist[key] = -i
+					}
+				default:
+					tr.Insert(key, i)
+					exist[key] = i
+				}
+			}
+			expectTree(t, tr, exist)
+			expectTree(t, snap, frozenExist)
+			expectVersion(t, version, frozenExist)
+			expectVersion(t, version.Insert(1000, 1000).Remove(1000), frozenExist)
+
+			tr.Clean()
+			assert.Equal(t, tr.Empty(), true)
+			expectTree(t, snap, frozenExist)
+
+			expectPanic(t, func() { snap.Insert(1, 1) })
+			expectPanic(t, func() { snap.Remove(1) })
+			expectPanic(t, func() { snap.Clean() })
+			if e := snap.Left(); e != nil {
+				expectPanic(t, func() { e.SetValue(0) })
+			}
+		})
+	}
+}
+
+// 写者不断修改并发布快照，读者不加锁地遍历快照，go test -race检查数据竞争
+func TestConcurrentSnapshot(t *testing.T) {
+	tr := NewRB[int, int](intcmp)
+	snaps := make(chan tree.Tree[int, int], 16)
+	var wg sync.WaitGroup
+	for r := 0; r < 4; r++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for snap := range snaps {
+				cnt, prev := 0, -1
+				snap.AscendGreaterOrEqual(0, func(key, value int) bool {
+					assert.Greater(t, key, prev)
+					assert.Equal(t, value, key)
+					cnt, prev = cnt+1, key
+					return true
+				})
+				assert.Equal(t, cnt, snap.Size())
+			}
+		}()
+	}
+	for i := 0; i < 2048; i++ {
+		key := rand.Intn(256)
+		if rand.Intn(3) == 0 {
+			tr.Remove(key)
+		} else {
+			tr.Insert(key, key)
+		}
+		if i%64 == 0 {
+			snaps <- tr.Snapshot()
+		}
+	}
+	close(snaps)
+	wg.Wait()
+}
+
+func expectPanic(t *testing.T, fn func()) {
+	defer func() {
+		assert.Equal(t, recover() != nil, true)
+	}()
+	fn()
+}
+
+func expectVersion(t *testing.T, v *Version[int, int], exist map[int]int) {
+	checkVersion(t, v)
+	expectTree(t, v.Frozen(), exist)
+}
+
+func expectTree(t *testing.T, tr tree.Tree[int, int], exist map[int]int) {
+	keys := make([]int, 0, len(exist))
+	for key := range exist {
+		keys = append(keys, key)
+	}
+	sort.Ints(keys)
+	assert.Equal(t, tr.Size(), len(keys))
+	i := 0
+	for e := tr.Left(); e != nil; e = e.Next() {
+		assert.Equal(t, e.Key(), keys[i])
+		assert.Equal(t, e.Value(), exist[keys[i]])
+		i++
+	}
+	assert.Equal(t, i, len(keys))
+	for e := tr.Right(); e != nil; e = e.Prev() {
+		i--
+		assert.Equal(t, e.Key(), keys[i])
+	}
+	for key := -1; key <= 130; key++ {
+		idx := sort.SearchInts(keys, key)
+		assert.Equal(t, tr.Rank(key), idx)
+		value, ok := tr.Get(key)
+		assert.Equal(t, ok, idx < len(keys) && keys[idx] == key)
+		assert.Equal(t, value, exist[key])
+		if prev := tr.Prev(key); idx > 0 {
+			assert.Equal(t, prev.Key(), keys[idx-1])
+		} else {
+			assert.Equal(t, prev, nil)
+		}
+	}
+	lo, hi := rand.Intn(130), rand.Intn(130)
+	var got []int
+	tr.AscendRange(lo, hi, func(key, value int) bool {
+		got = append(got, key)
+		return true
+	}, tree.Closed)
+	tr.DescendRange(lo, hi, func(key, value int) bool {
+		got = append(got, key)
+		return true
+	})
+	var wanted []int
+	for _, key := range keys {
+		if key >= lo && key <= hi {
+			wanted = append(wanted, key)
+		}
+	}
+	for i := len(keys) - 1; i >= 0; i-- {
+		if keys[i] >= lo && keys[i] < hi {
+			wanted = append(wanted, keys[i])
+		}
+	}
+	assert.Equal(t, len(got), len(wanted))
+	for i := range got {
+		assert.Equal(t, got[i], wanted[i])
+	}
+}
+
+// 检查顺序、子树大小以及AVL的高度差或左倾红黑树的性质
+func checkVersion(t *testing.T, v *Version[int, int]) {
+	_, isAVL := v.bal.(avl[int, int])
+	var check func(root *node[int, int], lo, hi *int) int
+	check = func(root *node[int, int], lo, hi *int) int {
+		if root == nil {
+			return 0
+		}
+		if lo != nil {
+			assert.Greater(t, root.key, *lo)
+		}
+		if hi != nil {
+			assert.Greater(t, *hi, root.key)
+		}
+		l, r := check(root.lchild, lo, &root.key), check(root.rchild, &root.key, hi)
+		assert.Equal(t, root.size, sizeOf(root.lchild)+sizeOf(root.rchild)+1)
+		if isAVL {
+			assert.Equal(t, l-r <= 1 && r-l <= 1, true)
+			assert.Equal(t, int(root.h), max(l, r)+1)
+			return max(l, r) + 1
+		}
+		// 左右黑高相同，右孩子不是红色，没有连续的红色
+		assert.Equal(t, l, r)
+		assert.Equal(t, isRed(root.rchild), false)
+		assert.Equal(t, isRed(root) && isRed(root.lchild), false)
+		if isRed(root) {
+			return l
+		}
+		return l + 1
+	}
+	check(v.root, nil, nil)
+	assert.Equal(t, !isAVL && isRed(v.root), false)
+}
+
+// 可变树的元素在树修改后按key读取当前的value和前驱后继，快照的元素不受影响
+func TestElementAfterModify(t *testing.T) {
+	tr := NewAVL[int, int](intcmp)
+	for key := 10; key <= 50; key += 10 {
+		tr.Insert(key, key)
+	}
+	snap := tr.Snapshot()
+	e, se := tr.Find(30), snap.Find(30)
+	e.SetValue(31)
+	assert.Equal(t, e.Value(), 31)
+	assert.Equal(t, se.Value(), 30)
+	tr.Insert(5, 5)
+	assert.Equal(t, e.Next().Key(), 40)
+	assert.Equal(t, e.Prev().Key(), 20)
+	tr.Remove(20)
+	tr.Remove(40)
+	assert.Equal(t, e.Next().Key(), 50)
+	assert.Equal(t, e.Prev().Key(), 10)
+	assert.Equal(t, e.Next().Next(), nil)
+	assert.Equal(t, e.Prev().Prev().Key(), 5)
+	assert.Equal(t, se.Next().Key(), 40)
+	assert.Equal(t, se.Prev().Key(), 20)
+	tr.Remove(30)
+	assert.Equal(t, e.Value(), 31)
+}
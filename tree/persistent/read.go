@@ -0,0 +1,165 @@
+// Copyright (c) 2025 Tian ChunXing. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package persistent
+
+import "github.com/mrtcx/plusdata/tree"
+
+func (v *Version[K, V]) Get(key K) (V, bool) {
+	if node, _ := v.findNode(key); node != nil {
+		return node.value, true
+	}
+	var zero V
+	return zero, false
+}
+
+func (v *Version[K, V]) Find(key K) tree.Element[K, V] {
+	return v.element(v.findNode(key))
+}
+
+func (v *Version[K, V]) Left() tree.Element[K, V] {
+	return v.Select(0)
+}
+
+func (v *Version[K, V]) Right() tree.Element[K, V] {
+	return v.Select(v.Size() - 1)
+}
+
+// Prev 小于key的最大元素
+func (v *Version[K, V]) Prev(key K) tree.Element[K, V] {
+	return v.Select(v.rank(key, false) - 1)
+}
+
+// Next 大于key的最小元素
+func (v *Version[K, V]) Next(key K) tree.Element[K, V] {
+	return v.Select(v.rank(key, true))
+}
+
+// Rank 返回小于key的元素个数
+func (v *Version[K, V]) Rank(key K) int {
+	return v.rank(key, false)
+}
+
+// Select 返回顺序遍历中下标为k的元素(从0开始)，k越界时返回nil
+func (v *Version[K, V]) Select(k int) tree.Element[K, V] {
+	if k < 0 || k >= v.Size() {
+		return nil
+	}
+	rank, root := k, v.root
+	for k != sizeOf(root.lchild) {
+		if k < sizeOf(root.lchild) {
+			root = root.lchild
+		} else {
+			k -= sizeOf(root.lchild) + 1
+			root = root.rchild
+		}
+	}
+	return v.element(root, rank)
+}
+
+func (v *Version[K, V]) findNode(key K) (*node[K, V], int) {
+	rank := 0
+	for root := v.root; root != nil; {
+		less := v.cmp(key, root.key)
+		if less == 0 {
+			return root, rank + sizeOf(root.lchild)
+		} else if less < 0 {
+			root = root.lchild
+		} else {
+			rank += sizeOf(root.lchild) + 1
+			root = root.rchild
+		}
+	}
+	return nil, 0
+}
+
+// 小于key(upper为true时小于等于key)的元素个数
+func (v *Version[K, V]) rank(key K, upper bool) int {
+	rank := 0
+	for root := v.root; root != nil; {
+		less := v.cmp(key, root.key)
+		if less < 0 || less == 0 && !upper {
+			root = root.lchild
+		} else {
+			rank += sizeOf(root.lchild) + 1
+			root = root.rchild
+		}
+	}
+	return rank
+}
+
+// AscendRange 正序遍历区间[lo, hi)内的元素, fn返回false时停止, bound可指定区间开闭
+func (v *Version[K, V]) AscendRange(lo, hi K, fn func(key K, value V) bool, bound ...tree.Bound) {
+	b := tree.RangeBound(bound)
+	v.ascend(lo, b.LoInclusive(), func(key K) bool {
+		less := v.cmp(key, hi)
+		return less < 0 || less == 0 && b.HiInclusive()
+	}, fn)
+}
+
+// DescendRange 倒序遍历区间[lo, hi)内的元素, fn返回false时停止, bound可指定区间开闭
+func (v *Version[K, V]) DescendRange(lo, hi K, fn func(key K, value V) bool, bound ...tree.Bound) {
+	b := tree.RangeBound(bound)
+	v.descend(hi, b.HiInclusive(), func(key K) bool {
+		less := v.cmp(key, lo)
+		return less > 0 || less == 0 && b.LoInclusive()
+	}, fn)
+}
+
+// AscendGreaterOrEqual 正序遍历key>=lo的元素
+func (v *Version[K, V]) AscendGreaterOrEqual(lo K, fn func(key K, value V) bool) {
+	v.ascend(lo, true, nil, fn)
+}
+
+// DescendLessOrEqual 倒序遍历key<=hi的元素
+func (v *Version[K, V]) DescendLessOrEqual(hi K, fn func(key K, value V) bool) {
+	v.descend(hi, true, nil, fn)
+}
+
+// 栈中保存还未访问的左父节点，出栈后把右子树的左链入栈，整体O(logN + k)
+func (v *Version[K, V]) ascend(lo K, include bool, inRange func(key K) bool, fn func(key K, value V) bool) {
+	stack := make([]*node[K, V], 0, 64)
+	for root := v.root; root != nil; {
+		less := v.cmp(lo, root.key)
+		if less < 0 || less == 0 && include {
+			stack = append(stack, root)
+			root = root.lchild
+		} else {
+			root = root.rchild
+		}
+	}
+	for len(stack) > 0 {
+		node := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		if inRange != nil && !inRange(node.key) || !fn(node.key, node.value) {
+			return
+		}
+		for root := node.rchild; root != nil; root = root.lchild {
+			stack = append(stack, root)
+		}
+	}
+}
+
+func (v *Version[K, V]) descend(hi K, include bool, inRange func(key K) bool, fn func(key K, value V) bool) {
+	stack := make([]*node[K, V], 0, 64)
+	for root := v.root; root != nil; {
+		less := v.cmp(hi, root.key)
+		if less > 0 || less == 0 && include {
+			stack = append(stack, root)
+			root = root.rchild
+		} else {
+			root = root.lchild
+		}
+	}
+	for len(stack) > 0 {
+		node := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		if inRange != nil && !inRange(node.key) || !fn(node.key, node.value) {
+			return
+		}
+		for root := node.lchild; root != nil; root = root.rchild {
+			stack = append(stack, root)
+		}
+	}
+}
@@ -0,0 +1,129 @@
+// Copyright (c) 2025 Tian ChunXing. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package persistent
+
+import "github.com/mrtcx/plusdata/tree"
+
+var (
+	_ tree.Tree[int, int] = (*Tree[int, int])(nil)
+	_ tree.Tree[int, int] = (*frozen[int, int])(nil)
+)
+
+// Tree 基于Version的可变树，每次修改替换当前版本，Snapshot不需要复制节点。
+// Tree本身只能由一个goroutine修改，快照可以交给其他goroutine读取
+type Tree[K, V any] struct {
+	cur *Version[K, V]
+}
+
+// NewAVL 持久化AVL树实现的可变树
+func NewAVL[K, V any](cmp tree.Comparator[K]) *Tree[K, V] {
+	return newTree(EmptyAVL[K, V](cmp))
+}
+
+// NewRB 持久化红黑树实现的可变树
+func NewRB[K, V any](cmp tree.Comparator[K]) *Tree[K, V] {
+	return newTree(EmptyRB[K, V](cmp))
+}
+
+func newTree[K, V any](v *Version[K, V]) *Tree[K, V] {
+	t := &Tree[K, V]{}
+	t.cur = &Version[K, V]{root: v.root, cmp: v.cmp, bal: v.bal, owner: t}
+	return t
+}
+
+// Snapshot O(1)返回当前版本的只读视图，之后对t的修改不影响快照
+func (t *Tree[K, V]) Snapshot() tree.Tree[K, V] {
+	return t.cur.Frozen()
+}
+
+// Version 返回当前的不可变版本，可以在它的基础上继续生成新版本
+func (t *Tree[K, V]) Version() *Version[K, V] {
+	return &Version[K, V]{root: t.cur.root, cmp: t.cur.cmp, bal: t.cur.bal}
+}
+
+func (t *Tree[K, V]) Size() int {
+	return t.cur.Size()
+}
+
+func (t *Tree[K, V]) Empty() bool {
+	return t.cur.Empty()
+}
+
+func (t *Tree[K, V]) Clean() {
+	t.cur = t.cur.Clean()
+}
+
+func (t *Tree[K, V]) Insert(key K, value V) {
+	t.cur = t.cur.Insert(key, value)
+}
+
+func (t *Tree[K, V]) Remove(key K) {
+	t.cur = t.cur.Remove(key)
+}
+
+func (t *Tree[K, V]) Get(key K) (V, bool) {
+	return t.cur.Get(key)
+}
+
+func (t *Tree[K, V]) Find(key K) tree.Element[K, V] {
+	return t.cur.Find(key)
+}
+
+func (t *Tree[K, V]) Left() tree.Element[K, V] {
+	return t.cur.Left()
+}
+
+func (t *Tree[K, V]) Right() tree.Element[K, V] {
+	return t.cur.Right()
+}
+
+func (t *Tree[K, V]) Prev(key K) tree.Element[K, V] {
+	return t.cur.Prev(key)
+}
+
+func (t *Tree[K, V]) Next(key K) tree.Element[K, V] {
+	return t.cur.Next(key)
+}
+
+func (t *Tree[K, V]) AscendRange(lo, hi K, fn func(key K, value V) bool, bound ...tree.Bound) {
+	t.cur.AscendRange(lo, hi, fn, bound...)
+}
+
+func (t *Tree[K, V]) DescendRange(lo, hi K, fn func(key K, value V) bool, bound ...tree.Bound) {
+	t.cur.DescendRange(lo, hi, fn, bound...)
+}
+
+func (t *Tree[K, V]) AscendGreaterOrEqual(lo K, fn func(key K, value V) bool) {
+	t.cur.AscendGreaterOrEqual(lo, fn)
+}
+
+func (t *Tree[K, V]) DescendLessOrEqual(hi K, fn func(key K, value V) bool) {
+	t.cur.DescendLessOrEqual(hi, fn)
+}
+
+func (t *Tree[K, V]) Rank(key K) int {
+	return t.cur.Rank(key)
+}
+
+func (t *Tree[K, V]) Select(k int) tree.Element[K, V] {
+	return t.cur.Select(k)
+}
+
+// frozen 版本的只读视图，读操作来自Version，修改操作会panic
+type frozen[K, V any] struct {
+	*Version[K, V]
+}
+
+func (f *frozen[K, V]) Clean() {
+	panic("persistent: snapshot is read-only")
+}
+
+func (f *frozen[K, V]) Insert(key K, value V) {
+	panic("persistent: snapshot is read-only")
+}
+
+func (f *frozen[K, V]) Remove(key K) {
+	panic("persistent: snapshot is read-only")
+}
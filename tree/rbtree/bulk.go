@@ -0,0 +1,43 @@
+// Copyright (c) 2025 Tian ChunXing. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package rbtree
+
+import (
+	"math/bits"
+
+	"github.com/mrtcx/plusdata/tree"
+)
+
+// BulkLoad 由严格递增的keys、values直接构建红黑树，O(N)
+// 以中点为根递归构建，左右子树大小最多相差1，叶子只在最深的两层，最深一层的节点为红色，其余为黑色
+func BulkLoad[K, V any](cmp tree.Comparator[K], keys []K, values []V) (*rbTree[K, V], error) {
+	if len(keys) != len(values) {
+		return nil, tree.ErrLengthMismatch
+	}
+	for i := 1; i < len(keys); i++ {
+		if cmp(keys[i-1], keys[i]) >= 0 {
+			return nil, tree.ErrNotSorted
+		}
+	}
+	rb := New[K, V](cmp)
+	rb.root = rb.build(keys, values, 0, bits.Len(uint(len(keys)))-1)
+	rb.size = len(keys)
+	return rb, nil
+}
+
+func (rb *rbTree[K, V]) build(keys []K, values []V, depth, maxDepth int) *Node[K, V] {
+	if len(keys) == 0 {
+		return rb.nilNode
+	}
+	mid := len(keys) / 2
+	root := rb.newNode(keys[mid], values[mid])
+	root.lchild = rb.build(keys[:mid], values[:mid], depth+1, maxDepth)
+	root.rchild = rb.build(keys[mid+1:], values[mid+1:], depth+1, maxDepth)
+	if depth < maxDepth || depth == 0 {
+		root.color = black
+	}
+	rb.update(root)
+	return root
+}
@@ -0,0 +1,55 @@
+// Copyright (c) 2025 Tian ChunXing. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package rbtree
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/mrtcx/plusdata/internal/assert"
+	"github.com/mrtcx/plusdata/tree"
+)
+
+func TestBulkLoad(t *testing.T) {
+	nums := []int{0, 1, 2, 3, 4, 5, 7, 8, 9, 17, 100, 1023, 1024, 1024 + 1}
+	for _, num := range nums {
+		tnum := num
+		t.Run(fmt.Sprintf("[num:%d]", tnum), func(t *testing.T) {
+			keys, values := make([]int, tnum), make([]int, tnum)
+			for i := range keys {
+				keys[i], values[i] = 2*i+1, 2*i+1
+			}
+			rb, err := BulkLoad(intcmp, keys, values)
+			assert.Equal(t, err, nil)
+			assert.Equal(t, rb.Size(), tnum)
+			assert.Equal(t, rb.root.color, black)
+			assert.Equal(t, checkColorBalance(t, rb, rb.root), true)
+			assert.Equal(t, checkOrder(t, rb, rb.root), true)
+			for i, key := range keys {
+				assert.Equal(t, rb.Rank(key), i)
+				assert.Equal(t, rb.Select(i).Key(), key)
+			}
+			// 构建完成后可以继续插入和删除
+			for i := 0; i <= 2*tnum; i += 2 {
+				rb.Insert(i, i)
+			}
+			assert.Equal(t, checkColorBalance(t, rb, rb.root), true)
+			for i := 0; i <= 2*tnum; i += 3 {
+				rb.Remove(i)
+			}
+			assert.Equal(t, checkColorBalance(t, rb, rb.root), true)
+			assert.Equal(t, checkOrder(t, rb, rb.root), true)
+		})
+	}
+}
+
+func TestBulkLoadError(t *testing.T) {
+	_, err := BulkLoad(intcmp, []int{1, 3, 2}, []int{1, 3, 2})
+	assert.Equal(t, err, tree.ErrNotSorted)
+	_, err = BulkLoad(intcmp, []int{1, 2, 2}, []int{1, 2, 2})
+	assert.Equal(t, err, tree.ErrNotSorted)
+	_, err = BulkLoad(intcmp, []int{1, 2}, []int{1})
+	assert.Equal(t, err, tree.ErrLengthMismatch)
+}
@@ -6,67 +6,77 @@ package rbtree
 
 import "github.com/mrtcx/plusdata/tree"
 
-type element struct {
-	rb   *rbTree
-	node *Node
+type element[K, V any] struct {
+	rb   *rbTree[K, V]
+	node *Node[K, V]
 }
 
-func (e *element) Key() interface{} {
+func (e *element[K, V]) Key() K {
 	return e.node.key
 }
 
-func (e *element) Value() interface{} {
+func (e *element[K, V]) Value() V {
 	return e.node.value
 }
 
-func (e *element) SetValue(value interface{}) {
+func (e *element[K, V]) SetValue(value V) {
 	e.node.value = value
+	if e.rb.aug != nil {
+		e.rb.refresh(e.rb.root, e.node)
+	}
 }
 
-func (e *element) Next() tree.Element {
+// Next 按key查找后继，key重复时按节点当前的下标查找，取得元素后树被修改也不受影响
+func (e *element[K, V]) Next() tree.Element[K, V] {
+	if k := e.rb.dupRank(e.node); k >= 0 {
+		return e.rb.Select(k + 1)
+	}
 	return e.rb.Next(e.node.key)
 }
 
-func (e *element) Prev() tree.Element {
+func (e *element[K, V]) Prev() tree.Element[K, V] {
+	if k := e.rb.dupRank(e.node); k >= 0 {
+		return e.rb.Select(k - 1)
+	}
 	return e.rb.Prev(e.node.key)
 }
 
-func (rb *rbTree) Find(key interface{}) tree.Element {
+func (rb *rbTree[K, V]) Find(key K) tree.Element[K, V] {
 	node := rb.findNode(key)
 	if node == nil {
 		return nil
 	}
-	return &element{rb: rb, node: node}
+	return &element[K, V]{rb: rb, node: node}
 }
 
-func (rb *rbTree) Left() tree.Element {
+func (rb *rbTree[K, V]) Left() tree.Element[K, V] {
 	mleft := rb.leftNode()
 	if mleft == nil {
 		return nil
 	}
-	return &element{rb: rb, node: mleft}
+	return &element[K, V]{rb: rb, node: mleft}
 }
 
-func (rb *rbTree) Right() tree.Element {
+func (rb *rbTree[K, V]) Right() tree.Element[K, V] {
 	mright := rb.rightNode()
 	if mright == nil {
 		return nil
 	}
-	return &element{rb: rb, node: mright}
+	return &element[K, V]{rb: rb, node: mright}
 }
 
-func (rb *rbTree) Prev(key interface{}) tree.Element {
+func (rb *rbTree[K, V]) Prev(key K) tree.Element[K, V] {
 	node := rb.findPrevNode(key)
 	if node == nil {
 		return nil
 	}
-	return &element{rb: rb, node: node}
+	return &element[K, V]{rb: rb, node: node}
 }
 
-func (rb *rbTree) Next(key interface{}) tree.Element {
+func (rb *rbTree[K, V]) Next(key K) tree.Element[K, V] {
 	node := rb.findNextNode(key)
 	if node == nil {
 		return nil
 	}
-	return &element{rb: rb, node: node}
+	return &element[K, V]{rb: rb, node: node}
 }
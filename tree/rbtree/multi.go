@@ -0,0 +1,75 @@
+// Copyright (c) 2025 Tian ChunXing. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package rbtree
+
+import "github.com/mrtcx/plusdata/tree"
+
+var _ tree.MultiTree[int, int] = (*rbTree[int, int])(nil)
+
+// InsertDup 插入到所有key相同的元素之后
+func (rb *rbTree[K, V]) InsertDup(key K, value V) {
+	rb.root = rb.insert(rb.root, key, value, true)
+	rb.root.color = black
+}
+
+func (rb *rbTree[K, V]) RemoveOne(key K, match func(value V) bool) bool {
+	rank := rb.Rank(key)
+	it := rb.Iterator()
+	for ok := it.Seek(key); ok && rb.cmp(it.Key(), key) == 0; ok = it.Next() {
+		if match(it.Value()) {
+			rb.removeAt(rank)
+			return true
+		}
+		rank++
+	}
+	return false
+}
+
+func (rb *rbTree[K, V]) RemoveAll(key K) int {
+	rank, cnt := rb.Rank(key), rb.Count(key)
+	for i := 0; i < cnt; i++ {
+		rb.removeAt(rank)
+	}
+	return cnt
+}
+
+func (rb *rbTree[K, V]) Count(key K) int {
+	return rb.rank(key, true) - rb.rank(key, false)
+}
+
+func (rb *rbTree[K, V]) EqualRange(key K) (first, last tree.Element[K, V]) {
+	lo, hi := rb.rank(key, false), rb.rank(key, true)
+	if lo == hi {
+		return nil, nil
+	}
+	return rb.Select(lo), rb.Select(hi - 1)
+}
+
+// 删除顺序遍历中下标为k的节点
+func (rb *rbTree[K, V]) removeAt(k int) {
+	rb.root = rb.blacken(rb.removeRank(rb.root, k))
+}
+
+func (rb *rbTree[K, V]) removeRank(root *Node[K, V], k int) *Node[K, V] {
+	if k < root.lchild.size {
+		root.lchild = rb.removeRank(root.lchild, k)
+	} else if k > root.lchild.size {
+		root.rchild = rb.removeRank(root.rchild, k-root.lchild.size-1)
+	} else if root.lchild == rb.nilNode || root.rchild == rb.nilNode {
+		tmp := root.lchild
+		if tmp == rb.nilNode {
+			tmp = root.rchild
+		}
+		rb.size--
+		return rb.inherit(tmp, root)
+	} else {
+		tmp := rb.precursor(root)
+		root.key = tmp.key
+		root.value = tmp.value
+		root.lchild = rb.removeMax(root.lchild)
+	}
+	rb.update(root)
+	return rb.removeMaintain(root)
+}
@@ -0,0 +1,62 @@
+// Copyright (c) 2025 Tian ChunXing. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package rbtree
+
+import "github.com/mrtcx/plusdata/tree"
+
+var _ tree.Navigable[int, int] = (*rbTree[int, int])(nil)
+
+// Floor 最后一个<=key的元素，存在重复key时为相等元素中的最后一个
+func (rb *rbTree[K, V]) Floor(key K) tree.Element[K, V] {
+	return rb.Select(rb.rank(key, true) - 1)
+}
+
+// Ceiling 第一个>=key的元素，存在重复key时为相等元素中的第一个
+func (rb *rbTree[K, V]) Ceiling(key K) tree.Element[K, V] {
+	return rb.Select(rb.rank(key, false))
+}
+
+func (rb *rbTree[K, V]) Lower(key K) tree.Element[K, V] {
+	return rb.Prev(key)
+}
+
+func (rb *rbTree[K, V]) Higher(key K) tree.Element[K, V] {
+	return rb.Next(key)
+}
+
+func (rb *rbTree[K, V]) PollFirst() (K, V, bool) {
+	return rb.poll(0)
+}
+
+func (rb *rbTree[K, V]) PollLast() (K, V, bool) {
+	return rb.poll(rb.size - 1)
+}
+
+func (rb *rbTree[K, V]) poll(k int) (K, V, bool) {
+	node := rb.selectNode(k)
+	if node == nil {
+		var zeroK K
+		var zeroV V
+		return zeroK, zeroV, false
+	}
+	key, value := node.key, node.value
+	rb.removeAt(k)
+	return key, value, true
+}
+
+// HeadMap key<to的元素的视图，视图中的修改直接作用于树
+func (rb *rbTree[K, V]) HeadMap(to K) tree.Navigable[K, V] {
+	return tree.NewHeadMap[K, V](rb, rb.cmp, to)
+}
+
+// TailMap key>=from的元素的视图
+func (rb *rbTree[K, V]) TailMap(from K) tree.Navigable[K, V] {
+	return tree.NewTailMap[K, V](rb, rb.cmp, from)
+}
+
+// SubMap 区间[from, to)内的元素的视图，bound可指定区间开闭
+func (rb *rbTree[K, V]) SubMap(from, to K, bound ...tree.Bound) tree.Navigable[K, V] {
+	return tree.NewSubMap[K, V](rb, rb.cmp, from, to, bound...)
+}
@@ -0,0 +1,80 @@
+// Copyright (c) 2025 Tian ChunXing. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package rbtree
+
+import "github.com/mrtcx/plusdata/tree"
+
+// Rank 返回树中小于key的元素个数，即key在顺序遍历中的下标
+func (rb *rbTree[K, V]) Rank(key K) int {
+	return rb.rank(key, false)
+}
+
+// 小于key(upper为true时小于等于key)的元素个数，存在重复key时，相等的节点可能在左右两侧，不能提前返回
+func (rb *rbTree[K, V]) rank(key K, upper bool) int {
+	rank := 0
+	for root := rb.root; root != rb.nilNode; {
+		less := rb.cmp(key, root.key)
+		if less < 0 || less == 0 && !upper {
+			root = root.lchild
+		} else {
+			rank += root.lchild.size + 1
+			root = root.rchild
+		}
+	}
+	return rank
+}
+
+// Select 返回顺序遍历中下标为k的元素(从0开始)，k越界时返回nil
+func (rb *rbTree[K, V]) Select(k int) tree.Element[K, V] {
+	node := rb.selectNode(k)
+	if node == nil {
+		return nil
+	}
+	return &element[K, V]{rb: rb, node: node}
+}
+
+func (rb *rbTree[K, V]) selectNode(k int) *Node[K, V] {
+	if k < 0 || k >= rb.size {
+		return nil
+	}
+	root := rb.root
+	for k != root.lchild.size {
+		if k < root.lchild.size {
+			root = root.lchild
+		} else {
+			k -= root.lchild.size + 1
+			root = root.rchild
+		}
+	}
+	return root
+}
+
+// key重复时node在顺序遍历中的下标，key不重复或node已不在树中时返回-1
+func (rb *rbTree[K, V]) dupRank(node *Node[K, V]) int {
+	if rb.Count(node.key) < 2 {
+		return -1
+	}
+	return rb.rankOf(rb.root, node, 0)
+}
+
+// 按key查找node，key相同的节点可能在两侧，按节点地址区分，base为子树之前的元素个数
+func (rb *rbTree[K, V]) rankOf(root, node *Node[K, V], base int) int {
+	if root == rb.nilNode {
+		return -1
+	}
+	if root == node {
+		return base + root.lchild.size
+	}
+	less := rb.cmp(node.key, root.key)
+	if less <= 0 {
+		if k := rb.rankOf(root.lchild, node, base); k >= 0 {
+			return k
+		}
+	}
+	if less >= 0 {
+		return rb.rankOf(root.rchild, node, base+root.lchild.size+1)
+	}
+	return -1
+}
@@ -5,22 +5,33 @@
 package rbtree
 
 import (
+	"reflect"
+	"sync"
+
+	"github.com/mrtcx/plusdata/codec"
 	"github.com/mrtcx/plusdata/tree"
 )
 
-var _ tree.Tree = (*rbTree)(nil)
+var _ tree.Tree[int, int] = (*rbTree[int, int])(nil)
 
-type rbTree struct {
-	root *Node
-	cmp  tree.Comparator
-	size int
+type rbTree[K, V any] struct {
+	root    *Node[K, V]
+	cmp     tree.Comparator[K]
+	size    int
+	nilNode *Node[K, V]
+	dbNil   *Node[K, V] //删除时代替nilNode表示"双黑"的nil，nilNode只读
+	kc      codec.Codec[K]
+	vc      codec.Codec[V]
+	aug     augmenter[K, V] //带聚合的树维护子树的聚合值，为nil时不维护
 }
 
-type Node struct {
-	key            interface{}
-	value          interface{}
+type Node[K, V any] struct {
+	key            K
+	value          V
 	color          rbColor //0- 红色， 1-黑色 2-双重黑
-	lchild, rchild *Node
+	lchild, rchild *Node[K, V]
+	size           int //子树中节点的数量
+	agg            any //带聚合的树中为子树聚合值的指针
 }
 
 type rbColor uint8
@@ -31,158 +42,190 @@ const (
 	doubleBlack rbColor = 2
 )
 
-// 虚拟的NIL叶子节点(黑色),所有nil都用它来替代，代码会少很多特判
-var _nil *Node = &Node{
-	color: black,
+// 虚拟的NIL叶子节点(黑色),所有nil都用它来替代，代码会少很多特判。
+// 同一类型的树共享只读的nilNode，Split、Join时节点可以直接在树之间移动
+var sentinels sync.Map
+
+func sentinel[K, V any]() *Node[K, V] {
+	key := reflect.TypeOf((*Node[K, V])(nil))
+	if s, ok := sentinels.Load(key); ok {
+		return s.(*Node[K, V])
+	}
+	s, _ := sentinels.LoadOrStore(key, &Node[K, V]{color: black})
+	return s.(*Node[K, V])
+}
+
+func New[K, V any](cmp tree.Comparator[K]) *rbTree[K, V] {
+	nilNode := sentinel[K, V]()
+	return &rbTree[K, V]{
+		root:    nilNode,
+		cmp:     cmp,
+		nilNode: nilNode,
+		dbNil:   &Node[K, V]{color: doubleBlack},
+	}
 }
 
-func newNode(k, v interface{}) *Node {
-	t := Node{
+// NewAny interface{}版本的构造函数，与泛型之前的New用法相同，返回的树实现tree.AnyTree
+func NewAny(cmp tree.AnyComparator) *rbTree[any, any] {
+	return New[any, any](cmp)
+}
+
+func (rb *rbTree[K, V]) newNode(k K, v V) *Node[K, V] {
+	t := Node[K, V]{
 		key:    k,
 		value:  v,
 		color:  red,
-		lchild: _nil,
-		rchild: _nil,
+		size:   1,
+		lchild: rb.nilNode,
+		rchild: rb.nilNode,
 	}
-	return &t
-}
-
-func New(cmp tree.Comparator) *rbTree {
-	return &rbTree{
-		root: _nil,
-		cmp:  cmp,
+	if rb.aug != nil {
+		rb.aug.init(&t)
 	}
+	return &t
 }
 
-func (rb *rbTree) Clean() {
-	rb.root, rb.size = _nil, 0
+func (rb *rbTree[K, V]) Clean() {
+	rb.root, rb.size = rb.nilNode, 0
 }
 
-func (rb *rbTree) Size() int {
+func (rb *rbTree[K, V]) Size() int {
 	return rb.size
 }
 
-func (rb *rbTree) Empty() bool {
+func (rb *rbTree[K, V]) Empty() bool {
 	return rb.size == 0
 }
 
-func (rb *rbTree) Insert(key, value interface{}) {
-	rb.root = rb.insert(rb.root, key, value)
+func (rb *rbTree[K, V]) Insert(key K, value V) {
+	rb.root = rb.insert(rb.root, key, value, false)
 	rb.root.color = black
 }
 
-func (rb *rbTree) insert(root *Node, key, value interface{}) *Node {
-	if root == _nil {
+// dup为true时不覆盖相同的key，插入到相同key的右侧
+func (rb *rbTree[K, V]) insert(root *Node[K, V], key K, value V, dup bool) *Node[K, V] {
+	if root == rb.nilNode {
 		rb.size++
-		return newNode(key, value)
+		return rb.newNode(key, value)
 	}
 	less := rb.cmp(key, root.key)
-	if less == 0 {
+	if less == 0 && !dup {
 		root.value = value
+		rb.update(root)
 		return root
 	} else if less < 0 {
-		root.lchild = rb.insert(root.lchild, key, value)
+		root.lchild = rb.insert(root.lchild, key, value, dup)
 	} else {
-		root.rchild = rb.insert(root.rchild, key, value)
+		root.rchild = rb.insert(root.rchild, key, value, dup)
 	}
-	return insertMaintain(root)
+	rb.update(root)
+	return rb.insertMaintain(root)
 }
 
 // 修复双红冲突
-func insertMaintain(root *Node) *Node {
+func (rb *rbTree[K, V]) insertMaintain(root *Node[K, V]) *Node[K, V] {
 	if root.lchild.color == black && root.rchild.color == black {
 		return root
 	}
-	hasRedChild := func(root *Node) bool {
+	hasRedChild := func(root *Node[K, V]) bool {
 		return root.lchild.color == red || root.rchild.color == 0
 	}
 	if root.lchild.color == red && root.rchild.color == red {
 		root.color, root.lchild.color, root.rchild.color = red, black, black
 	} else if root.lchild.color == red && hasRedChild(root.lchild) {
 		if root.lchild.rchild.color == red {
-			root.lchild = leftRota(root.lchild)
+			root.lchild = rb.leftRota(root.lchild)
 		}
-		root = rightRota(root)
+		root = rb.rightRota(root)
 		root.lchild.color = black
 	} else if root.rchild.color == red && hasRedChild(root.rchild) {
 		if root.rchild.lchild.color == red {
-			root.rchild = rightRota(root.rchild)
+			root.rchild = rb.rightRota(root.rchild)
 		}
-		root = leftRota(root)
+		root = rb.leftRota(root)
 		root.rchild.color = black
 	}
 	return root
 }
 
-func (rb *rbTree) Remove(key interface{}) {
-	rb.root = rb.remove(rb.root, key)
-	rb.root.color = black
+func (rb *rbTree[K, V]) Remove(key K) {
+	rb.root = rb.blacken(rb.remove(rb.root, key))
 }
 
-func (rb *rbTree) remove(root *Node, key interface{}) *Node {
-	if root == _nil {
-		return _nil
+func (rb *rbTree[K, V]) remove(root *Node[K, V], key K) *Node[K, V] {
+	if root == rb.nilNode {
+		return rb.nilNode
 	}
 	less := rb.cmp(key, root.key)
 	if less == 0 {
-		if root.lchild == _nil || root.rchild == _nil {
+		if root.lchild == rb.nilNode || root.rchild == rb.nilNode {
 			tmp := root.lchild
-			if tmp == _nil {
+			if tmp == rb.nilNode {
 				tmp = root.rchild
 			}
-			tmp.color += root.color //此时会有"双黑"冲突
 			rb.size--
-			return tmp
+			return rb.inherit(tmp, root) //此时会有"双黑"冲突
 		} else {
-			tmp := precursor(root)
+			tmp := rb.precursor(root)
 			root.key = tmp.key
 			root.value = tmp.value
-			root.lchild = rb.remove(root.lchild, tmp.key)
+			root.lchild = rb.removeMax(root.lchild)
 		}
 	} else if less < 0 {
 		root.lchild = rb.remove(root.lchild, key)
 	} else {
 		root.rchild = rb.remove(root.rchild, key)
 	}
-	return removeMaintain(root)
+	rb.update(root)
+	return rb.removeMaintain(root)
+}
+
+// 删除子树中最右的节点，存在重复key时不能按key删除前驱
+func (rb *rbTree[K, V]) removeMax(root *Node[K, V]) *Node[K, V] {
+	if root.rchild == rb.nilNode {
+		rb.size--
+		return rb.inherit(root.lchild, root)
+	}
+	root.rchild = rb.removeMax(root.rchild)
+	rb.update(root)
+	return rb.removeMaintain(root)
 }
 
-func (rb *rbTree) Get(key interface{}) (interface{}, bool) {
+func (rb *rbTree[K, V]) Get(key K) (V, bool) {
 	node := rb.findNode(key)
 	if node == nil {
-		return nil, false
+		var zero V
+		return zero, false
 	}
 	return node.value, true
 }
 
-func (rb *rbTree) leftNode() *Node {
+func (rb *rbTree[K, V]) leftNode() *Node[K, V] {
 	root := rb.root
-	if root == _nil {
+	if root == rb.nilNode {
 		return nil
 	}
-	for root.lchild != _nil {
+	for root.lchild != rb.nilNode {
 		root = root.lchild
 	}
 	return root
 }
 
-func (rb *rbTree) rightNode() *Node {
+func (rb *rbTree[K, V]) rightNode() *Node[K, V] {
 	root := rb.root
-	if root == _nil {
+	if root == rb.nilNode {
 		return nil
 	}
-	for root.rchild != _nil {
+	for root.rchild != rb.nilNode {
 		root = root.rchild
 	}
 	return root
 }
 
-func (rb *rbTree) findNode(key interface{}) *Node {
+// 查找key所在的节点
+func (rb *rbTree[K, V]) findNode(key K) *Node[K, V] {
 	root := rb.root
-	for {
-		if root == _nil {
-			return nil
-		}
+	for root != rb.nilNode {
 		less := rb.cmp(key, root.key)
 		if less == 0 {
 			return root
@@ -192,67 +235,53 @@ func (rb *rbTree) findNode(key interface{}) *Node {
 			root = root.rchild
 		}
 	}
+	return nil
 }
 
-func (rb *rbTree) findPrevNode(key interface{}) *Node {
+// 最后一个小于key的节点
+func (rb *rbTree[K, V]) findPrevNode(key K) *Node[K, V] {
 	root := rb.root
-	var lparent *Node = nil
-	for {
-		if root == _nil {
-			return nil
-		}
-		less := rb.cmp(key, root.key)
-		if less > 0 {
-			if root.rchild == _nil {
-				return root
-			}
-			root, lparent = root.rchild, root
+	var lparent *Node[K, V] = nil
+	for root != rb.nilNode {
+		if rb.cmp(key, root.key) > 0 {
+			lparent = root
+			root = root.rchild
 		} else {
-			if root.lchild == _nil {
-				return lparent
-			}
 			root = root.lchild
 		}
 	}
+	return lparent
 }
 
-func (rb *rbTree) findNextNode(key interface{}) *Node {
+// 第一个大于key的节点
+func (rb *rbTree[K, V]) findNextNode(key K) *Node[K, V] {
 	root := rb.root
-	var rparent *Node = nil
-	for {
-		if root == _nil {
-			return nil
-		}
-		less := rb.cmp(key, root.key)
-		if less >= 0 {
-			if root.rchild == _nil {
-				return rparent
-			}
+	var rparent *Node[K, V] = nil
+	for root != rb.nilNode {
+		if rb.cmp(key, root.key) >= 0 {
 			root = root.rchild
 		} else {
-			if root.lchild == _nil {
-				return root
-			}
-			root, rparent = root.lchild, root
+			rparent = root
+			root = root.lchild
 		}
 	}
+	return rparent
 }
 
 // 修复双黑冲突
-func removeMaintain(root *Node) *Node {
+func (rb *rbTree[K, V]) removeMaintain(root *Node[K, V]) *Node[K, V] {
 	//无双黑冲突
 	if root.lchild.color != doubleBlack && root.rchild.color != doubleBlack {
 		return root
 	}
-	hasRedChild := func(inode *Node) bool {
+	hasRedChild := func(inode *Node[K, V]) bool {
 		return inode.lchild.color == red || inode.rchild.color == red
 	}
 
 	//无红色孩子,也无红色孙子
 	if root.lchild.color == doubleBlack && root.rchild.color != red && !hasRedChild(root.rchild) ||
 		root.rchild.color == doubleBlack && root.lchild.color != red && !hasRedChild(root.lchild) {
-		root.lchild.color -= black
-		root.rchild.color -= black
+		root.lchild, root.rchild = rb.lighten(root.lchild), rb.lighten(root.rchild)
 		root.color += black
 		return root
 	}
@@ -260,63 +289,105 @@ func removeMaintain(root *Node) *Node {
 	//有红色的孩子
 	if hasRedChild(root) {
 		if root.lchild.color == red {
-			root = rightRota(root)
+			root = rb.rightRota(root)
 			root.color = black
 			root.rchild.color = red
-			root.rchild = removeMaintain(root.rchild)
+			root.rchild = rb.removeMaintain(root.rchild)
 		} else {
-			root = leftRota(root)
+			root = rb.leftRota(root)
 			root.color = black
 			root.lchild.color = red
-			root.lchild = removeMaintain(root.lchild)
+			root.lchild = rb.removeMaintain(root.lchild)
 		}
 		return root
 	}
 
 	//有红色的孙子
 	if root.lchild.color == doubleBlack {
-		root.lchild.color = black
+		root.lchild = rb.lighten(root.lchild)
 		if root.rchild.rchild.color != red {
 			root.rchild.color = red
 			root.rchild.lchild.color = black
-			root.rchild = rightRota(root.rchild)
+			root.rchild = rb.rightRota(root.rchild)
 		}
-		root = leftRota(root)
+		root = rb.leftRota(root)
 		root.color = root.lchild.color
 		root.lchild.color, root.rchild.color = black, black
 		return root
 	} else {
-		root.rchild.color = black
+		root.rchild = rb.lighten(root.rchild)
 		if root.lchild.lchild.color != red {
 			root.lchild.color = red
 			root.lchild.rchild.color = black
-			root.lchild = leftRota(root.lchild)
+			root.lchild = rb.leftRota(root.lchild)
 		}
-		root = rightRota(root)
+		root = rb.rightRota(root)
 		root.color = root.rchild.color
 		root.lchild.color, root.rchild.color = black, black
 		return root
 	}
 }
 
-func precursor(root *Node) *Node {
+// 删除removed后由孩子child代替，removed为黑色时child多一重黑色，child为nilNode时用dbNil代替
+func (rb *rbTree[K, V]) inherit(child, removed *Node[K, V]) *Node[K, V] {
+	if child != rb.nilNode {
+		child.color += removed.color
+		return child
+	}
+	if removed.color == black {
+		return rb.dbNil
+	}
+	return rb.nilNode
+}
+
+// 去掉一重黑色
+func (rb *rbTree[K, V]) lighten(node *Node[K, V]) *Node[K, V] {
+	if node == rb.dbNil {
+		return rb.nilNode
+	}
+	node.color -= black
+	return node
+}
+
+// 作为整棵树的根，染成黑色
+func (rb *rbTree[K, V]) blacken(root *Node[K, V]) *Node[K, V] {
+	if root == rb.dbNil || root == rb.nilNode {
+		return rb.nilNode
+	}
+	root.color = black
+	return root
+}
+
+func (rb *rbTree[K, V]) precursor(root *Node[K, V]) *Node[K, V] {
 	root = root.lchild
-	for root.rchild != _nil {
+	for root.rchild != rb.nilNode {
 		root = root.rchild
 	}
 	return root
 }
 
-func leftRota(root *Node) *Node {
+func (rb *rbTree[K, V]) leftRota(root *Node[K, V]) *Node[K, V] {
 	temp := root.rchild
 	root.rchild = temp.lchild
 	temp.lchild = root
+	rb.update(root)
+	rb.update(temp)
 	return temp
 }
 
-func rightRota(root *Node) *Node {
+func (rb *rbTree[K, V]) rightRota(root *Node[K, V]) *Node[K, V] {
 	temp := root.lchild
 	root.lchild = temp.rchild
 	temp.rchild = root
+	rb.update(root)
+	rb.update(temp)
 	return temp
 }
+
+// 更新子树大小，带聚合的树同时更新子树的聚合值
+func (rb *rbTree[K, V]) update(root *Node[K, V]) {
+	root.size = root.lchild.size + root.rchild.size + 1
+	if rb.aug != nil {
+		rb.aug.update(root)
+	}
+}
@@ -13,15 +13,15 @@ import (
 	"github.com/mrtcx/plusdata/tree"
 )
 
-var intcmp = tree.IntComparator
+var intcmp = tree.OrderedComparator[int]
 
 func TestInsert(t *testing.T) {
 	nums := []int{1, 2, 4, 8, 1024, 1024 + 1}
 	for _, num := range nums {
 		tnum := num
 		t.Run(fmt.Sprintf("[num:%d]", tnum), func(t *testing.T) {
-			rb := New(intcmp)
-			assert.Equal(t, rb.root, _nil)
+			rb := New[int, int](intcmp)
+			assert.Equal(t, rb.root, rb.nilNode)
 			for i := 1; i <= tnum; i++ {
 				rb.Insert(i, i)
 				rb.Insert(i, i)
@@ -30,7 +30,7 @@ func TestInsert(t *testing.T) {
 			}
 			assert.Equal(t, rb.size, tnum)
 
-			rb = New(intcmp)
+			rb = New[int, int](intcmp)
 			for i := tnum; i >= 1; i-- {
 				rb.Insert(i, i)
 				rb.Insert(i, i)
@@ -39,7 +39,7 @@ func TestInsert(t *testing.T) {
 			}
 			assert.Equal(t, rb.size, tnum)
 
-			rb = New(intcmp)
+			rb = New[int, int](intcmp)
 			for i, j := 1, tnum; i <= j; i, j = i+1, j-1 {
 				rb.Insert(i, i)
 				rb.Insert(j, j)
@@ -48,7 +48,7 @@ func TestInsert(t *testing.T) {
 			}
 			assert.Equal(t, rb.size, tnum)
 
-			rb = New(intcmp)
+			rb = New[int, int](intcmp)
 			for i := 1; i <= tnum; i++ {
 				randnum := int(rand.Int31() % int32(tnum))
 				rb.Insert(randnum, randnum)
@@ -76,7 +76,7 @@ func TestRemove(t *testing.T) {
 				assert.Equal(t, checkOrder(t, rb, rb.root), true)
 				assert.Equal(t, rb.size, tnum-i)
 			}
-			assert.Equal(t, rb.root, _nil)
+			assert.Equal(t, rb.root, rb.nilNode)
 			assert.Equal(t, rb.size, 0)
 
 			rb = newInsertNum(tnum)
@@ -86,7 +86,7 @@ func TestRemove(t *testing.T) {
 				assert.Equal(t, checkOrder(t, rb, rb.root), true)
 				assert.Equal(t, rb.size, i-1)
 			}
-			assert.Equal(t, rb.root, _nil)
+			assert.Equal(t, rb.root, rb.nilNode)
 			assert.Equal(t, rb.size, 0)
 
 			rb = newInsertNum(tnum)
@@ -100,14 +100,14 @@ func TestRemove(t *testing.T) {
 			}
 			rb.Remove(left)
 			rb.Remove(right)
-			assert.Equal(t, rb.root, _nil)
+			assert.Equal(t, rb.root, rb.nilNode)
 			assert.Equal(t, rb.size, 0)
 		})
 	}
 }
 
-func newInsertNum(num int) *rbTree {
-	rb := New(intcmp)
+func newInsertNum(num int) *rbTree[int, int] {
+	rb := New[int, int](intcmp)
 	for i := 1; i <= num; i++ {
 		rb.Insert(i, i)
 	}
@@ -116,9 +116,9 @@ func newInsertNum(num int) *rbTree {
 
 func TestGet(t *testing.T) {
 	num := 1024
-	rb := New(intcmp)
+	rb := New[int, int](intcmp)
 	geti, ei := rb.Get(1)
-	assert.Equal(t, geti, nil)
+	assert.Equal(t, geti, 0)
 	assert.Equal(t, ei, false)
 	for i := 1; i <= num; i++ {
 		rb.Insert(i, i)
@@ -126,7 +126,7 @@ func TestGet(t *testing.T) {
 		assert.Equal(t, geti, i)
 		assert.Equal(t, ei, true)
 		geti, ei = rb.Get(i + 1)
-		assert.Equal(t, geti, nil)
+		assert.Equal(t, geti, 0)
 		assert.Equal(t, ei, false)
 	}
 	for i := 1; i <= num; i++ {
@@ -141,13 +141,13 @@ func TestGet(t *testing.T) {
 	}
 }
 
-func checkColorBalance(t *testing.T, rb *rbTree, root *Node) bool {
-	balance, _ := checkColorBalance2(t, root)
+func checkColorBalance(t *testing.T, rb *rbTree[int, int], root *Node[int, int]) bool {
+	balance, _ := checkColorBalance2(t, rb, root)
 	return balance
 }
 
-func checkColorBalance2(t *testing.T, root *Node) (bool, int) {
-	if root == _nil {
+func checkColorBalance2(t *testing.T, tr *rbTree[int, int], root *Node[int, int]) (bool, int) {
+	if root == tr.nilNode {
 		return true, 1
 	}
 	if root.color != red && root.color != black {
@@ -158,8 +158,8 @@ func checkColorBalance2(t *testing.T, root *Node) (bool, int) {
 		t.Logf("fail node:%v %v %v", root, root.rchild, root.lchild)
 		return false, 0
 	}
-	lb, lbsize := checkColorBalance2(t, root.lchild)
-	rb, rbsize := checkColorBalance2(t, root.rchild)
+	lb, lbsize := checkColorBalance2(t, tr, root.lchild)
+	rb, rbsize := checkColorBalance2(t, tr, root.rchild)
 	if !lb || !rb {
 		return false, 0
 	}
@@ -174,12 +174,12 @@ func checkColorBalance2(t *testing.T, root *Node) (bool, int) {
 	return true, bsize
 }
 
-func checkOrder(t *testing.T, rb *rbTree, root *Node) bool {
-	if root == _nil {
+func checkOrder(t *testing.T, rb *rbTree[int, int], root *Node[int, int]) bool {
+	if root == rb.nilNode {
 		return rb.size == 0
 	}
 	var arr []int = make([]int, 0)
-	seqence(root, &arr)
+	seqence(rb, root, &arr)
 	if len(arr) != rb.size {
 		t.Logf("fail seq %d %d", rb.size, len(arr))
 		return false
@@ -193,13 +193,31 @@ func checkOrder(t *testing.T, rb *rbTree, root *Node) bool {
 	return true
 }
 
-func seqence(root *Node, arr *[]int) {
-	if root == _nil {
+func seqence(rb *rbTree[int, int], root *Node[int, int], arr *[]int) {
+	if root == rb.nilNode {
 		return
 	}
-	seqence(root.lchild, arr)
-	*arr = append(*arr, root.key.(int))
-	seqence(root.rchild, arr)
+	seqence(rb, root.lchild, arr)
+	*arr = append(*arr, root.key)
+	seqence(rb, root.rchild, arr)
+}
+
+func TestAnyKey(t *testing.T) {
+	num := 1024
+	var tr tree.Tree[any, any] = New[any, any](tree.IntComparator)
+	for i := num; i >= 1; i-- {
+		tr.Insert(i, i)
+	}
+	e := tr.Left()
+	for i := 1; i <= num; i++ {
+		assert.Equal(t, e.Key(), i)
+		assert.Equal(t, e.Value(), i)
+		e = e.Next()
+	}
+	assert.Equal(t, e, nil)
+	geti, ei := tr.Get(num + 1)
+	assert.Equal(t, geti, nil)
+	assert.Equal(t, ei, false)
 }
 
 func BenchmarkInsert(b *testing.B) {
@@ -218,7 +236,7 @@ func BenchmarkInsert(b *testing.B) {
 			}
 		})
 		b.Run(fmt.Sprintf("rbtree-%s", name), func(b *testing.B) {
-			tr := New(tree.IntComparator)
+			tr := New[int, interface{}](intcmp)
 			b.ResetTimer()
 			for i := 0; i < b.N; i++ {
 				for j := 0; j < size; j++ {
@@ -248,7 +266,7 @@ func BenchmarkRemove(b *testing.B) {
 			}
 		})
 		b.Run(fmt.Sprintf("rbtree-%s", name), func(b *testing.B) {
-			tr := New(tree.IntComparator)
+			tr := New[int, interface{}](intcmp)
 			for j := 0; j < size; j++ {
 				tr.Insert(j, nil)
 			}
@@ -281,7 +299,7 @@ func BenchmarkGet(b *testing.B) {
 			}
 		})
 		b.Run(fmt.Sprintf("rbtree-%s", name), func(b *testing.B) {
-			tr := New(tree.IntComparator)
+			tr := New[int, interface{}](intcmp)
 			for j := 0; j < size; j++ {
 				tr.Insert(j, nil)
 			}
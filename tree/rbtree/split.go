@@ -0,0 +1,202 @@
+// Copyright (c) 2025 Tian ChunXing. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package rbtree
+
+import "github.com/mrtcx/plusdata/tree"
+
+// 与rb使用相同比较函数、codec和聚合的空树
+func (rb *rbTree[K, V]) empty() *rbTree[K, V] {
+	return &rbTree[K, V]{
+		root:    rb.nilNode,
+		cmp:     rb.cmp,
+		nilNode: rb.nilNode,
+		dbNil:   &Node[K, V]{color: doubleBlack},
+		kc:      rb.kc,
+		vc:      rb.vc,
+		aug:     rb.aug,
+	}
+}
+
+func (rb *rbTree[K, V]) setRoot(root *Node[K, V]) {
+	rb.root, rb.size = root, root.size
+}
+
+// Split 按key把树分成key<key的left和key>=key的right，节点移动到两棵新树中，原树变为空树，O(logN)。
+// 重复的key按原有顺序都在right中
+func (rb *rbTree[K, V]) Split(key K) (left, right *rbTree[K, V]) {
+	l, r := rb.split(rb.root, key, false)
+	left, right = rb.empty(), rb.empty()
+	left.setRoot(l)
+	right.setRoot(r)
+	rb.Clean()
+	return left, right
+}
+
+// Join 把right的全部节点合并到left中并返回left，right变为空树，O(logN)。
+// left中的key需要都不大于right中的key(相同的key中left的在前)，否则返回tree.ErrNotSorted，两棵树不变
+func Join[K, V any](left, right *rbTree[K, V]) (*rbTree[K, V], error) {
+	if !left.Empty() && !right.Empty() && left.cmp(left.rightNode().key, right.leftNode().key) > 0 {
+		return left, tree.ErrNotSorted
+	}
+	left.setRoot(left.join2(left.root, right.root))
+	right.Clean()
+	return left, nil
+}
+
+// Union 并集，key在a中存在时丢弃b中key相同的全部元素。结果为a，b变为空树，较小的树大小为M时复杂度O(Mlog(N/M+1))
+func Union[K, V any](a, b *rbTree[K, V]) *rbTree[K, V] {
+	a.setRoot(a.union(a.root, b.root))
+	b.Clean()
+	return a
+}
+
+// Intersection 交集，保留a中key也在b中的元素。结果为a，b变为空树
+func Intersection[K, V any](a, b *rbTree[K, V]) *rbTree[K, V] {
+	a.setRoot(a.filter(a.root, b.root, true))
+	b.Clean()
+	return a
+}
+
+// Difference 差集，保留a中key不在b中的元素。结果为a，b变为空树
+func Difference[K, V any](a, b *rbTree[K, V]) *rbTree[K, V] {
+	a.setRoot(a.filter(a.root, b.root, false))
+	b.Clean()
+	return a
+}
+
+// 黑高，不计nilNode
+func (rb *rbTree[K, V]) blackHeight(root *Node[K, V]) int {
+	h := 0
+	for ; root != rb.nilNode; root = root.lchild {
+		if root.color == black {
+			h++
+		}
+	}
+	return h
+}
+
+// 以mid为根连接l和r，l中的key都不大于mid，r中的key都不小于mid，返回黑色的根。
+// 沿黑高较大一侧的边向下，找到黑高相同的黑色子树后用红色的mid连接，回溯时修复双红冲突，复杂度为两棵树的黑高差
+func (rb *rbTree[K, V]) join(l, mid, r *Node[K, V]) *Node[K, V] {
+	l, r = rb.blacken(l), rb.blacken(r)
+	lh, rh := rb.blackHeight(l), rb.blackHeight(r)
+	var root *Node[K, V]
+	if lh > rh {
+		root = rb.joinRight(l, mid, r, lh, rh)
+	} else if lh < rh {
+		root = rb.joinLeft(l, mid, r, lh, rh)
+	} else {
+		mid.lchild, mid.rchild = l, r
+		rb.update(mid)
+		root = mid
+	}
+	return rb.blacken(root)
+}
+
+func (rb *rbTree[K, V]) joinRight(l, mid, r *Node[K, V], lh, rh int) *Node[K, V] {
+	if l.color == black && lh == rh {
+		mid.lchild, mid.rchild, mid.color = l, r, red
+		rb.update(mid)
+		return mid
+	}
+	if l.color == black {
+		lh--
+	}
+	l.rchild = rb.joinRight(l.rchild, mid, r, lh, rh)
+	rb.update(l)
+	if l.color == black && l.rchild.color == red && l.rchild.rchild.color == red {
+		l.rchild.rchild.color = black
+		return rb.leftRota(l)
+	}
+	return l
+}
+
+func (rb *rbTree[K, V]) joinLeft(l, mid, r *Node[K, V], lh, rh int) *Node[K, V] {
+	if r.color == black && lh == rh {
+		mid.lchild, mid.rchild, mid.color = l, r, red
+		rb.update(mid)
+		return mid
+	}
+	if r.color == black {
+		rh--
+	}
+	r.lchild = rb.joinLeft(l, mid, r.lchild, lh, rh)
+	rb.update(r)
+	if r.color == black && r.lchild.color == red && r.lchild.lchild.color == red {
+		r.lchild.lchild.color = black
+		return rb.rightRota(r)
+	}
+	return r
+}
+
+// 没有中间节点时，取出r中最小的节点作为中间节点
+func (rb *rbTree[K, V]) join2(l, r *Node[K, V]) *Node[K, V] {
+	if r == rb.nilNode {
+		return l
+	}
+	mid := r
+	for mid.lchild != rb.nilNode {
+		mid = mid.lchild
+	}
+	// removeRank会修改rb.size，调用方之后按根的size重新设置
+	r = rb.blacken(rb.removeRank(r, 0))
+	return rb.join(l, mid, r)
+}
+
+// 把子树分成小于key(upper为true时小于等于key)的l和其余的r，相同的key保持原有顺序
+func (rb *rbTree[K, V]) split(root *Node[K, V], key K, upper bool) (l, r *Node[K, V]) {
+	if root == rb.nilNode {
+		return rb.nilNode, rb.nilNode
+	}
+	lchild, rchild := root.lchild, root.rchild
+	less := rb.cmp(key, root.key)
+	if less < 0 || less == 0 && !upper {
+		l, r = rb.split(lchild, key, upper)
+		return l, rb.join(r, root, rchild)
+	}
+	l, r = rb.split(rchild, key, upper)
+	return rb.join(lchild, root, l), r
+}
+
+// 把子树分成小于key、等于key、大于key三部分
+func (rb *rbTree[K, V]) split3(root *Node[K, V], key K) (l, eq, r *Node[K, V]) {
+	l, r = rb.split(root, key, false)
+	eq, r = rb.split(r, key, true)
+	return l, eq, r
+}
+
+// 按t1的根分割t2，两侧分别递归后再连接
+func (rb *rbTree[K, V]) union(t1, t2 *Node[K, V]) *Node[K, V] {
+	if t1 == rb.nilNode {
+		return t2
+	} else if t2 == rb.nilNode {
+		return t1
+	}
+	l2, _, r2 := rb.split3(t2, t1.key)
+	lchild, rchild := t1.lchild, t1.rchild
+	return rb.join(rb.union(lchild, l2), t1, rb.union(rchild, r2))
+}
+
+// 保留t1中key在t2中存在(keep为true)或不存在(keep为false)的元素。
+// 先把t1中与根key相同的元素一起分出来，它们的去留只由t2中是否有这个key决定
+func (rb *rbTree[K, V]) filter(t1, t2 *Node[K, V], keep bool) *Node[K, V] {
+	if t1 == rb.nilNode {
+		return rb.nilNode
+	}
+	if t2 == rb.nilNode {
+		if keep {
+			return rb.nilNode
+		}
+		return t1
+	}
+	key := t1.key
+	l1, eq1, r1 := rb.split3(t1, key)
+	l2, eq2, r2 := rb.split3(t2, key)
+	l, r := rb.filter(l1, l2, keep), rb.filter(r1, r2, keep)
+	if (eq2 != rb.nilNode) == keep {
+		return rb.join2(rb.join2(l, eq1), r)
+	}
+	return rb.join2(l, r)
+}
@@ -0,0 +1,186 @@
+// Copyright (c) 2025 Tian ChunXing. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package rbtree
+
+import (
+	"fmt"
+	"math/rand"
+	"sync"
+	"testing"
+
+	"github.com/mrtcx/plusdata/internal/assert"
+	"github.com/mrtcx/plusdata/tree"
+)
+
+func TestSplitJoin(t *testing.T) {
+	nums := []int{0, 1, 2, 8, 1024}
+	for _, num := range nums {
+		tnum := num
+		t.Run(fmt.Sprintf("[num:%d]", tnum), func(t *testing.T) {
+			for i := 0; i < 16; i++ {
+				rb := New[int, int](intcmp)
+				for j := 0; j < tnum; j++ {
+					rb.InsertDup(rand.Intn(tnum), j)
+				}
+				want := elements(rb)
+				key := rand.Intn(tnum+2) - 1
+				left, right := rb.Split(key)
+				assert.Equal(t, rb.Size(), 0)
+				idx := 0
+				for idx < len(want) && want[idx][0] < key {
+					idx++
+				}
+				expectElements(t, left, want[:idx])
+				expectElements(t, right, want[idx:])
+
+				joined, err := Join(left, right)
+				assert.Equal(t, err, nil)
+				assert.Equal(t, right.Size(), 0)
+				expectElements(t, joined, want)
+			}
+		})
+	}
+}
+
+func TestJoinUnbalanced(t *testing.T) {
+	for _, num := range []int{1, 7, 1000} {
+		small, large := New[int, int](intcmp), New[int, int](intcmp)
+		for i := 0; i < num; i++ {
+			large.Insert(i+2, i)
+		}
+		small.Insert(0, 0)
+		small.Insert(3, 3)
+		assert.Equal(t, small.nilNode, large.nilNode)
+		_, err := Join(small, large)
+		assert.Equal(t, err, tree.ErrNotSorted)
+		assert.Equal(t, small.Size(), 2)
+		assert.Equal(t, large.Size(), num)
+
+		small.Remove(3)
+		small.Insert(2, 2) //相同的key可以连接，left的在前
+		want := append(elements(small), elements(large)...)
+		joined, err := Join(small, large)
+		assert.Equal(t, err, nil)
+		expectElements(t, joined, want)
+	}
+}
+
+func TestSetOperation(t *testing.T) {
+	nums := [][2]int{{0, 0}, {0, 16}, {16, 0}, {8, 1024}, {1024, 8}, {512, 512}}
+	for _, num := range nums {
+		na, nb := num[0], num[1]
+		t.Run(fmt.Sprintf("[num:%d,%d]", na, nb), func(t *testing.T) {
+			// a、b中都有重复的key
+			newTrees := func() (*rbTree[int, int], *rbTree[int, int], map[int]bool) {
+				a, b := New[int, int](intcmp), New[int, int](intcmp)
+				inb := map[int]bool{}
+				for i := 0; i < na; i++ {
+					a.InsertDup(rand.Intn(na+nb), i)
+				}
+				for i := 0; i < nb; i++ {
+					key := rand.Intn(na + nb)
+					b.InsertDup(key, -i)
+					inb[key] = true
+				}
+				return a, b, inb
+			}
+			filter := func(elems [][2]int, keep func(key int) bool) [][2]int {
+				var res [][2]int
+				for _, e := range elems {
+					if keep(e[0]) {
+						res = append(res, e)
+					}
+				}
+				return res
+			}
+
+			a, b, inb := newTrees()
+			ea, eb := elements(a), elements(b)
+			ina := map[int]bool{}
+			for _, e := range ea {
+				ina[e[0]] = true
+			}
+			// a中的元素加上key不在a中的b的元素，按key归并，相同的key保持各自的顺序
+			var want [][2]int
+			rest := filter(eb, func(key int) bool { return !ina[key] })
+			i, j := 0, 0
+			for i < len(ea) || j < len(rest) {
+				if j == len(rest) || i < len(ea) && ea[i][0] <= rest[j][0] {
+					want, i = append(want, ea[i]), i+1
+				} else {
+					want, j = append(want, rest[j]), j+1
+				}
+			}
+			expectElements(t, Union(a, b), want)
+			assert.Equal(t, b.Size(), 0)
+
+			a, b, inb = newTrees()
+			want = filter(elements(a), func(key int) bool { return inb[key] })
+			expectElements(t, Intersection(a, b), want)
+			assert.Equal(t, b.Size(), 0)
+
+			a, b, inb = newTrees()
+			want = filter(elements(a), func(key int) bool { return !inb[key] })
+			expectElements(t, Difference(a, b), want)
+			assert.Equal(t, b.Size(), 0)
+		})
+	}
+}
+
+// 分割后的两棵树共享只读的nilNode，可以在不同的goroutine中同时修改
+func TestSplitConcurrent(t *testing.T) {
+	rb := New[int, int](intcmp)
+	for i := 0; i < 1024; i++ {
+		rb.Insert(i, i)
+	}
+	left, right := rb.Split(512)
+	var wg sync.WaitGroup
+	for _, half := range []*rbTree[int, int]{left, right} {
+		wg.Add(1)
+		go func(half *rbTree[int, int]) {
+			defer wg.Done()
+			for i := 0; i < 4096; i++ {
+				key := rand.Intn(1024)
+				if rand.Intn(2) == 0 {
+					half.Remove(key)
+				} else {
+					half.Insert(key, key)
+				}
+			}
+			assert.Equal(t, checkColorBalance(t, half, half.root), true)
+		}(half)
+	}
+	wg.Wait()
+}
+
+// 按顺序的(key, value)
+func elements(rb *rbTree[int, int]) [][2]int {
+	var res [][2]int
+	for e := rb.Left(); e != nil; e = e.Next() {
+		res = append(res, [2]int{e.Key(), e.Value()})
+	}
+	return res
+}
+
+func expectElements(t *testing.T, rb *rbTree[int, int], want [][2]int) {
+	assert.Equal(t, checkColorBalance(t, rb, rb.root), true)
+	assert.Equal(t, rb.root.color, black)
+	assert.Equal(t, rb.size, rb.root.size)
+	var check func(root *Node[int, int])
+	check = func(root *Node[int, int]) {
+		if root == rb.nilNode {
+			return
+		}
+		check(root.lchild)
+		check(root.rchild)
+		assert.Equal(t, root.size, root.lchild.size+root.rchild.size+1)
+	}
+	check(rb.root)
+	got := elements(rb)
+	assert.Equal(t, len(got), len(want))
+	for i := 0; i < len(got) && i < len(want); i++ {
+		assert.Equal(t, got[i], want[i])
+	}
+}
@@ -0,0 +1,89 @@
+// Copyright (c) 2025 Tian ChunXing. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package scapegoat
+
+import (
+	"bytes"
+	"io"
+
+	"github.com/mrtcx/plusdata/codec"
+)
+
+// SetCodec 设置编解码key和value的codec，int、int64、uint64、float64、string、[]byte可以不设置
+func (sg *scapegoat[K, V]) SetCodec(kc codec.Codec[K], vc codec.Codec[V]) {
+	sg.kc, sg.vc = kc, vc
+}
+
+// Encode 按顺序把元素逐个编码写入w，编码的格式与树的种类无关，可以解码到其他种类的树中
+func (sg *scapegoat[K, V]) Encode(w io.Writer) error {
+	kc, vc, err := sg.codecs()
+	if err != nil {
+		return err
+	}
+	enc := codec.NewEncoder(w, codec.KindTree, sg.size)
+	it := sg.Iterator()
+	for ok := it.First(); ok; ok = it.Next() {
+		codec.Write(enc, kc, it.Key())
+		codec.Write(enc, vc, it.Value())
+	}
+	return enc.Close()
+}
+
+// Decode 清空后从r中解码，出错时树为空
+func (sg *scapegoat[K, V]) Decode(r io.Reader) error {
+	sg.Clean()
+	if err := sg.decode(r); err != nil {
+		sg.Clean()
+		return err
+	}
+	return nil
+}
+
+func (sg *scapegoat[K, V]) decode(r io.Reader) error {
+	kc, vc, err := sg.codecs()
+	if err != nil {
+		return err
+	}
+	dec, n, err := codec.NewDecoder(r, codec.KindTree)
+	if err != nil {
+		return err
+	}
+	for i := 0; i < n; i++ {
+		key, err := codec.Read(dec, kc)
+		if err != nil {
+			return err
+		}
+		value, err := codec.Read(dec, vc)
+		if err != nil {
+			return err
+		}
+		sg.Insert(key, value)
+	}
+	return dec.Close()
+}
+
+func (sg *scapegoat[K, V]) codecs() (codec.Codec[K], codec.Codec[V], error) {
+	kc, err := codec.Resolve(sg.kc)
+	if err != nil {
+		return nil, nil, err
+	}
+	vc, err := codec.Resolve(sg.vc)
+	if err != nil {
+		return nil, nil, err
+	}
+	return kc, vc, nil
+}
+
+func (sg *scapegoat[K, V]) MarshalBinary() ([]byte, error) {
+	var buf bytes.Buffer
+	if err := sg.Encode(&buf); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func (sg *scapegoat[K, V]) UnmarshalBinary(data []byte) error {
+	return sg.Decode(bytes.NewReader(data))
+}
@@ -0,0 +1,110 @@
+// Copyright (c) 2025 Tian ChunXing. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package scapegoat
+
+import "github.com/mrtcx/plusdata/tree"
+
+var _ tree.Iterable[int, int] = (*scapegoat[int, int])(nil)
+
+// Iterator 栈中保存从根到当前节点的路径，栈顶为当前节点，栈为空时无效
+type Iterator[K, V any] struct {
+	sg    *scapegoat[K, V]
+	stack []*Node[K, V]
+}
+
+func (sg *scapegoat[K, V]) Iterator() tree.Iterator[K, V] {
+	return &Iterator[K, V]{sg: sg, stack: make([]*Node[K, V], 0, 64)}
+}
+
+func (it *Iterator[K, V]) Seek(key K) bool {
+	it.stack = it.stack[:0]
+	found := 0
+	for root := it.sg.root; root != nil; {
+		it.stack = append(it.stack, root)
+		less := it.sg.cmp(key, root.key)
+		if less == 0 {
+			return true
+		} else if less < 0 {
+			found = len(it.stack)
+			root = root.lchild
+		} else {
+			root = root.rchild
+		}
+	}
+	// 路径中最后一个向左走的节点，就是第一个大于key的节点
+	it.stack = it.stack[:found]
+	return it.Valid()
+}
+
+func (it *Iterator[K, V]) First() bool {
+	it.stack = it.stack[:0]
+	it.pushLeft(it.sg.root)
+	return it.Valid()
+}
+
+func (it *Iterator[K, V]) Last() bool {
+	it.stack = it.stack[:0]
+	it.pushRight(it.sg.root)
+	return it.Valid()
+}
+
+func (it *Iterator[K, V]) Next() bool {
+	if !it.Valid() {
+		return false
+	}
+	node := it.stack[len(it.stack)-1]
+	if node.rchild != nil {
+		it.pushLeft(node.rchild)
+		return true
+	}
+	// 向上回溯，直到从左子树返回
+	it.stack = it.stack[:len(it.stack)-1]
+	for len(it.stack) > 0 && it.stack[len(it.stack)-1].rchild == node {
+		node = it.stack[len(it.stack)-1]
+		it.stack = it.stack[:len(it.stack)-1]
+	}
+	return it.Valid()
+}
+
+func (it *Iterator[K, V]) Prev() bool {
+	if !it.Valid() {
+		return false
+	}
+	node := it.stack[len(it.stack)-1]
+	if node.lchild != nil {
+		it.pushRight(node.lchild)
+		return true
+	}
+	it.stack = it.stack[:len(it.stack)-1]
+	for len(it.stack) > 0 && it.stack[len(it.stack)-1].lchild == node {
+		node = it.stack[len(it.stack)-1]
+		it.stack = it.stack[:len(it.stack)-1]
+	}
+	return it.Valid()
+}
+
+func (it *Iterator[K, V]) Valid() bool {
+	return len(it.stack) > 0
+}
+
+func (it *Iterator[K, V]) Key() K {
+	return it.stack[len(it.stack)-1].key
+}
+
+func (it *Iterator[K, V]) Value() V {
+	return it.stack[len(it.stack)-1].value
+}
+
+func (it *Iterator[K, V]) pushLeft(root *Node[K, V]) {
+	for ; root != nil; root = root.lchild {
+		it.stack = append(it.stack, root)
+	}
+}
+
+func (it *Iterator[K, V]) pushRight(root *Node[K, V]) {
+	for ; root != nil; root = root.rchild {
+		it.stack = append(it.stack, root)
+	}
+}
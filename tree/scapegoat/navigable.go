@@ -0,0 +1,62 @@
+// Copyright (c) 2025 Tian ChunXing. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package scapegoat
+
+import "github.com/mrtcx/plusdata/tree"
+
+var _ tree.Navigable[int, int] = (*scapegoat[int, int])(nil)
+
+// Floor 最后一个<=key的元素
+func (sg *scapegoat[K, V]) Floor(key K) tree.Element[K, V] {
+	return sg.Select(sg.rank(key, true) - 1)
+}
+
+// Ceiling 第一个>=key的元素
+func (sg *scapegoat[K, V]) Ceiling(key K) tree.Element[K, V] {
+	return sg.Select(sg.rank(key, false))
+}
+
+func (sg *scapegoat[K, V]) Lower(key K) tree.Element[K, V] {
+	return sg.Prev(key)
+}
+
+func (sg *scapegoat[K, V]) Higher(key K) tree.Element[K, V] {
+	return sg.Next(key)
+}
+
+func (sg *scapegoat[K, V]) PollFirst() (K, V, bool) {
+	return sg.poll(0)
+}
+
+func (sg *scapegoat[K, V]) PollLast() (K, V, bool) {
+	return sg.poll(sg.size - 1)
+}
+
+func (sg *scapegoat[K, V]) poll(k int) (K, V, bool) {
+	node := sg.selectNode(k)
+	if node == nil {
+		var zeroK K
+		var zeroV V
+		return zeroK, zeroV, false
+	}
+	key, value := node.key, node.value
+	sg.Remove(key)
+	return key, value, true
+}
+
+// HeadMap key<to的元素的视图，视图中的修改直接作用于树
+func (sg *scapegoat[K, V]) HeadMap(to K) tree.Navigable[K, V] {
+	return tree.NewHeadMap[K, V](sg, sg.cmp, to)
+}
+
+// TailMap key>=from的元素的视图
+func (sg *scapegoat[K, V]) TailMap(from K) tree.Navigable[K, V] {
+	return tree.NewTailMap[K, V](sg, sg.cmp, from)
+}
+
+// SubMap 区间[from, to)内的元素的视图，bound可指定区间开闭
+func (sg *scapegoat[K, V]) SubMap(from, to K, bound ...tree.Bound) tree.Navigable[K, V] {
+	return tree.NewSubMap[K, V](sg, sg.cmp, from, to, bound...)
+}
@@ -0,0 +1,82 @@
+// Copyright (c) 2025 Tian ChunXing. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package scapegoat
+
+import "github.com/mrtcx/plusdata/tree"
+
+// AscendRange 正序遍历区间[lo, hi)内的元素, fn返回false时停止, bound可指定区间开闭
+func (sg *scapegoat[K, V]) AscendRange(lo, hi K, fn func(key K, value V) bool, bound ...tree.Bound) {
+	b := tree.RangeBound(bound)
+	sg.ascend(lo, b.LoInclusive(), func(key K) bool {
+		less := sg.cmp(key, hi)
+		return less < 0 || less == 0 && b.HiInclusive()
+	}, fn)
+}
+
+// DescendRange 倒序遍历区间[lo, hi)内的元素, fn返回false时停止, bound可指定区间开闭
+func (sg *scapegoat[K, V]) DescendRange(lo, hi K, fn func(key K, value V) bool, bound ...tree.Bound) {
+	b := tree.RangeBound(bound)
+	sg.descend(hi, b.HiInclusive(), func(key K) bool {
+		less := sg.cmp(key, lo)
+		return less > 0 || less == 0 && b.LoInclusive()
+	}, fn)
+}
+
+// AscendGreaterOrEqual 正序遍历key>=lo的元素
+func (sg *scapegoat[K, V]) AscendGreaterOrEqual(lo K, fn func(key K, value V) bool) {
+	sg.ascend(lo, true, nil, fn)
+}
+
+// DescendLessOrEqual 倒序遍历key<=hi的元素
+func (sg *scapegoat[K, V]) DescendLessOrEqual(hi K, fn func(key K, value V) bool) {
+	sg.descend(hi, true, nil, fn)
+}
+
+// 栈中保存还未访问的左父节点，出栈后把右子树的左链入栈，整体O(logN + k)
+func (sg *scapegoat[K, V]) ascend(lo K, include bool, inRange func(key K) bool, fn func(key K, value V) bool) {
+	stack := make([]*Node[K, V], 0, 64)
+	for root := sg.root; root != nil; {
+		less := sg.cmp(lo, root.key)
+		if less < 0 || less == 0 && include {
+			stack = append(stack, root)
+			root = root.lchild
+		} else {
+			root = root.rchild
+		}
+	}
+	for len(stack) > 0 {
+		node := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		if inRange != nil && !inRange(node.key) || !fn(node.key, node.value) {
+			return
+		}
+		for root := node.rchild; root != nil; root = root.lchild {
+			stack = append(stack, root)
+		}
+	}
+}
+
+func (sg *scapegoat[K, V]) descend(hi K, include bool, inRange func(key K) bool, fn func(key K, value V) bool) {
+	stack := make([]*Node[K, V], 0, 64)
+	for root := sg.root; root != nil; {
+		less := sg.cmp(hi, root.key)
+		if less > 0 || less == 0 && include {
+			stack = append(stack, root)
+			root = root.rchild
+		} else {
+			root = root.lchild
+		}
+	}
+	for len(stack) > 0 {
+		node := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		if inRange != nil && !inRange(node.key) || !fn(node.key, node.value) {
+			return
+		}
+		for root := node.lchild; root != nil; root = root.rchild {
+			stack = append(stack, root)
+		}
+	}
+}
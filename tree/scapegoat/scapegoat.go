@@ -0,0 +1,190 @@
+// Copyright (c) 2025 Tian ChunXing. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+// Package scapegoat 替罪羊树，节点中只有key、value、左右孩子和子树大小，不保存颜色、高度等平衡信息，插入删除不旋转。
+// 插入后节点的深度超过log(1/α)(N)时，沿路径向上找到第一个不满足α-重量平衡的祖先(替罪羊)，把它的子树重建为完全平衡的树；
+// 删除后元素个数少于历史最大值的α倍时重建整棵树。插入、删除均摊O(logN)，查找最坏O(logN)
+package scapegoat
+
+import (
+	"math"
+
+	"github.com/mrtcx/plusdata/codec"
+	"github.com/mrtcx/plusdata/tree"
+)
+
+var _ tree.Tree[int, int] = (*scapegoat[int, int])(nil)
+
+const _alpha = 0.7 //子树大小不超过父节点的α倍时认为平衡
+
+type scapegoat[K, V any] struct {
+	root    *Node[K, V]
+	cmp     tree.Comparator[K]
+	size    int
+	maxSize int //上次重建整棵树后元素个数的最大值
+	kc      codec.Codec[K]
+	vc      codec.Codec[V]
+}
+
+type Node[K, V any] struct {
+	key            K
+	value          V
+	lchild, rchild *Node[K, V]
+	size           int //子树中节点的数量
+}
+
+func New[K, V any](cmp tree.Comparator[K]) *scapegoat[K, V] {
+	return &scapegoat[K, V]{cmp: cmp}
+}
+
+func (sg *scapegoat[K, V]) Clean() {
+	sg.root, sg.size, sg.maxSize = nil, 0, 0
+}
+
+func (sg *scapegoat[K, V]) Size() int {
+	return sg.size
+}
+
+func (sg *scapegoat[K, V]) Empty() bool {
+	return sg.size == 0
+}
+
+// Insert 记录从根到新节点的路径(指向节点的指针的地址)，深度超过限制时重建替罪羊的子树
+func (sg *scapegoat[K, V]) Insert(key K, value V) {
+	path := make([]**Node[K, V], 0, 64)
+	slot := &sg.root
+	for *slot != nil {
+		node := *slot
+		less := sg.cmp(key, node.key)
+		if less == 0 {
+			node.value = value
+			return
+		}
+		path = append(path, slot)
+		if less < 0 {
+			slot = &node.lchild
+		} else {
+			slot = &node.rchild
+		}
+	}
+	*slot = &Node[K, V]{key: key, value: value, size: 1}
+	for _, p := range path {
+		(*p).size++
+	}
+	sg.size++
+	sg.maxSize = max(sg.maxSize, sg.size)
+	if float64(len(path)) <= math.Log(float64(sg.size))/math.Log(1/_alpha) {
+		return
+	}
+	for i := len(path) - 1; i >= 0; i-- {
+		node := *path[i]
+		if float64(max(sizeOf(node.lchild), sizeOf(node.rchild))) > _alpha*float64(node.size) {
+			*path[i] = sg.rebuild(node)
+			return
+		}
+	}
+}
+
+// Remove 与普通二叉搜索树相同，有两个孩子时用后继代替；元素个数少于maxSize的α倍时重建整棵树
+func (sg *scapegoat[K, V]) Remove(key K) {
+	path := make([]**Node[K, V], 0, 64)
+	slot := &sg.root
+	for *slot != nil {
+		less := sg.cmp(key, (*slot).key)
+		if less == 0 {
+			break
+		}
+		path = append(path, slot)
+		if less < 0 {
+			slot = &(*slot).lchild
+		} else {
+			slot = &(*slot).rchild
+		}
+	}
+	node := *slot
+	if node == nil {
+		return
+	}
+	for _, p := range path {
+		(*p).size--
+	}
+	if node.lchild == nil {
+		*slot = node.rchild
+	} else if node.rchild == nil {
+		*slot = node.lchild
+	} else {
+		node.size--
+		succ := &node.rchild
+		for (*succ).lchild != nil {
+			(*succ).size--
+			succ = &(*succ).lchild
+		}
+		node.key, node.value = (*succ).key, (*succ).value
+		*succ = (*succ).rchild
+	}
+	sg.size--
+	if float64(sg.size) < _alpha*float64(sg.maxSize) {
+		sg.root = sg.rebuild(sg.root)
+		sg.maxSize = sg.size
+	}
+}
+
+// 把子树重建为完全平衡的树，复用原有的节点
+func (sg *scapegoat[K, V]) rebuild(root *Node[K, V]) *Node[K, V] {
+	nodes := make([]*Node[K, V], 0, sizeOf(root))
+	stack := make([]*Node[K, V], 0, 64)
+	for root != nil || len(stack) > 0 {
+		for ; root != nil; root = root.lchild {
+			stack = append(stack, root)
+		}
+		root = stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		nodes = append(nodes, root)
+		root = root.rchild
+	}
+	return build(nodes)
+}
+
+func build[K, V any](nodes []*Node[K, V]) *Node[K, V] {
+	if len(nodes) == 0 {
+		return nil
+	}
+	mid := len(nodes) / 2
+	root := nodes[mid]
+	root.lchild, root.rchild = build(nodes[:mid]), build(nodes[mid+1:])
+	root.size = len(nodes)
+	return root
+}
+
+func (sg *scapegoat[K, V]) Get(key K) (V, bool) {
+	node := sg.findNode(key)
+	if node == nil {
+		var zero V
+		return zero, false
+	}
+	return node.value, true
+}
+
+// 查找key所在的节点
+func (sg *scapegoat[K, V]) findNode(key K) *Node[K, V] {
+	root := sg.root
+	for root != nil {
+		less := sg.cmp(key, root.key)
+		if less == 0 {
+			return root
+		} else if less < 0 {
+			root = root.lchild
+		} else {
+			root = root.rchild
+		}
+	}
+	return nil
+}
+
+func sizeOf[K, V any](node *Node[K, V]) int {
+	if node == nil {
+		return 0
+	}
+	return node.size
+}
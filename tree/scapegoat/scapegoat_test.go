@@ -0,0 +1,189 @@
+// Copyright (c) 2025 Tian ChunXing. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package scapegoat
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"testing"
+
+	"github.com/mrtcx/plusdata/internal/assert"
+	"github.com/mrtcx/plusdata/tree"
+)
+
+var intcmp = tree.OrderedComparator[int]
+
+func TestInsertRemove(t *testing.T) {
+	nums := []int{1, 2, 8, 1024}
+	for _, num := range nums {
+		tnum := num
+		t.Run(fmt.Sprintf("[num:%d]", tnum), func(t *testing.T) {
+			sg := New[int, int](intcmp)
+			exist := map[int]int{}
+			for i := 0; i < 4*tnum; i++ {
+				key := rand.Intn(tnum)
+				if rand.Intn(3) == 0 {
+					sg.Remove(key)
+					delete(exist, key)
+				} else {
+					sg.Insert(key, i)
+					exist[key] = i
+				}
+				if tnum < 1024 || i%64 == 0 {
+					checkScapegoat(t, sg)
+				}
+			}
+			checkScapegoat(t, sg)
+			assert.Equal(t, sg.Size(), len(exist))
+			for key := -1; key <= tnum; key++ {
+				value, ok := sg.Get(key)
+				_, exists := exist[key]
+				assert.Equal(t, ok, exists)
+				assert.Equal(t, value, exist[key])
+			}
+		})
+	}
+}
+
+// 检查key的顺序、子树大小和树高
+func checkScapegoat(t *testing.T, sg *scapegoat[int, int]) {
+	var check func(root *Node[int, int], lo, hi *int)
+	check = func(root *Node[int, int], lo, hi *int) {
+		if root == nil {
+			return
+		}
+		if lo != nil {
+			assert.Greater(t, root.key, *lo)
+		}
+		if hi != nil {
+			assert.Greater(t, *hi, root.key)
+		}
+		check(root.lchild, lo, &root.key)
+		check(root.rchild, &root.key, hi)
+		assert.Equal(t, root.size, sizeOf(root.lchild)+sizeOf(root.rchild)+1)
+	}
+	check(sg.root, nil, nil)
+	assert.Equal(t, sg.size, sizeOf(sg.root))
+	assert.Equal(t, height(sg.root) <= int(math.Log(float64(max(sg.maxSize, 1)))/math.Log(1/_alpha))+2, true)
+}
+
+func height(root *Node[int, int]) int {
+	if root == nil {
+		return 0
+	}
+	return max(height(root.lchild), height(root.rchild)) + 1
+}
+
+// 取得元素后修改树，前驱和后继按key查找，不受影响
+func TestElementAfterModify(t *testing.T) {
+	sg := New[int, int](intcmp)
+	for key := 10; key <= 50; key += 10 {
+		sg.Insert(key, key)
+	}
+	e := sg.Find(30)
+	sg.Insert(5, 5)
+	assert.Equal(t, e.Next().Key(), 40)
+	assert.Equal(t, e.Prev().Key(), 20)
+	sg.Remove(20)
+	sg.Remove(40)
+	assert.Equal(t, e.Next().Key(), 50)
+	assert.Equal(t, e.Prev().Key(), 10)
+	assert.Equal(t, e.Next().Next(), nil)
+	assert.Equal(t, e.Prev().Prev().Key(), 5)
+}
+
+func BenchmarkInsert(b *testing.B) {
+	testsizes := []int{100000, 500000, 1000000, 2000000}
+	testNames := []string{"10w", "50w", "100w", "200w"}
+	for i, v := range testsizes {
+		size := v
+		name := testNames[i]
+		b.Run(fmt.Sprintf("map-%s", name), func(b *testing.B) {
+			tr := make(map[int]interface{})
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				for j := 0; j < size; j++ {
+					tr[j] = nil
+				}
+			}
+		})
+		b.Run(fmt.Sprintf("scapegoat-%s", name), func(b *testing.B) {
+			tr := New[int, interface{}](intcmp)
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				for j := 0; j < size; j++ {
+					tr.Insert(j, nil)
+				}
+			}
+		})
+	}
+}
+
+func BenchmarkRemove(b *testing.B) {
+	testsizes := []int{100000, 500000, 1000000, 2000000}
+	testNames := []string{"10w", "50w", "100w", "200w"}
+	for i, v := range testsizes {
+		size := v
+		name := testNames[i]
+		b.Run(fmt.Sprintf("map-%s", name), func(b *testing.B) {
+			tr := make(map[int]interface{})
+			for j := 0; j < size; j++ {
+				tr[j] = nil
+			}
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				for j := 0; j < size; j++ {
+					delete(tr, j)
+				}
+			}
+		})
+		b.Run(fmt.Sprintf("scapegoat-%s", name), func(b *testing.B) {
+			tr := New[int, interface{}](intcmp)
+			for j := 0; j < size; j++ {
+				tr.Insert(j, nil)
+			}
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				for j := 0; j < size; j++ {
+					tr.Remove(j)
+				}
+			}
+		})
+	}
+}
+
+func BenchmarkGet(b *testing.B) {
+	testsizes := []int{100000, 500000, 1000000, 2000000}
+	testNames := []string{"10w", "50w", "100w", "200w"}
+	for i, v := range testsizes {
+		size := v
+		name := testNames[i]
+		b.Run(fmt.Sprintf("map-%s", name), func(b *testing.B) {
+			tr := make(map[int]interface{})
+			for j := 0; j < size; j++ {
+				tr[j] = nil
+			}
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				for j := 0; j < size; j++ {
+					_, _ = tr[j]
+				}
+			}
+		})
+		b.Run(fmt.Sprintf("scapegoat-%s", name), func(b *testing.B) {
+			tr := New[int, interface{}](intcmp)
+			for j := 0; j < size; j++ {
+				tr.Insert(j, nil)
+			}
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				for j := 0; j < size; j++ {
+					_, _ = tr.Get(j)
+				}
+			}
+		})
+	}
+}
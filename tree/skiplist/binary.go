@@ -0,0 +1,88 @@
+// Copyright (c) 2025 Tian ChunXing. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package skiplist
+
+import (
+	"bytes"
+	"io"
+
+	"github.com/mrtcx/plusdata/codec"
+)
+
+// SetCodec 设置编解码key和value的codec，int、int64、uint64、float64、string、[]byte可以不设置
+func (s *skipList[K, V]) SetCodec(kc codec.Codec[K], vc codec.Codec[V]) {
+	s.kc, s.vc = kc, vc
+}
+
+// Encode 按顺序把元素逐个编码写入w，编码的格式与树的种类无关，可以解码到其他种类的树中
+func (s *skipList[K, V]) Encode(w io.Writer) error {
+	kc, vc, err := s.codecs()
+	if err != nil {
+		return err
+	}
+	enc := codec.NewEncoder(w, codec.KindTree, s.size)
+	for node := s.head.nexts[0]; node != nil; node = node.nexts[0] {
+		codec.Write(enc, kc, node.key)
+		codec.Write(enc, vc, node.value)
+	}
+	return enc.Close()
+}
+
+// Decode 清空后从r中解码，重复的key按原有顺序保留，出错时树为空
+func (s *skipList[K, V]) Decode(r io.Reader) error {
+	s.Clean()
+	if err := s.decode(r); err != nil {
+		s.Clean()
+		return err
+	}
+	return nil
+}
+
+func (s *skipList[K, V]) decode(r io.Reader) error {
+	kc, vc, err := s.codecs()
+	if err != nil {
+		return err
+	}
+	dec, n, err := codec.NewDecoder(r, codec.KindTree)
+	if err != nil {
+		return err
+	}
+	for i := 0; i < n; i++ {
+		key, err := codec.Read(dec, kc)
+		if err != nil {
+			return err
+		}
+		value, err := codec.Read(dec, vc)
+		if err != nil {
+			return err
+		}
+		s.InsertDup(key, value)
+	}
+	return dec.Close()
+}
+
+func (s *skipList[K, V]) codecs() (codec.Codec[K], codec.Codec[V], error) {
+	kc, err := codec.Resolve(s.kc)
+	if err != nil {
+		return nil, nil, err
+	}
+	vc, err := codec.Resolve(s.vc)
+	if err != nil {
+		return nil, nil, err
+	}
+	return kc, vc, nil
+}
+
+func (s *skipList[K, V]) MarshalBinary() ([]byte, error) {
+	var buf bytes.Buffer
+	if err := s.Encode(&buf); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func (s *skipList[K, V]) UnmarshalBinary(data []byte) error {
+	return s.Decode(bytes.NewReader(data))
+}
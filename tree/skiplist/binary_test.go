@@ -0,0 +1,79 @@
+// Copyright (c) 2025 Tian ChunXing. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package skiplist
+
+import (
+	"fmt"
+	"math/rand"
+	"testing"
+
+	"github.com/mrtcx/plusdata/codec"
+	"github.com/mrtcx/plusdata/internal/assert"
+	"github.com/mrtcx/plusdata/internal/treetest"
+	"github.com/mrtcx/plusdata/tree"
+)
+
+// 重复的key编码后按原有顺序保留，各树共用的测试只覆盖不重复的key
+func TestMarshalBinaryDup(t *testing.T) {
+	for _, num := range []int{0, 1, 1000} {
+		tnum := num
+		t.Run(fmt.Sprintf("[num:%d]", tnum), func(t *testing.T) {
+			tr := New[int, int](tree.OrderedComparator[int])
+			for i := 0; i < tnum; i++ {
+				tr.InsertDup(rand.Intn(tnum/2+1), i) //重复的key按原有顺序保留
+			}
+			data, err := tr.MarshalBinary()
+			assert.Equal(t, err, nil)
+			tr2 := New[int, int](tree.OrderedComparator[int])
+			tr2.Insert(-1, -1)
+			assert.Equal(t, tr2.UnmarshalBinary(data), nil)
+			assert.Equal(t, treetest.Dump[int, int](tr2), treetest.Dump[int, int](tr))
+			assert.Equal(t, tr2.Size(), tr.Size())
+			if tnum > 0 {
+				assert.NotEqual(t, tr2.UnmarshalBinary(data[:len(data)-1]), nil)
+				assert.Equal(t, tr2.Size(), 0)
+			}
+		})
+	}
+}
+
+func TestEncodeCodec(t *testing.T) {
+	strcmp := tree.OrderedComparator[string]
+	tr := New[string, []int](strcmp)
+	tr.Insert("a", []int{1})
+	_, err := tr.MarshalBinary()
+	assert.Equal(t, err, codec.ErrNoCodec)
+	tr.SetCodec(nil, intsCodec{})
+	tr.Insert("b", []int{2, 3})
+	data, err := tr.MarshalBinary()
+	assert.Equal(t, err, nil)
+	tr2 := New[string, []int](strcmp)
+	tr2.SetCodec(codec.StringCodec{}, intsCodec{})
+	assert.Equal(t, tr2.UnmarshalBinary(data), nil)
+	assert.Equal(t, treetest.Dump[string, []int](tr2), "[a:[1] b:[2 3]]")
+}
+
+// []int编码为个数加每个元素
+type intsCodec struct{}
+
+func (intsCodec) Append(buf []byte, v []int) []byte {
+	buf = codec.IntCodec{}.Append(buf, len(v))
+	for _, i := range v {
+		buf = codec.IntCodec{}.Append(buf, i)
+	}
+	return buf
+}
+
+func (intsCodec) Decode(buf []byte) ([]int, int) {
+	l, n := codec.IntCodec{}.Decode(buf)
+	if n <= 0 || l < 0 || len(buf) < 8+8*l {
+		return nil, 0
+	}
+	v := make([]int, l)
+	for i := range v {
+		v[i], _ = codec.IntCodec{}.Decode(buf[8+8*i:])
+	}
+	return v, 8 + 8*l
+}
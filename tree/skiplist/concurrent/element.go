@@ -0,0 +1,128 @@
+// Copyright (c) 2025 Tian ChunXing. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package concurrent
+
+import "github.com/mrtcx/plusdata/tree"
+
+// element 元素被其他协程删除后，Value返回删除前读到的值，Next仍然可以继续向后遍历
+type element[K, V any] struct {
+	s     *skipList[K, V]
+	node  *Node[K, V]
+	value V
+}
+
+func (s *skipList[K, V]) newElement(n *Node[K, V]) tree.Element[K, V] {
+	if n == nil {
+		return nil
+	}
+	v := n.value.Load()
+	if v == nil {
+		return nil
+	}
+	return &element[K, V]{s: s, node: n, value: *v}
+}
+
+func (e *element[K, V]) Key() K {
+	return e.node.key
+}
+
+func (e *element[K, V]) Value() V {
+	if v := e.node.value.Load(); v != nil {
+		e.value = *v
+	}
+	return e.value
+}
+
+// SetValue 元素已经被删除时不生效
+func (e *element[K, V]) SetValue(value V) {
+	for {
+		old := e.node.value.Load()
+		if old == nil || e.node.value.CompareAndSwap(old, &value) {
+			return
+		}
+	}
+}
+
+func (e *element[K, V]) Next() tree.Element[K, V] {
+	for n := e.s.next(e.node); n != nil; n = e.s.next(n) {
+		if ne := e.s.newElement(n); ne != nil {
+			return ne
+		}
+	}
+	return nil
+}
+
+// Prev 没有前驱指针，按key重新查找，O(logN)
+func (e *element[K, V]) Prev() tree.Element[K, V] {
+	return e.s.Prev(e.node.key)
+}
+
+func (s *skipList[K, V]) Find(key K) tree.Element[K, V] {
+	return s.newElement(s.findNode(key))
+}
+
+func (s *skipList[K, V]) Left() tree.Element[K, V] {
+	for n := s.next(s.head); n != nil; n = s.next(n) {
+		if e := s.newElement(n); e != nil {
+			return e
+		}
+	}
+	return nil
+}
+
+func (s *skipList[K, V]) Right() tree.Element[K, V] {
+	for {
+		last := s.last()
+		if last == nil {
+			return nil
+		}
+		if e := s.newElement(last); e != nil {
+			return e
+		}
+	}
+}
+
+// 最后一个未删除的节点
+func (s *skipList[K, V]) last() *Node[K, V] {
+	pred, last := s.head, (*Node[K, V])(nil)
+	for i := int(s.level.Load()) - 1; i >= 0; i-- {
+		for cur := pred.nexts[i].Load().next; cur != nil; {
+			curRef := cur.nexts[i].Load()
+			if !curRef.marked {
+				pred = cur
+				if !cur.deleted() {
+					last = cur
+				}
+			}
+			cur = curRef.next
+		}
+	}
+	return last
+}
+
+func (s *skipList[K, V]) Prev(key K) tree.Element[K, V] {
+	for {
+		n := s.findPrev(key, false)
+		if n == nil {
+			return nil
+		}
+		if e := s.newElement(n); e != nil {
+			return e
+		}
+	}
+}
+
+func (s *skipList[K, V]) Next(key K) tree.Element[K, V] {
+	start := s.findPrev(key, true)
+	if start == nil {
+		start = s.head
+	}
+	for n := s.next(start); n != nil; n = s.next(n) {
+		if e := s.newElement(n); e != nil {
+			return e
+		}
+	}
+	return nil
+}
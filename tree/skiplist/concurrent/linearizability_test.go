@@ -0,0 +1,159 @@
+// Copyright (c) 2025 Tian ChunXing. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package concurrent
+
+import (
+	"fmt"
+	"math/rand"
+	"sort"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/mrtcx/plusdata/internal/assert"
+)
+
+const (
+	opInsert = iota
+	opRemove
+	opGet
+)
+
+// 一次操作的调用和返回时间(全局逻辑时钟)，以及参数和结果
+type event struct {
+	op        int
+	key       int
+	value     int // Insert的参数，Get的结果
+	ok        bool
+	call, ret int64
+}
+
+// 单个key的状态，不同key的操作互不影响，可以分开检查
+type regState struct {
+	present bool
+	value   int
+}
+
+// 检查单个key的历史是否可线性化(Wing & Gong的搜索，按已线性化的操作集合和状态去重)
+// 一个操作可以排在剩余操作的最前面，当且仅当它的调用早于剩余操作中最早的返回
+func linearizable(events []event) bool {
+	if len(events) > 64 {
+		panic("too many events for one key")
+	}
+	sort.Slice(events, func(i, j int) bool { return events[i].call < events[j].call })
+	all := uint64(1)<<len(events) - 1
+	type memoKey struct {
+		done uint64
+		st   regState
+	}
+	seen := map[memoKey]bool{}
+	var search func(done uint64, st regState) bool
+	search = func(done uint64, st regState) bool {
+		if done == all {
+			return true
+		}
+		minRet := int64(1<<63 - 1)
+		for i, e := range events {
+			if done&(1<<i) == 0 && e.ret < minRet {
+				minRet = e.ret
+			}
+		}
+		for i, e := range events {
+			if done&(1<<i) != 0 || e.call > minRet {
+				continue
+			}
+			next := st
+			switch e.op {
+			case opInsert:
+				next = regState{present: true, value: e.value}
+			case opRemove:
+				next = regState{}
+			case opGet:
+				if e.ok != st.present || e.ok && e.value != st.value {
+					continue
+				}
+			}
+			k := memoKey{done | 1<<i, next}
+			if seen[k] {
+				continue
+			}
+			seen[k] = true
+			if search(done|1<<i, next) {
+				return true
+			}
+		}
+		return false
+	}
+	return search(0, regState{})
+}
+
+func TestLinearizableChecker(t *testing.T) {
+	// Insert返回后才开始的Get必须看到它
+	bad := []event{
+		{op: opInsert, value: 1, call: 1, ret: 2},
+		{op: opGet, ok: false, call: 3, ret: 4},
+	}
+	assert.Equal(t, linearizable(bad), false)
+	// 与Insert重叠的Get两种结果都可以
+	good := []event{
+		{op: opInsert, value: 1, call: 1, ret: 4},
+		{op: opGet, ok: false, call: 2, ret: 3},
+		{op: opGet, ok: true, value: 1, call: 2, ret: 5},
+	}
+	assert.Equal(t, linearizable(good), true)
+	// 已经读到新值后，之后的读不能再读到旧值
+	stale := []event{
+		{op: opInsert, value: 1, call: 1, ret: 2},
+		{op: opInsert, value: 2, call: 3, ret: 10},
+		{op: opGet, ok: true, value: 2, call: 4, ret: 5},
+		{op: opGet, ok: true, value: 1, call: 6, ret: 7},
+	}
+	assert.Equal(t, linearizable(stale), false)
+}
+
+func TestLinearizable(t *testing.T) {
+	const rounds, workers, opsPerWorker, keyNum = 200, 4, 24, 3
+	for round := 0; round < rounds; round++ {
+		s := New[int, int](intcmp)
+		var clock atomic.Int64
+		histories := make([][]event, workers)
+		var wg sync.WaitGroup
+		for w := 0; w < workers; w++ {
+			wg.Add(1)
+			go func(id int) {
+				defer wg.Done()
+				r := rand.New(rand.NewSource(int64(round*workers + id)))
+				for i := 0; i < opsPerWorker; i++ {
+					e := event{op: r.Intn(3), key: r.Intn(keyNum), value: id*1000 + i}
+					e.call = clock.Add(1)
+					switch e.op {
+					case opInsert:
+						s.Insert(e.key, e.value)
+					case opRemove:
+						s.Remove(e.key)
+					case opGet:
+						e.value, e.ok = s.Get(e.key)
+					}
+					e.ret = clock.Add(1)
+					histories[id] = append(histories[id], e)
+				}
+			}(w)
+		}
+		wg.Wait()
+
+		byKey := map[int][]event{}
+		for _, h := range histories {
+			for _, e := range h {
+				byKey[e.key] = append(byKey[e.key], e)
+			}
+		}
+		for key, events := range byKey {
+			if !linearizable(events) {
+				t.Fatalf("round %d key %d: history is not linearizable: %s", round, key, fmt.Sprint(events))
+			}
+		}
+		checkList(t, s)
+	}
+}
@@ -0,0 +1,65 @@
+// Copyright (c) 2025 Tian ChunXing. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package concurrent
+
+import "github.com/mrtcx/plusdata/tree"
+
+// AscendRange 正序遍历区间[lo, hi)内的元素, fn返回false时停止, bound可指定区间开闭
+func (s *skipList[K, V]) AscendRange(lo, hi K, fn func(key K, value V) bool, bound ...tree.Bound) {
+	b := tree.RangeBound(bound)
+	s.ascend(lo, b.LoInclusive(), func(key K) bool {
+		less := s.cmp(key, hi)
+		return less < 0 || less == 0 && b.HiInclusive()
+	}, fn)
+}
+
+// DescendRange 倒序遍历区间[lo, hi)内的元素, fn返回false时停止, bound可指定区间开闭
+func (s *skipList[K, V]) DescendRange(lo, hi K, fn func(key K, value V) bool, bound ...tree.Bound) {
+	b := tree.RangeBound(bound)
+	s.descend(hi, b.HiInclusive(), func(key K) bool {
+		less := s.cmp(key, lo)
+		return less > 0 || less == 0 && b.LoInclusive()
+	}, fn)
+}
+
+// AscendGreaterOrEqual 正序遍历key>=lo的元素
+func (s *skipList[K, V]) AscendGreaterOrEqual(lo K, fn func(key K, value V) bool) {
+	s.ascend(lo, true, nil, fn)
+}
+
+// DescendLessOrEqual 倒序遍历key<=hi的元素
+func (s *skipList[K, V]) DescendLessOrEqual(hi K, fn func(key K, value V) bool) {
+	s.descend(hi, true, nil, fn)
+}
+
+// 定位后沿第0层遍历，跳过已删除的节点
+func (s *skipList[K, V]) ascend(lo K, include bool, inRange func(key K) bool, fn func(key K, value V) bool) {
+	start := s.findPrev(lo, !include)
+	if start == nil {
+		start = s.head
+	}
+	for n := s.next(start); n != nil; n = s.next(n) {
+		v := n.value.Load()
+		if v == nil {
+			continue
+		}
+		if inRange != nil && !inRange(n.key) || !fn(n.key, *v) {
+			return
+		}
+	}
+}
+
+// 没有前驱指针，每一步都重新查找前驱，O(logN)
+func (s *skipList[K, V]) descend(hi K, include bool, inRange func(key K) bool, fn func(key K, value V) bool) {
+	for n := s.findPrev(hi, include); n != nil; n = s.findPrev(n.key, false) {
+		v := n.value.Load()
+		if v == nil {
+			continue
+		}
+		if inRange != nil && !inRange(n.key) || !fn(n.key, *v) {
+			return
+		}
+	}
+}
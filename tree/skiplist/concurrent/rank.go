@@ -0,0 +1,30 @@
+// Copyright (c) 2025 Tian ChunXing. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package concurrent
+
+import "github.com/mrtcx/plusdata/tree"
+
+// Rank 返回小于key的元素个数，并发修改时维护跨度的代价太高，沿第0层计数，O(N)
+func (s *skipList[K, V]) Rank(key K) int {
+	rank := 0
+	for n := s.next(s.head); n != nil && s.cmp(n.key, key) < 0; n = s.next(n) {
+		rank++
+	}
+	return rank
+}
+
+// Select 返回顺序遍历中下标为k的元素(从0开始)，k越界时返回nil，O(N)
+func (s *skipList[K, V]) Select(k int) tree.Element[K, V] {
+	if k < 0 {
+		return nil
+	}
+	for n := s.next(s.head); n != nil; n = s.next(n) {
+		if k == 0 {
+			return s.newElement(n)
+		}
+		k--
+	}
+	return nil
+}
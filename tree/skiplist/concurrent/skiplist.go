@@ -0,0 +1,282 @@
+// Copyright (c) 2025 Tian ChunXing. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+// Package concurrent 并发安全的无锁跳表
+//
+// 每层的next指针和删除标记放在同一个不可变的ref中，通过CAS整体替换(即带标记的指针)。
+// Insert、Remove基于CAS实现无锁，Get不修改任何指针，同样是lock-free的，
+// 遍历是弱一致的: 遍历过程中其他协程的修改可能看到，也可能看不到，但不会重复或者乱序。
+package concurrent
+
+import (
+	"math/rand"
+	"sync/atomic"
+
+	"github.com/mrtcx/plusdata/tree"
+)
+
+var _ tree.Tree[int, int] = (*skipList[int, int])(nil)
+
+const (
+	_maxLevel    = 32     // 最大层数
+	_probability = 0x4000 // 25%的概率阈值 (0xFFFF * 0.25)
+)
+
+type skipList[K, V any] struct {
+	cmp   tree.Comparator[K]
+	head  *Node[K, V]
+	size  atomic.Int64
+	level atomic.Int32 //当前的最大层数，只增不减，查找从这一层开始；节点链接前先增大，保证不超过它
+}
+
+type Node[K, V any] struct {
+	key   K
+	value atomic.Pointer[V] //为nil时表示已经被删除(逻辑删除)
+	nexts []atomic.Pointer[ref[K, V]]
+}
+
+// ref 带删除标记的next指针，创建后不再修改
+// marked为true表示所在的节点已经被删除，它在这一层的next不能再修改
+type ref[K, V any] struct {
+	next   *Node[K, V]
+	marked bool
+}
+
+func New[K, V any](cmp tree.Comparator[K]) *skipList[K, V] {
+	s := &skipList[K, V]{
+		cmp:  cmp,
+		head: newNode[K, V](_maxLevel),
+	}
+	s.level.Store(1)
+	return s
+}
+
+func newNode[K, V any](level int) *Node[K, V] {
+	n := &Node[K, V]{nexts: make([]atomic.Pointer[ref[K, V]], level)}
+	for i := range n.nexts {
+		n.nexts[i].Store(&ref[K, V]{})
+	}
+	return n
+}
+
+func (n *Node[K, V]) deleted() bool {
+	return n.value.Load() == nil
+}
+
+// Clean 逐个删除元素，O(N)，与并发的Insert交错时，这些Insert的元素可能被保留
+func (s *skipList[K, V]) Clean() {
+	for n := s.head.nexts[0].Load().next; n != nil; n = n.nexts[0].Load().next {
+		s.Remove(n.key)
+	}
+}
+
+func (s *skipList[K, V]) Size() int {
+	return int(s.size.Load())
+}
+
+func (s *skipList[K, V]) Empty() bool {
+	return s.Size() == 0
+}
+
+func (s *skipList[K, V]) Insert(key K, value V) {
+	var preds, succs [_maxLevel]*Node[K, V]
+	var predRefs [_maxLevel]*ref[K, V]
+	level := randomLevel()
+	// 先增大最大层数，之后的查找覆盖新节点的每一层
+	for cur := s.level.Load(); int(cur) < level && !s.level.CompareAndSwap(cur, int32(level)); cur = s.level.Load() {
+	}
+	for {
+		if s.find(key, &preds, &predRefs, &succs) {
+			// key已存在，替换value
+			n := succs[0]
+			old := n.value.Load()
+			if old == nil {
+				// 正在被删除，帮助标记后重新查找
+				s.mark(n)
+				continue
+			}
+			if n.value.CompareAndSwap(old, &value) {
+				return
+			}
+			continue
+		}
+		n := newNode[K, V](level)
+		n.key = key
+		n.value.Store(&value)
+		for i := 0; i < level; i++ {
+			n.nexts[i].Store(&ref[K, V]{next: succs[i]})
+		}
+		// 第0层链接成功即插入成功，上层只是索引
+		if !preds[0].nexts[0].CompareAndSwap(predRefs[0], &ref[K, V]{next: n}) {
+			continue
+		}
+		s.size.Add(1)
+		s.link(n, level, &preds, &predRefs, &succs)
+		return
+	}
+}
+
+// 链接n的上层，n被删除时停止
+func (s *skipList[K, V]) link(n *Node[K, V], level int, preds *[_maxLevel]*Node[K, V], predRefs *[_maxLevel]*ref[K, V], succs *[_maxLevel]*Node[K, V]) {
+	for i := 1; i < level; i++ {
+		for {
+			r := n.nexts[i].Load()
+			if r.marked {
+				return
+			}
+			if r.next != succs[i] && !n.nexts[i].CompareAndSwap(r, &ref[K, V]{next: succs[i]}) {
+				continue
+			}
+			if preds[i].nexts[i].CompareAndSwap(predRefs[i], &ref[K, V]{next: n}) {
+				break
+			}
+			if !s.find(n.key, preds, predRefs, succs) || succs[0] != n {
+				return
+			}
+		}
+	}
+}
+
+func (s *skipList[K, V]) Remove(key K) {
+	var preds, succs [_maxLevel]*Node[K, V]
+	var predRefs [_maxLevel]*ref[K, V]
+	for {
+		if !s.find(key, &preds, &predRefs, &succs) {
+			return
+		}
+		n := succs[0]
+		old := n.value.Load()
+		if old == nil {
+			// 已经被其他协程删除
+			s.mark(n)
+			return
+		}
+		// value置为nil是删除的线性化点，只有成功的协程负责计数
+		if n.value.CompareAndSwap(old, nil) {
+			s.size.Add(-1)
+			s.mark(n)
+			// 查找时顺便把标记的节点从各层摘除
+			s.find(key, &preds, &predRefs, &succs)
+			return
+		}
+	}
+}
+
+// 从上到下标记n的每一层，标记后n的next不再改变，查找时会把它摘除
+func (s *skipList[K, V]) mark(n *Node[K, V]) {
+	for i := len(n.nexts) - 1; i >= 0; i-- {
+		for {
+			r := n.nexts[i].Load()
+			if r.marked || n.nexts[i].CompareAndSwap(r, &ref[K, V]{next: r.next, marked: true}) {
+				break
+			}
+		}
+	}
+}
+
+// 从当前的最大层数开始，查找每一层最后一个<key的节点preds和它的下一个节点succs，predRefs为preds当时的ref，用于CAS。
+// 途中遇到标记的节点就把它摘除，摘除失败(前驱变化)时从头重新查找
+func (s *skipList[K, V]) find(key K, preds *[_maxLevel]*Node[K, V], predRefs *[_maxLevel]*ref[K, V], succs *[_maxLevel]*Node[K, V]) bool {
+retry:
+	pred := s.head
+	// Insert在查找前已经增大了level，新节点的每一层都在查找的范围内
+	for i := int(s.level.Load()) - 1; i >= 0; i-- {
+		predRef := pred.nexts[i].Load()
+		if predRef.marked {
+			goto retry
+		}
+		cur := predRef.next
+		for cur != nil {
+			curRef := cur.nexts[i].Load()
+			if curRef.marked {
+				r := &ref[K, V]{next: curRef.next}
+				if !pred.nexts[i].CompareAndSwap(predRef, r) {
+					goto retry
+				}
+				predRef, cur = r, curRef.next
+				continue
+			}
+			if s.cmp(cur.key, key) >= 0 {
+				break
+			}
+			pred, predRef, cur = cur, curRef, curRef.next
+		}
+		preds[i], predRefs[i], succs[i] = pred, predRef, cur
+	}
+	return succs[0] != nil && s.cmp(succs[0].key, key) == 0
+}
+
+func (s *skipList[K, V]) Get(key K) (value V, exist bool) {
+	if n := s.findNode(key); n != nil {
+		if v := n.value.Load(); v != nil {
+			return *v, true
+		}
+	}
+	return value, false
+}
+
+// 从当前的最大层数开始，不修改指针，跳过已删除的节点，是lock-free的：并发的插入可能让查找多读取节点
+func (s *skipList[K, V]) findNode(key K) *Node[K, V] {
+	pred := s.head
+	var cur *Node[K, V]
+	for i := int(s.level.Load()) - 1; i >= 0; i-- {
+		cur = pred.nexts[i].Load().next
+		for cur != nil {
+			curRef := cur.nexts[i].Load()
+			if curRef.marked {
+				cur = curRef.next
+				continue
+			}
+			if s.cmp(cur.key, key) >= 0 {
+				break
+			}
+			pred, cur = cur, curRef.next
+		}
+	}
+	if cur != nil && s.cmp(cur.key, key) == 0 {
+		return cur
+	}
+	return nil
+}
+
+// 最后一个<key(upper为true时<=key)且未删除的节点，不存在时返回nil
+func (s *skipList[K, V]) findPrev(key K, upper bool) *Node[K, V] {
+	pred := s.head
+	for i := int(s.level.Load()) - 1; i >= 0; i-- {
+		cur := pred.nexts[i].Load().next
+		for cur != nil {
+			curRef := cur.nexts[i].Load()
+			if curRef.marked {
+				cur = curRef.next
+				continue
+			}
+			if c := s.cmp(cur.key, key); c > 0 || c == 0 && !upper {
+				break
+			}
+			if !cur.deleted() {
+				pred = cur
+			}
+			cur = curRef.next
+		}
+	}
+	if pred == s.head {
+		return nil
+	}
+	return pred
+}
+
+// n之后第一个未删除的节点，n被删除后它的next仍然指向原来的后继，所以可以继续向后
+func (s *skipList[K, V]) next(n *Node[K, V]) *Node[K, V] {
+	for n = n.nexts[0].Load().next; n != nil && n.deleted(); n = n.nexts[0].Load().next {
+	}
+	return n
+}
+
+func randomLevel() int {
+	level := 1
+	for rand.Int31n(0xFFFF) < _probability && level < _maxLevel {
+		level++
+	}
+	return level
+}
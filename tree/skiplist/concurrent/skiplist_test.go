@@ -0,0 +1,288 @@
+// Copyright (c) 2025 Tian ChunXing. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package concurrent
+
+import (
+	"fmt"
+	"math/rand"
+	"sort"
+	"sync"
+	"testing"
+
+	"github.com/mrtcx/plusdata/internal/assert"
+	"github.com/mrtcx/plusdata/tree"
+)
+
+var intcmp = tree.OrderedComparator[int]
+
+// 静止状态下检查: 第0层严格有序且没有已删除的节点，数量等于Size，上层的未删除节点有序，
+// 节点的层数不超过最大层数，查找从最大层数开始不会漏掉节点
+func checkList(t *testing.T, s *skipList[int, int]) []int {
+	keys := []int{}
+	level := int(s.level.Load())
+	for i := level; i < _maxLevel; i++ {
+		assert.Equal(t, s.head.nexts[i].Load().next == nil, true)
+	}
+	for n := s.head.nexts[0].Load().next; n != nil; n = n.nexts[0].Load().next {
+		assert.Greater(t, level+1, len(n.nexts))
+		assert.Equal(t, n.deleted(), false)
+		assert.Equal(t, n.nexts[0].Load().marked, false)
+		if len(keys) > 0 {
+			assert.Greater(t, n.key, keys[len(keys)-1])
+		}
+		keys = append(keys, n.key)
+	}
+	assert.Equal(t, len(keys), s.Size())
+	for i := 1; i < _maxLevel; i++ {
+		prev := -1 << 62
+		for n := s.head.nexts[i].Load().next; n != nil; n = n.nexts[i].Load().next {
+			if !n.deleted() {
+				assert.Greater(t, n.key, prev)
+				prev = n.key
+			}
+		}
+	}
+	return keys
+}
+
+func sortedKeys(model map[int]int) []int {
+	keys := make([]int, 0, len(model))
+	for k := range model {
+		keys = append(keys, k)
+	}
+	sort.Ints(keys)
+	return keys
+}
+
+func TestInsertRemove(t *testing.T) {
+	s := New[int, int](intcmp)
+	model := map[int]int{}
+	for i := 0; i < 5000; i++ {
+		k := rand.Intn(1000)
+		if rand.Intn(3) == 0 {
+			s.Remove(k)
+			delete(model, k)
+		} else {
+			s.Insert(k, i)
+			model[k] = i
+		}
+	}
+	keys := sortedKeys(model)
+	assert.Equal(t, fmt.Sprint(checkList(t, s)), fmt.Sprint(keys))
+	for k, v := range model {
+		got, ok := s.Get(k)
+		assert.Equal(t, ok, true)
+		assert.Equal(t, got, v)
+	}
+	_, ok := s.Get(-1)
+	assert.Equal(t, ok, false)
+	s.Clean()
+	assert.Equal(t, s.Empty(), true)
+	checkList(t, s)
+}
+
+func TestElement(t *testing.T) {
+	s := New[int, int](intcmp)
+	assert.Equal(t, s.Left(), nil)
+	assert.Equal(t, s.Right(), nil)
+	for i := 1; i <= 100; i++ {
+		s.Insert(i*2, i)
+	}
+	assert.Equal(t, s.Left().Key(), 2)
+	assert.Equal(t, s.Right().Key(), 200)
+	assert.Equal(t, s.Find(3), nil)
+	assert.Equal(t, s.Find(4).Value(), 2)
+	assert.Equal(t, s.Prev(2), nil)
+	assert.Equal(t, s.Prev(5).Key(), 4)
+	assert.Equal(t, s.Next(4).Key(), 6)
+	assert.Equal(t, s.Next(200), nil)
+
+	i := 0
+	for e := s.Left(); e != nil; e = e.Next() {
+		i++
+		assert.Equal(t, e.Key(), i*2)
+		e.SetValue(-i)
+	}
+	assert.Equal(t, i, 100)
+	for e := s.Right(); e != nil; e = e.Prev() {
+		assert.Equal(t, e.Value(), -i)
+		i--
+	}
+
+	// 元素被删除后仍然可以继续向后遍历
+	e := s.Find(10)
+	s.Remove(10)
+	s.Remove(12)
+	assert.Equal(t, e.Value(), -5)
+	assert.Equal(t, e.Next().Key(), 14)
+	e.SetValue(1)
+	_, ok := s.Get(10)
+	assert.Equal(t, ok, false)
+}
+
+func TestRangeRank(t *testing.T) {
+	s := New[int, int](intcmp)
+	keys := []int{}
+	for i := 0; i < 200; i += 2 {
+		s.Insert(i, i)
+		keys = append(keys, i)
+	}
+	for _, b := range []tree.Bound{tree.ClosedOpen, tree.Closed, tree.OpenClosed, tree.Open} {
+		for _, r := range [][2]int{{-5, 300}, {0, 198}, {1, 2}, {10, 10}, {51, 149}, {100, 0}} {
+			lo, hi := r[0], r[1]
+			want := []int{}
+			for _, k := range keys {
+				if (k > lo || k == lo && b.LoInclusive()) && (k < hi || k == hi && b.HiInclusive()) {
+					want = append(want, k)
+				}
+			}
+			got := []int{}
+			s.AscendRange(lo, hi, func(key, value int) bool {
+				got = append(got, key)
+				return true
+			}, b)
+			assert.Equal(t, fmt.Sprint(got), fmt.Sprint(want))
+			got = got[:0]
+			s.DescendRange(lo, hi, func(key, value int) bool {
+				got = append([]int{key}, got...)
+				return true
+			}, b)
+			assert.Equal(t, fmt.Sprint(got), fmt.Sprint(want))
+		}
+	}
+	for i, k := range keys {
+		assert.Equal(t, s.Rank(k), i)
+		assert.Equal(t, s.Rank(k+1), i+1)
+		assert.Equal(t, s.Select(i).Key(), k)
+	}
+	assert.Equal(t, s.Select(len(keys)), nil)
+	assert.Equal(t, s.Select(-1), nil)
+}
+
+// 每个写协程只修改自己的key(key%writers==id)，结束后结果是确定的；读协程同时查询和遍历
+func TestConcurrentStress(t *testing.T) {
+	const writers, readers, ops, keyNum = 8, 4, 20000, 2000
+	s := New[int, int](intcmp)
+	models := make([]map[int]int, writers)
+	var wg sync.WaitGroup
+	done := make(chan struct{})
+	for w := 0; w < writers; w++ {
+		models[w] = map[int]int{}
+		wg.Add(1)
+		go func(id int, model map[int]int) {
+			defer wg.Done()
+			r := rand.New(rand.NewSource(int64(id)))
+			for i := 0; i < ops; i++ {
+				k := r.Intn(keyNum/writers)*writers + id
+				switch r.Intn(4) {
+				case 0:
+					s.Remove(k)
+					delete(model, k)
+				case 1:
+					if e := s.Find(k); e != nil {
+						e.SetValue(-i)
+						model[k] = -i
+					}
+				default:
+					s.Insert(k, i)
+					model[k] = i
+				}
+			}
+		}(w, models[w])
+	}
+	var rwg sync.WaitGroup
+	for rd := 0; rd < readers; rd++ {
+		rwg.Add(1)
+		go func() {
+			defer rwg.Done()
+			for {
+				select {
+				case <-done:
+					return
+				default:
+				}
+				// 弱一致的遍历也必须严格有序
+				prev := -1
+				s.AscendGreaterOrEqual(0, func(key, value int) bool {
+					if key <= prev {
+						t.Errorf("ascend out of order: %d after %d", key, prev)
+					}
+					prev = key
+					return true
+				})
+				s.Get(rand.Intn(keyNum))
+				s.Next(rand.Intn(keyNum))
+				s.Prev(rand.Intn(keyNum))
+			}
+		}()
+	}
+	wg.Wait()
+	close(done)
+	rwg.Wait()
+
+	all := map[int]int{}
+	for _, m := range models {
+		for k, v := range m {
+			all[k] = v
+		}
+	}
+	assert.Equal(t, fmt.Sprint(checkList(t, s)), fmt.Sprint(sortedKeys(all)))
+	for k, v := range all {
+		got, _ := s.Get(k)
+		assert.Equal(t, got, v)
+	}
+}
+
+// 所有协程争抢少量的key，Size最终与实际元素个数一致
+func TestConcurrentContention(t *testing.T) {
+	s := New[int, int](intcmp)
+	var wg sync.WaitGroup
+	for w := 0; w < 16; w++ {
+		wg.Add(1)
+		go func(id int) {
+			defer wg.Done()
+			r := rand.New(rand.NewSource(int64(id)))
+			for i := 0; i < 5000; i++ {
+				k := r.Intn(16)
+				if r.Intn(2) == 0 {
+					s.Remove(k)
+				} else {
+					s.Insert(k, id)
+				}
+			}
+		}(w)
+	}
+	wg.Wait()
+	checkList(t, s)
+}
+
+func BenchmarkParallelGet(b *testing.B) {
+	s := New[int, int](intcmp)
+	for i := 0; i < 100000; i++ {
+		s.Insert(i, i)
+	}
+	b.ResetTimer()
+	b.RunParallel(func(pb *testing.PB) {
+		r := rand.New(rand.NewSource(rand.Int63()))
+		for pb.Next() {
+			s.Get(r.Intn(100000))
+		}
+	})
+}
+
+func BenchmarkParallelInsertRemove(b *testing.B) {
+	s := New[int, int](intcmp)
+	b.RunParallel(func(pb *testing.PB) {
+		r := rand.New(rand.NewSource(rand.Int63()))
+		for pb.Next() {
+			k := r.Intn(100000)
+			if r.Intn(2) == 0 {
+				s.Insert(k, k)
+			} else {
+				s.Remove(k)
+			}
+		}
+	})
+}
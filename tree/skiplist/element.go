@@ -6,73 +6,79 @@ package skiplist
 
 import "github.com/mrtcx/plusdata/tree"
 
-type element struct {
-	sk   *skipList
-	node *Node
+type element[K, V any] struct {
+	sk   *skipList[K, V]
+	node *Node[K, V]
 }
 
-func (e *element) Key() interface{} {
+func (e *element[K, V]) Key() K {
 	return e.node.key
 }
 
-func (e *element) Value() interface{} {
+func (e *element[K, V]) Value() V {
 	return e.node.value
 }
 
-func (e *element) SetValue(value interface{}) {
+func (e *element[K, V]) SetValue(value V) {
 	e.node.value = value
 }
 
-func (e *element) Prev() tree.Element {
-	return e.sk.Prev(e.node.key)
+func (e *element[K, V]) Prev() tree.Element[K, V] {
+	if e.node.prev == nil {
+		return nil
+	}
+	return &element[K, V]{
+		sk:   e.sk,
+		node: e.node.prev,
+	}
 }
 
-func (e *element) Next() tree.Element {
+func (e *element[K, V]) Next() tree.Element[K, V] {
 	if e.node.nexts[0] == nil {
 		return nil
 	}
-	return &element{
+	return &element[K, V]{
 		sk:   e.sk,
 		node: e.node.nexts[0],
 	}
 }
 
-func (s *skipList) Find(key interface{}) tree.Element {
+func (s *skipList[K, V]) Find(key K) tree.Element[K, V] {
 	node := s.findNode(key)
 	if node == nil {
 		return nil
 	}
-	return &element{sk: s, node: node}
+	return &element[K, V]{sk: s, node: node}
 }
 
-func (s *skipList) Left() tree.Element {
+func (s *skipList[K, V]) Left() tree.Element[K, V] {
 	node := s.frontNode()
 	if node == nil {
 		return nil
 	}
-	return &element{sk: s, node: node}
+	return &element[K, V]{sk: s, node: node}
 }
 
-func (s *skipList) Right() tree.Element {
+func (s *skipList[K, V]) Right() tree.Element[K, V] {
 	node := s.backNode()
 	if node == nil {
 		return nil
 	}
-	return &element{sk: s, node: node}
+	return &element[K, V]{sk: s, node: node}
 }
 
-func (s *skipList) Prev(key interface{}) tree.Element {
+func (s *skipList[K, V]) Prev(key K) tree.Element[K, V] {
 	node := s.findPrevNode(key)
 	if node == nil {
 		return nil
 	}
-	return &element{sk: s, node: node}
+	return &element[K, V]{sk: s, node: node}
 }
 
-func (s *skipList) Next(key interface{}) tree.Element {
+func (s *skipList[K, V]) Next(key K) tree.Element[K, V] {
 	node := s.findNextNode(key)
 	if node == nil {
 		return nil
 	}
-	return &element{sk: s, node: node}
+	return &element[K, V]{sk: s, node: node}
 }
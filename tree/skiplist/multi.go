@@ -0,0 +1,57 @@
+// Copyright (c) 2025 Tian ChunXing. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package skiplist
+
+import "github.com/mrtcx/plusdata/tree"
+
+var _ tree.MultiTree[int, int] = (*skipList[int, int])(nil)
+
+// InsertDup 插入到所有key相同的元素之后
+func (s *skipList[K, V]) InsertDup(key K, value V) {
+	pres, ranks := s.levelPreNodes(key, true)
+	s.insert(pres, ranks, key, value)
+}
+
+func (s *skipList[K, V]) RemoveOne(key K, match func(value V) bool) bool {
+	pres, ranks := s.levelPreNodes(key, false)
+	node, rank := pres[0].nexts[0], ranks[0]+1
+	for ; node != nil && s.cmp(node.key, key) == 0; node, rank = node.nexts[0], rank+1 {
+		if !match(node.value) {
+			continue
+		}
+		// node之前key相同的节点也可能在高层，每一层的前驱前移到node之前
+		for i := range pres {
+			for pres[i].nexts[i] != nil && ranks[i]+pres[i].spans[i] < rank {
+				ranks[i] += pres[i].spans[i]
+				pres[i] = pres[i].nexts[i]
+			}
+		}
+		s.unlink(pres, node)
+		return true
+	}
+	return false
+}
+
+func (s *skipList[K, V]) RemoveAll(key K) int {
+	pres, _ := s.levelPreNodes(key, false)
+	cnt := 0
+	for node := pres[0].nexts[0]; node != nil && s.cmp(node.key, key) == 0; node = pres[0].nexts[0] {
+		s.unlink(pres, node)
+		cnt++
+	}
+	return cnt
+}
+
+func (s *skipList[K, V]) Count(key K) int {
+	return s.rank(key, true) - s.rank(key, false)
+}
+
+func (s *skipList[K, V]) EqualRange(key K) (first, last tree.Element[K, V]) {
+	node := s.preLocate(key).nexts[0]
+	if node == nil || s.cmp(node.key, key) != 0 {
+		return nil, nil
+	}
+	return &element[K, V]{sk: s, node: node}, &element[K, V]{sk: s, node: s.preLocateUpper(key)}
+}
@@ -0,0 +1,83 @@
+// Copyright (c) 2025 Tian ChunXing. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package skiplist
+
+import "github.com/mrtcx/plusdata/tree"
+
+var _ tree.Navigable[int, int] = (*skipList[int, int])(nil)
+
+// Floor 最后一个<=key的元素，存在重复key时为相等元素中的最后一个
+func (s *skipList[K, V]) Floor(key K) tree.Element[K, V] {
+	node := s.preLocateUpper(key)
+	if node == s.head {
+		return nil
+	}
+	return &element[K, V]{sk: s, node: node}
+}
+
+// Ceiling 第一个>=key的元素，存在重复key时为相等元素中的第一个
+func (s *skipList[K, V]) Ceiling(key K) tree.Element[K, V] {
+	node := s.preLocate(key).nexts[0]
+	if node == nil {
+		return nil
+	}
+	return &element[K, V]{sk: s, node: node}
+}
+
+func (s *skipList[K, V]) Lower(key K) tree.Element[K, V] {
+	return s.Prev(key)
+}
+
+func (s *skipList[K, V]) Higher(key K) tree.Element[K, V] {
+	return s.Next(key)
+}
+
+func (s *skipList[K, V]) PollFirst() (K, V, bool) {
+	if s.size == 0 {
+		var zeroK K
+		var zeroV V
+		return zeroK, zeroV, false
+	}
+	node := s.head.nexts[0]
+	pres := make([]*Node[K, V], len(s.head.nexts))
+	for i := range pres {
+		pres[i] = s.head
+	}
+	s.unlink(pres, node)
+	return node.key, node.value, true
+}
+
+func (s *skipList[K, V]) PollLast() (K, V, bool) {
+	if s.size == 0 {
+		var zeroK K
+		var zeroV V
+		return zeroK, zeroV, false
+	}
+	node, pre := s.tail, s.head
+	pres := make([]*Node[K, V], len(s.head.nexts))
+	for i := len(pres) - 1; i >= 0; i-- {
+		for pre.nexts[i] != nil && pre.nexts[i] != node {
+			pre = pre.nexts[i]
+		}
+		pres[i] = pre
+	}
+	s.unlink(pres, node)
+	return node.key, node.value, true
+}
+
+// HeadMap key<to的元素的视图，视图中的修改直接作用于跳表
+func (s *skipList[K, V]) HeadMap(to K) tree.Navigable[K, V] {
+	return tree.NewHeadMap[K, V](s, s.cmp, to)
+}
+
+// TailMap key>=from的元素的视图
+func (s *skipList[K, V]) TailMap(from K) tree.Navigable[K, V] {
+	return tree.NewTailMap[K, V](s, s.cmp, from)
+}
+
+// SubMap 区间[from, to)内的元素的视图，bound可指定区间开闭
+func (s *skipList[K, V]) SubMap(from, to K, bound ...tree.Bound) tree.Navigable[K, V] {
+	return tree.NewSubMap[K, V](s, s.cmp, from, to, bound...)
+}
@@ -0,0 +1,68 @@
+// Copyright (c) 2025 Tian ChunXing. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package skiplist
+
+import "github.com/mrtcx/plusdata/tree"
+
+// AscendRange 正序遍历区间[lo, hi)内的元素, fn返回false时停止, bound可指定区间开闭
+func (s *skipList[K, V]) AscendRange(lo, hi K, fn func(key K, value V) bool, bound ...tree.Bound) {
+	b := tree.RangeBound(bound)
+	s.ascend(lo, b.LoInclusive(), func(key K) bool {
+		less := s.cmp(key, hi)
+		return less < 0 || less == 0 && b.HiInclusive()
+	}, fn)
+}
+
+// DescendRange 倒序遍历区间[lo, hi)内的元素, fn返回false时停止, bound可指定区间开闭
+func (s *skipList[K, V]) DescendRange(lo, hi K, fn func(key K, value V) bool, bound ...tree.Bound) {
+	b := tree.RangeBound(bound)
+	s.descend(hi, b.HiInclusive(), func(key K) bool {
+		less := s.cmp(key, lo)
+		return less > 0 || less == 0 && b.LoInclusive()
+	}, fn)
+}
+
+// AscendGreaterOrEqual 正序遍历key>=lo的元素
+func (s *skipList[K, V]) AscendGreaterOrEqual(lo K, fn func(key K, value V) bool) {
+	s.ascend(lo, true, nil, fn)
+}
+
+// DescendLessOrEqual 倒序遍历key<=hi的元素
+func (s *skipList[K, V]) DescendLessOrEqual(hi K, fn func(key K, value V) bool) {
+	s.descend(hi, true, nil, fn)
+}
+
+// 定位到起点后沿第0层的nexts遍历
+func (s *skipList[K, V]) ascend(lo K, include bool, inRange func(key K) bool, fn func(key K, value V) bool) {
+	var pre *Node[K, V]
+	if include {
+		pre = s.preLocate(lo)
+	} else {
+		pre = s.preLocateUpper(lo)
+	}
+	for node := pre.nexts[0]; node != nil; node = node.nexts[0] {
+		if inRange != nil && !inRange(node.key) || !fn(node.key, node.value) {
+			return
+		}
+	}
+}
+
+// 定位到起点后沿第0层的prev遍历
+func (s *skipList[K, V]) descend(hi K, include bool, inRange func(key K) bool, fn func(key K, value V) bool) {
+	var node *Node[K, V]
+	if include {
+		node = s.preLocateUpper(hi)
+	} else {
+		node = s.preLocate(hi)
+	}
+	if node == s.head {
+		return
+	}
+	for ; node != nil; node = node.prev {
+		if inRange != nil && !inRange(node.key) || !fn(node.key, node.value) {
+			return
+		}
+	}
+}
@@ -0,0 +1,42 @@
+// Copyright (c) 2025 Tian ChunXing. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package skiplist
+
+import "github.com/mrtcx/plusdata/tree"
+
+// Rank 返回跳表中小于key的元素个数，即key在顺序遍历中的下标
+func (s *skipList[K, V]) Rank(key K) int {
+	return s.rank(key, false)
+}
+
+// 小于key(upper为true时小于等于key)的元素个数
+func (s *skipList[K, V]) rank(key K, upper bool) int {
+	pre, rank := s.head, 0
+	for i := len(pre.nexts) - 1; i >= 0; i-- {
+		for pre.nexts[i] != nil && s.before(pre.nexts[i].key, key, upper) {
+			rank += pre.spans[i]
+			pre = pre.nexts[i]
+		}
+	}
+	return rank
+}
+
+// Select 返回顺序遍历中下标为k的元素(从0开始)，k越界时返回nil
+func (s *skipList[K, V]) Select(k int) tree.Element[K, V] {
+	if k < 0 || k >= s.size {
+		return nil
+	}
+	pre, rank := s.head, 0
+	for i := len(pre.nexts) - 1; i >= 0; i-- {
+		for pre.nexts[i] != nil && rank+pre.spans[i] <= k+1 {
+			rank += pre.spans[i]
+			pre = pre.nexts[i]
+		}
+		if rank == k+1 {
+			break
+		}
+	}
+	return &element[K, V]{sk: s, node: pre}
+}
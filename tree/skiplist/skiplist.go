@@ -7,82 +7,120 @@ package skiplist
 import (
 	"math/rand"
 
+	"github.com/mrtcx/plusdata/codec"
 	"github.com/mrtcx/plusdata/tree"
 )
 
-var _ tree.Tree = (*skipList)(nil)
+var _ tree.Tree[int, int] = (*skipList[int, int])(nil)
 
 const (
 	_maxLevel    = 32     // 最大层数
 	_probability = 0x4000 // 25%的概率阈值 (0xFFFF * 0.25)
 )
 
-type skipList struct {
+type skipList[K, V any] struct {
 	size int
-	cmp  tree.Comparator
-	head *Node
-	tail *Node
+	cmp  tree.Comparator[K]
+	head *Node[K, V]
+	tail *Node[K, V]
+	kc   codec.Codec[K]
+	vc   codec.Codec[V]
 }
 
-type Node struct {
-	key   interface{}
-	value interface{}
-	nexts []*Node
+type Node[K, V any] struct {
+	key   K
+	value V
+	nexts []*Node[K, V]
+	spans []int       //spans[i]为第i层到nexts[i]跨过的第0层节点数
+	prev  *Node[K, V] //第0层的前驱, 头节点的下一个节点为nil
 }
 
-func New(cmp tree.Comparator) *skipList {
-	return &skipList{
+func New[K, V any](cmp tree.Comparator[K]) *skipList[K, V] {
+	return &skipList[K, V]{
 		cmp: cmp,
-		head: &Node{
-			nexts: make([]*Node, 1),
+		head: &Node[K, V]{
+			nexts: make([]*Node[K, V], 1),
+			spans: make([]int, 1),
 		},
 	}
 }
 
-func (s *skipList) Clean() {
-	s.size, s.head = 0, &Node{nexts: make([]*Node, 1)}
+// NewAny interface{}版本的构造函数，与泛型之前的New用法相同，返回的树实现tree.AnyTree
+func NewAny(cmp tree.AnyComparator) *skipList[any, any] {
+	return New[any, any](cmp)
 }
 
-func (s *skipList) Size() int {
+func (s *skipList[K, V]) Clean() {
+	s.size, s.tail = 0, nil
+	s.head = &Node[K, V]{nexts: make([]*Node[K, V], 1), spans: make([]int, 1)}
+}
+
+func (s *skipList[K, V]) Size() int {
 	return s.size
 }
 
-func (s *skipList) Empty() bool {
+func (s *skipList[K, V]) Empty() bool {
 	return s.size == 0
 }
 
-func (s *skipList) Insert(key interface{}, value interface{}) {
-	pres := s.levelPreNodes(key)
+func (s *skipList[K, V]) Insert(key K, value V) {
+	pres, ranks := s.levelPreNodes(key, false)
 	if pres[0].nexts[0] != nil && s.cmp(pres[0].nexts[0].key, key) == 0 {
 		pres[0].nexts[0].value = value
 		return
 	}
+	s.insert(pres, ranks, key, value)
+}
+
+// 在每一层的前驱pres之后插入新节点
+func (s *skipList[K, V]) insert(pres []*Node[K, V], ranks []int, key K, value V) {
 	level := randomLevel()
-	insertNode := &Node{key: key, value: value, nexts: make([]*Node, level)}
-	if level < len(pres) {
-		pres = pres[:level]
-	}
-	for i := len(pres) - 1; i >= 0; i-- {
+	insertNode := &Node[K, V]{key: key, value: value, nexts: make([]*Node[K, V], level), spans: make([]int, level)}
+	for i := len(s.head.nexts); i < level; i++ {
+		s.head.nexts = append(s.head.nexts, nil)
+		s.head.spans = append(s.head.spans, s.size)
+		pres, ranks = append(pres, s.head), append(ranks, 0)
+	}
+	for i := 0; i < len(pres); i++ {
+		if i >= level {
+			pres[i].spans[i]++
+			continue
+		}
 		pres[i].nexts[i], insertNode.nexts[i] = insertNode, pres[i].nexts[i]
+		// ranks[0]-ranks[i]为第i层前驱到插入位置之间的节点数
+		insertNode.spans[i] = pres[i].spans[i] - (ranks[0] - ranks[i])
+		pres[i].spans[i] = ranks[0] - ranks[i] + 1
 	}
-	for i := len(s.head.nexts); i < level; i++ {
-		s.head.nexts = append(s.head.nexts, insertNode)
-		s.head.nexts[i] = insertNode
+	if pres[0] != s.head {
+		insertNode.prev = pres[0]
 	}
 	if insertNode.nexts[0] == nil {
 		s.tail = insertNode
+	} else {
+		insertNode.nexts[0].prev = insertNode
 	}
 	s.size++
 }
 
-func (s *skipList) Remove(key interface{}) {
-	levelPres := s.levelPreNodes(key)
+func (s *skipList[K, V]) Remove(key K) {
+	levelPres, _ := s.levelPreNodes(key, false)
 	if levelPres[0].nexts[0] == nil || s.cmp(levelPres[0].nexts[0].key, key) != 0 {
 		return
 	}
+	s.unlink(levelPres, levelPres[0].nexts[0])
+}
+
+// 摘除node, levelPres[i]为node在第i层的前驱(node的层数不足i时, 为第i层跨过node的节点)
+func (s *skipList[K, V]) unlink(levelPres []*Node[K, V], node *Node[K, V]) {
+	if node.nexts[0] != nil {
+		node.nexts[0].prev = node.prev
+	}
 	for i := len(levelPres) - 1; i >= 0; i-- {
-		if levelPres[i].nexts[i] != nil {
-			levelPres[i].nexts[i] = levelPres[i].nexts[i].nexts[i]
+		if levelPres[i].nexts[i] == node {
+			levelPres[i].spans[i] += node.spans[i] - 1
+			levelPres[i].nexts[i] = node.nexts[i]
+		} else {
+			levelPres[i].spans[i]--
 		}
 	}
 	if levelPres[0].nexts[0] == nil {
@@ -91,29 +129,30 @@ func (s *skipList) Remove(key interface{}) {
 	s.size--
 }
 
-func (s *skipList) Get(key interface{}) (interface{}, bool) {
+func (s *skipList[K, V]) Get(key K) (V, bool) {
 	node := s.findNode(key)
 	if node == nil {
-		return nil, false
+		var zero V
+		return zero, false
 	}
 	return node.value, true
 }
 
-func (s *skipList) frontNode() *Node {
+func (s *skipList[K, V]) frontNode() *Node[K, V] {
 	if s.size == 0 {
 		return nil
 	}
 	return s.head.nexts[0]
 }
 
-func (s *skipList) backNode() *Node {
+func (s *skipList[K, V]) backNode() *Node[K, V] {
 	if s.size == 0 {
 		return nil
 	}
 	return s.tail
 }
 
-func (s *skipList) findNode(key interface{}) *Node {
+func (s *skipList[K, V]) findNode(key K) *Node[K, V] {
 	pre := s.preLocate(key)
 	if pre.nexts[0] != nil && s.cmp(pre.nexts[0].key, key) == 0 {
 		return pre.nexts[0]
@@ -121,7 +160,7 @@ func (s *skipList) findNode(key interface{}) *Node {
 	return nil
 }
 
-func (s *skipList) findPrevNode(key interface{}) *Node {
+func (s *skipList[K, V]) findPrevNode(key K) *Node[K, V] {
 	pre := s.preLocate(key)
 	if pre == s.head {
 		return nil
@@ -129,39 +168,52 @@ func (s *skipList) findPrevNode(key interface{}) *Node {
 	return pre
 }
 
-func (s *skipList) findNextNode(key interface{}) *Node {
-	pre := s.preLocate(key)
-	if pre.nexts[0] != nil {
-		if s.cmp(pre.nexts[0].key, key) > 0 {
-			return pre.nexts[0]
+func (s *skipList[K, V]) findNextNode(key K) *Node[K, V] {
+	return s.preLocateUpper(key).nexts[0]
+}
+
+// 每一层中最后一个<key(upper为true时<=key)的节点，以及该节点在第0层的排名(头节点为0)
+func (s *skipList[K, V]) levelPreNodes(key K, upper bool) ([]*Node[K, V], []int) {
+	pre, rank := s.head, 0
+	var pres []*Node[K, V] = make([]*Node[K, V], len(pre.nexts))
+	var ranks []int = make([]int, len(pre.nexts))
+	for i := len(pre.nexts) - 1; i >= 0; i-- {
+		for pre.nexts[i] != nil && s.before(pre.nexts[i].key, key, upper) {
+			rank += pre.spans[i]
+			pre = pre.nexts[i]
 		}
-		return pre.nexts[0].nexts[0]
+		pres[i], ranks[i] = pre, rank
 	}
-	return nil
+	return pres, ranks
 }
 
-func (s *skipList) levelPreNodes(key interface{}) []*Node {
+func (s *skipList[K, V]) preLocate(key K) *Node[K, V] {
 	pre := s.head
-	var pres []*Node = make([]*Node, len(pre.nexts))
 	for i := len(pre.nexts) - 1; i >= 0; i-- {
 		for pre.nexts[i] != nil && s.cmp(key, pre.nexts[i].key) > 0 {
 			pre = pre.nexts[i]
 		}
-		pres[i] = pre
 	}
-	return pres
+	return pre
 }
 
-func (s *skipList) preLocate(key interface{}) *Node {
+// 最后一个<=key的节点，不存在时为头节点
+func (s *skipList[K, V]) preLocateUpper(key K) *Node[K, V] {
 	pre := s.head
 	for i := len(pre.nexts) - 1; i >= 0; i-- {
-		for pre.nexts[i] != nil && s.cmp(key, pre.nexts[i].key) > 0 {
+		for pre.nexts[i] != nil && s.cmp(key, pre.nexts[i].key) >= 0 {
 			pre = pre.nexts[i]
 		}
 	}
 	return pre
 }
 
+// nodeKey是否排在key之前
+func (s *skipList[K, V]) before(nodeKey, key K, upper bool) bool {
+	less := s.cmp(key, nodeKey)
+	return less > 0 || upper && less == 0
+}
+
 func randomLevel() int {
 	level := 1
 	for rand.Int31n(0xFFFF) < _probability && level < _maxLevel {
@@ -13,14 +13,14 @@ import (
 	"github.com/mrtcx/plusdata/tree"
 )
 
-var intcmp = tree.IntComparator
+var intcmp = tree.OrderedComparator[int]
 
 func TestInsert(t *testing.T) {
 	nums := []int{1, 2, 4, 8, 1024, 1024 + 1}
 	for _, num := range nums {
 		tnum := num
 		t.Run(fmt.Sprintf("[num:%d]", tnum), func(t *testing.T) {
-			sk := New(intcmp)
+			sk := New[int, int](intcmp)
 			assert.Equal(t, sk.head.nexts[0], nil)
 			for i := 1; i <= tnum; i++ {
 				sk.Insert(i, i)
@@ -29,7 +29,7 @@ func TestInsert(t *testing.T) {
 			}
 			assert.Equal(t, sk.size, tnum)
 
-			sk = New(intcmp)
+			sk = New[int, int](intcmp)
 			for i := tnum; i >= 1; i-- {
 				sk.Insert(i, i)
 				sk.Insert(i, i)
@@ -37,7 +37,7 @@ func TestInsert(t *testing.T) {
 			}
 			assert.Equal(t, sk.size, tnum)
 
-			sk = New(intcmp)
+			sk = New[int, int](intcmp)
 			for i, j := 1, tnum; i <= j; i, j = i+1, j-1 {
 				sk.Insert(i, i)
 				sk.Insert(j, j)
@@ -45,7 +45,7 @@ func TestInsert(t *testing.T) {
 			}
 			assert.Equal(t, sk.size, tnum)
 
-			sk = New(intcmp)
+			sk = New[int, int](intcmp)
 			for i := 1; i <= tnum; i++ {
 				randnum := int(rand.Int31() % int32(tnum))
 				sk.Insert(randnum, randnum)
@@ -98,8 +98,8 @@ func TestRemove(t *testing.T) {
 	}
 }
 
-func newInsertNum(num int) *skipList {
-	sk := New(intcmp)
+func newInsertNum(num int) *skipList[int, int] {
+	sk := New[int, int](intcmp)
 	for i := 1; i <= num; i++ {
 		sk.Insert(i, i)
 	}
@@ -108,9 +108,9 @@ func newInsertNum(num int) *skipList {
 
 func TestGet(t *testing.T) {
 	num := 1024
-	sk := New(intcmp)
+	sk := New[int, int](intcmp)
 	geti, ei := sk.Get(1)
-	assert.Equal(t, geti, nil)
+	assert.Equal(t, geti, 0)
 	assert.Equal(t, ei, false)
 	for i := 1; i <= num; i++ {
 		sk.Insert(i, i)
@@ -118,7 +118,7 @@ func TestGet(t *testing.T) {
 		assert.Equal(t, geti, i)
 		assert.Equal(t, ei, true)
 		geti, ei = sk.Get(i + 1)
-		assert.Equal(t, geti, nil)
+		assert.Equal(t, geti, 0)
 		assert.Equal(t, ei, false)
 	}
 	for i := 1; i <= num; i++ {
@@ -133,11 +133,12 @@ func TestGet(t *testing.T) {
 	}
 }
 
-func checkOrder(t *testing.T, sk *skipList) bool {
+func checkOrder(t *testing.T, sk *skipList[int, int]) bool {
 	if sk.head.nexts[0] == nil {
 		return sk.size == 0
 	}
-	var arr []interface{}
+	var arr []int
+	var prev *Node[int, int]
 	head := sk.head.nexts[0]
 	for head != nil {
 		arr = append(arr, head.key)
@@ -145,7 +146,15 @@ func checkOrder(t *testing.T, sk *skipList) bool {
 			t.Logf("fail node:%v", head)
 			return false
 		}
-		head = head.nexts[0]
+		if head.prev != prev {
+			t.Logf("fail prev:%v %v", head, prev)
+			return false
+		}
+		prev, head = head, head.nexts[0]
+	}
+	if prev != sk.tail {
+		t.Logf("fail tail:%v %v", prev, sk.tail)
+		return false
 	}
 	if len(arr) != sk.size {
 		t.Logf("fail size %d %d", len(arr), sk.size)
@@ -157,9 +166,39 @@ func checkOrder(t *testing.T, sk *skipList) bool {
 			return false
 		}
 	}
+	rank := map[*Node[int, int]]int{}
+	for node, i := sk.head, 0; node != nil; node, i = node.nexts[0], i+1 {
+		rank[node] = i
+	}
+	for node := sk.head; node != nil; node = node.nexts[0] {
+		for i, next := range node.nexts {
+			if next != nil && node.spans[i] != rank[next]-rank[node] {
+				t.Logf("fail span level:%d %v %v", i, node, next)
+				return false
+			}
+		}
+	}
 	return true
 }
 
+func TestAnyKey(t *testing.T) {
+	num := 1024
+	var tr tree.Tree[any, any] = New[any, any](tree.IntComparator)
+	for i := num; i >= 1; i-- {
+		tr.Insert(i, i)
+	}
+	e := tr.Left()
+	for i := 1; i <= num; i++ {
+		assert.Equal(t, e.Key(), i)
+		assert.Equal(t, e.Value(), i)
+		e = e.Next()
+	}
+	assert.Equal(t, e, nil)
+	geti, ei := tr.Get(num + 1)
+	assert.Equal(t, geti, nil)
+	assert.Equal(t, ei, false)
+}
+
 func BenchmarkInsert(b *testing.B) {
 	testsizes := []int{100000, 500000, 1000000, 2000000}
 	testNames := []string{"10w", "50w", "100w", "200w"}
@@ -176,7 +215,7 @@ func BenchmarkInsert(b *testing.B) {
 			}
 		})
 		b.Run(fmt.Sprintf("skiplist-%s", name), func(b *testing.B) {
-			tr := New(tree.IntComparator)
+			tr := New[int, interface{}](intcmp)
 			b.ResetTimer()
 			for i := 0; i < b.N; i++ {
 				for j := 0; j < size; j++ {
@@ -206,7 +245,7 @@ func BenchmarkRemove(b *testing.B) {
 			}
 		})
 		b.Run(fmt.Sprintf("skiplist-%s", name), func(b *testing.B) {
-			tr := New(tree.IntComparator)
+			tr := New[int, interface{}](intcmp)
 			for j := 0; j < size; j++ {
 				tr.Insert(j, nil)
 			}
@@ -239,7 +278,7 @@ func BenchmarkGet(b *testing.B) {
 			}
 		})
 		b.Run(fmt.Sprintf("skiplist-%s", name), func(b *testing.B) {
-			tr := New(tree.IntComparator)
+			tr := New[int, interface{}](intcmp)
 			for j := 0; j < size; j++ {
 				tr.Insert(j, nil)
 			}
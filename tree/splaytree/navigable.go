@@ -0,0 +1,62 @@
+// Copyright (c) 2025 Tian ChunXing. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package splaytree
+
+import "github.com/mrtcx/plusdata/tree"
+
+var _ tree.Navigable[int, int] = (*splayTree[int, int])(nil)
+
+// Floor 最后一个<=key的元素
+func (st *splayTree[K, V]) Floor(key K) tree.Element[K, V] {
+	return st.Select(st.rank(key, true) - 1)
+}
+
+// Ceiling 第一个>=key的元素
+func (st *splayTree[K, V]) Ceiling(key K) tree.Element[K, V] {
+	return st.Select(st.rank(key, false))
+}
+
+func (st *splayTree[K, V]) Lower(key K) tree.Element[K, V] {
+	return st.Prev(key)
+}
+
+func (st *splayTree[K, V]) Higher(key K) tree.Element[K, V] {
+	return st.Next(key)
+}
+
+func (st *splayTree[K, V]) PollFirst() (K, V, bool) {
+	return st.poll(0)
+}
+
+func (st *splayTree[K, V]) PollLast() (K, V, bool) {
+	return st.poll(st.size - 1)
+}
+
+func (st *splayTree[K, V]) poll(k int) (K, V, bool) {
+	node := st.selectNode(k)
+	if node == nil {
+		var zeroK K
+		var zeroV V
+		return zeroK, zeroV, false
+	}
+	key, value := node.key, node.value
+	st.Remove(key)
+	return key, value, true
+}
+
+// HeadMap key<to的元素的视图，视图中的修改直接作用于树
+func (st *splayTree[K, V]) HeadMap(to K) tree.Navigable[K, V] {
+	return tree.NewHeadMap[K, V](st, st.cmp, to)
+}
+
+// TailMap key>=from的元素的视图
+func (st *splayTree[K, V]) TailMap(from K) tree.Navigable[K, V] {
+	return tree.NewTailMap[K, V](st, st.cmp, from)
+}
+
+// SubMap 区间[from, to)内的元素的视图，bound可指定区间开闭
+func (st *splayTree[K, V]) SubMap(from, to K, bound ...tree.Bound) tree.Navigable[K, V] {
+	return tree.NewSubMap[K, V](st, st.cmp, from, to, bound...)
+}
@@ -0,0 +1,84 @@
+// Copyright (c) 2025 Tian ChunXing. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package splaytree
+
+import "github.com/mrtcx/plusdata/tree"
+
+// AscendRange 正序遍历区间[lo, hi)内的元素, fn返回false时停止, bound可指定区间开闭
+func (st *splayTree[K, V]) AscendRange(lo, hi K, fn func(key K, value V) bool, bound ...tree.Bound) {
+	b := tree.RangeBound(bound)
+	st.ascend(lo, b.LoInclusive(), func(key K) bool {
+		less := st.cmp(key, hi)
+		return less < 0 || less == 0 && b.HiInclusive()
+	}, fn)
+}
+
+// DescendRange 倒序遍历区间[lo, hi)内的元素, fn返回false时停止, bound可指定区间开闭
+func (st *splayTree[K, V]) DescendRange(lo, hi K, fn func(key K, value V) bool, bound ...tree.Bound) {
+	b := tree.RangeBound(bound)
+	st.descend(hi, b.HiInclusive(), func(key K) bool {
+		less := st.cmp(key, lo)
+		return less > 0 || less == 0 && b.LoInclusive()
+	}, fn)
+}
+
+// AscendGreaterOrEqual 正序遍历key>=lo的元素
+func (st *splayTree[K, V]) AscendGreaterOrEqual(lo K, fn func(key K, value V) bool) {
+	st.ascend(lo, true, nil, fn)
+}
+
+// DescendLessOrEqual 倒序遍历key<=hi的元素
+func (st *splayTree[K, V]) DescendLessOrEqual(hi K, fn func(key K, value V) bool) {
+	st.descend(hi, true, nil, fn)
+}
+
+// 先把lo伸展到根，栈中保存还未访问的左父节点，出栈后把右子树的左链入栈，整体均摊O(logN + k)
+func (st *splayTree[K, V]) ascend(lo K, include bool, inRange func(key K) bool, fn func(key K, value V) bool) {
+	st.root = st.splay(st.root, lo)
+	stack := make([]*Node[K, V], 0, 64)
+	for root := st.root; root != nil; {
+		less := st.cmp(lo, root.key)
+		if less < 0 || less == 0 && include {
+			stack = append(stack, root)
+			root = root.lchild
+		} else {
+			root = root.rchild
+		}
+	}
+	for len(stack) > 0 {
+		node := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		if inRange != nil && !inRange(node.key) || !fn(node.key, node.value) {
+			return
+		}
+		for root := node.rchild; root != nil; root = root.lchild {
+			stack = append(stack, root)
+		}
+	}
+}
+
+func (st *splayTree[K, V]) descend(hi K, include bool, inRange func(key K) bool, fn func(key K, value V) bool) {
+	st.root = st.splay(st.root, hi)
+	stack := make([]*Node[K, V], 0, 64)
+	for root := st.root; root != nil; {
+		less := st.cmp(hi, root.key)
+		if less > 0 || less == 0 && include {
+			stack = append(stack, root)
+			root = root.rchild
+		} else {
+			root = root.lchild
+		}
+	}
+	for len(stack) > 0 {
+		node := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		if inRange != nil && !inRange(node.key) || !fn(node.key, node.value) {
+			return
+		}
+		for root := node.lchild; root != nil; root = root.rchild {
+			stack = append(stack, root)
+		}
+	}
+}
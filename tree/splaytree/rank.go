@@ -0,0 +1,51 @@
+// Copyright (c) 2025 Tian ChunXing. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package splaytree
+
+import "github.com/mrtcx/plusdata/tree"
+
+// Rank 返回树中小于key的元素个数，即key在顺序遍历中的下标
+func (st *splayTree[K, V]) Rank(key K) int {
+	return st.rank(key, false)
+}
+
+// 小于key(upper为true时小于等于key)的元素个数，key或与key相邻的节点伸展到根后由左子树大小得出
+func (st *splayTree[K, V]) rank(key K, upper bool) int {
+	st.root = st.splay(st.root, key)
+	if st.root == nil {
+		return 0
+	}
+	rank := sizeOf(st.root.lchild)
+	if less := st.cmp(key, st.root.key); less > 0 || less == 0 && upper {
+		rank++
+	}
+	return rank
+}
+
+// Select 返回顺序遍历中下标为k的元素(从0开始)，k越界时返回nil
+func (st *splayTree[K, V]) Select(k int) tree.Element[K, V] {
+	node := st.selectNode(k)
+	if node == nil {
+		return nil
+	}
+	return &element[K, V]{st: st, node: node}
+}
+
+func (st *splayTree[K, V]) selectNode(k int) *Node[K, V] {
+	if k < 0 || k >= st.size {
+		return nil
+	}
+	root := st.root
+	for k != sizeOf(root.lchild) {
+		if k < sizeOf(root.lchild) {
+			root = root.lchild
+		} else {
+			k -= sizeOf(root.lchild) + 1
+			root = root.rchild
+		}
+	}
+	st.root = st.splay(st.root, root.key)
+	return root
+}
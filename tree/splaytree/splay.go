@@ -0,0 +1,198 @@
+// Copyright (c) 2025 Tian ChunXing. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+// Package splaytree 伸展树，每次访问后通过旋转把访问的节点移动到根，不保存平衡信息，各操作均摊O(logN)。
+// 最近访问的key离根更近，适合访问有局部性的场景。
+// 注意查找、遍历等读操作也会调整树的结构，并发读也需要加锁(sync.NewSyncTreeExclusive)
+package splaytree
+
+import (
+	"github.com/mrtcx/plusdata/codec"
+	"github.com/mrtcx/plusdata/tree"
+)
+
+var _ tree.Tree[int, int] = (*splayTree[int, int])(nil)
+
+type splayTree[K, V any] struct {
+	root         *Node[K, V]
+	cmp          tree.Comparator[K]
+	size         int
+	lpath, rpath []*Node[K, V] //splay时拆到左树、右树的节点，复用避免每次分配
+	kc           codec.Codec[K]
+	vc           codec.Codec[V]
+}
+
+type Node[K, V any] struct {
+	key            K
+	value          V
+	lchild, rchild *Node[K, V]
+	size           int //子树中节点的数量
+}
+
+func New[K, V any](cmp tree.Comparator[K]) *splayTree[K, V] {
+	return &splayTree[K, V]{cmp: cmp}
+}
+
+func (st *splayTree[K, V]) Clean() {
+	st.root, st.size = nil, 0
+}
+
+func (st *splayTree[K, V]) Size() int {
+	return st.size
+}
+
+func (st *splayTree[K, V]) Empty() bool {
+	return st.size == 0
+}
+
+// Insert 把key或与key相邻的节点伸展到根，key不存在时新节点成为根，原来的根按大小挂到左边或右边
+func (st *splayTree[K, V]) Insert(key K, value V) {
+	root := st.splay(st.root, key)
+	if root == nil {
+		st.root, st.size = &Node[K, V]{key: key, value: value, size: 1}, 1
+		return
+	}
+	less := st.cmp(key, root.key)
+	if less == 0 {
+		root.value = value
+		st.root = root
+		return
+	}
+	node := &Node[K, V]{key: key, value: value}
+	if less < 0 {
+		node.lchild, node.rchild = root.lchild, root
+		root.lchild = nil
+	} else {
+		node.lchild, node.rchild = root, root.rchild
+		root.rchild = nil
+	}
+	update(root)
+	update(node)
+	st.root = node
+	st.size++
+}
+
+// Remove 把key伸展到根后删除，左子树的最大节点伸展到左子树的根，再接上右子树
+func (st *splayTree[K, V]) Remove(key K) {
+	root := st.splay(st.root, key)
+	if root == nil || st.cmp(key, root.key) != 0 {
+		st.root = root
+		return
+	}
+	if root.lchild == nil {
+		st.root = root.rchild
+	} else {
+		left := st.splay(root.lchild, key)
+		left.rchild = root.rchild
+		update(left)
+		st.root = left
+	}
+	st.size--
+}
+
+func (st *splayTree[K, V]) Get(key K) (V, bool) {
+	node := st.findNode(key)
+	if node == nil {
+		var zero V
+		return zero, false
+	}
+	return node.value, true
+}
+
+// 查找key所在的节点，找到的节点成为根
+func (st *splayTree[K, V]) findNode(key K) *Node[K, V] {
+	st.root = st.splay(st.root, key)
+	if st.root == nil || st.cmp(key, st.root.key) != 0 {
+		return nil
+	}
+	return st.root
+}
+
+// 自顶向下伸展(Sleator)，迭代实现，栈深度与树高无关。沿查找路径把节点拆到左树(都小于key)和右树(都大于key)，
+// zig-zig时先旋转一次，最后以停下的节点为根把左右树接回去。key不存在时最后访问的节点(key的前驱或后继)成为根。
+// 拆下的节点子树大小在重新组装后从下往上更新
+func (st *splayTree[K, V]) splay(root *Node[K, V], key K) *Node[K, V] {
+	if root == nil {
+		return nil
+	}
+	var header Node[K, V] //header.rchild为左树的根，header.lchild为右树的根
+	l, r := &header, &header
+	lpath, rpath := st.lpath[:0], st.rpath[:0]
+	for {
+		less := st.cmp(key, root.key)
+		if less < 0 {
+			if root.lchild == nil {
+				break
+			}
+			if st.cmp(key, root.lchild.key) < 0 {
+				root = rotateRight(root)
+				if root.lchild == nil {
+					break
+				}
+			}
+			r.lchild = root
+			r = root
+			rpath = append(rpath, root)
+			root = root.lchild
+		} else if less > 0 {
+			if root.rchild == nil {
+				break
+			}
+			if st.cmp(key, root.rchild.key) > 0 {
+				root = rotateLeft(root)
+				if root.rchild == nil {
+					break
+				}
+			}
+			l.rchild = root
+			l = root
+			lpath = append(lpath, root)
+			root = root.rchild
+		} else {
+			break
+		}
+	}
+	l.rchild, r.lchild = root.lchild, root.rchild
+	root.lchild, root.rchild = header.rchild, header.lchild
+	for i := len(lpath) - 1; i >= 0; i-- {
+		update(lpath[i])
+	}
+	for i := len(rpath) - 1; i >= 0; i-- {
+		update(rpath[i])
+	}
+	update(root)
+	clear(lpath)
+	clear(rpath)
+	st.lpath, st.rpath = lpath[:0], rpath[:0]
+	return root
+}
+
+func rotateLeft[K, V any](root *Node[K, V]) *Node[K, V] {
+	rchild := root.rchild
+	root.rchild = rchild.lchild
+	rchild.lchild = root
+	rchild.size = root.size
+	update(root)
+	return rchild
+}
+
+func rotateRight[K, V any](root *Node[K, V]) *Node[K, V] {
+	lchild := root.lchild
+	root.lchild = lchild.rchild
+	lchild.rchild = root
+	lchild.size = root.size
+	update(root)
+	return lchild
+}
+
+func sizeOf[K, V any](node *Node[K, V]) int {
+	if node == nil {
+		return 0
+	}
+	return node.size
+}
+
+func update[K, V any](node *Node[K, V]) {
+	node.size = sizeOf(node.lchild) + sizeOf(node.rchild) + 1
+}
@@ -0,0 +1,231 @@
+// Copyright (c) 2025 Tian ChunXing. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package splaytree
+
+import (
+	"fmt"
+	"math/rand"
+	"testing"
+
+	"github.com/mrtcx/plusdata/internal/assert"
+	"github.com/mrtcx/plusdata/tree"
+)
+
+var intcmp = tree.OrderedComparator[int]
+
+func TestInsertRemove(t *testing.T) {
+	nums := []int{1, 2, 8, 1024}
+	for _, num := range nums {
+		tnum := num
+		t.Run(fmt.Sprintf("[num:%d]", tnum), func(t *testing.T) {
+			st := New[int, int](intcmp)
+			exist := map[int]int{}
+			for i := 0; i < 4*tnum; i++ {
+				key := rand.Intn(tnum)
+				if rand.Intn(3) == 0 {
+					st.Remove(key)
+					delete(exist, key)
+				} else {
+					st.Insert(key, i)
+					exist[key] = i
+				}
+				if tnum < 1024 || i%64 == 0 {
+					checkSplay(t, st)
+				}
+			}
+			checkSplay(t, st)
+			assert.Equal(t, st.Size(), len(exist))
+			for key := -1; key <= tnum; key++ {
+				value, ok := st.Get(key)
+				_, exists := exist[key]
+				assert.Equal(t, ok, exists)
+				assert.Equal(t, value, exist[key])
+			}
+		})
+	}
+}
+
+// 访问过的key成为根，有序访问全部key后树退化为链，再次访问时树高减半
+func TestSplay(t *testing.T) {
+	st := New[int, int](intcmp)
+	num := 1024
+	for i := 0; i < num; i++ {
+		st.Insert(i, i)
+	}
+	assert.Equal(t, st.root.key, num-1)
+	assert.Equal(t, height(st.root), num)
+	st.Get(0)
+	checkSplay(t, st)
+	assert.Equal(t, st.root.key, 0)
+	assert.Greater(t, num/2+2, height(st.root))
+	for _, key := range rand.Perm(num) {
+		value, ok := st.Get(key)
+		assert.Equal(t, ok, true)
+		assert.Equal(t, value, key)
+		assert.Equal(t, st.root.key, key)
+		assert.Equal(t, st.Rank(key), key)
+		assert.Equal(t, st.Select(key).Key(), key)
+		assert.Equal(t, st.root.key, key)
+	}
+	checkSplay(t, st)
+}
+
+// 自顶向下伸展不递归，退化为很长的链时也不会占用与树高成正比的栈
+func TestSplayDegenerate(t *testing.T) {
+	st := New[int, int](intcmp)
+	num := 1 << 18
+	for i := 0; i < num; i++ {
+		st.Insert(i, i)
+	}
+	assert.Equal(t, height(st.root), num)
+	for _, key := range []int{0, num - 1, num / 2, 1} {
+		value, ok := st.Get(key)
+		assert.Equal(t, ok, true)
+		assert.Equal(t, value, key)
+		assert.Equal(t, st.root.key, key)
+		assert.Equal(t, st.root.size, num)
+	}
+	assert.Greater(t, num/4, height(st.root))
+	checkSplay(t, st)
+}
+
+// 检查key的顺序和子树大小
+func checkSplay(t *testing.T, st *splayTree[int, int]) {
+	var check func(root *Node[int, int], lo, hi *int)
+	check = func(root *Node[int, int], lo, hi *int) {
+		if root == nil {
+			return
+		}
+		if lo != nil {
+			assert.Greater(t, root.key, *lo)
+		}
+		if hi != nil {
+			assert.Greater(t, *hi, root.key)
+		}
+		check(root.lchild, lo, &root.key)
+		check(root.rchild, &root.key, hi)
+		assert.Equal(t, root.size, sizeOf(root.lchild)+sizeOf(root.rchild)+1)
+	}
+	check(st.root, nil, nil)
+	assert.Equal(t, st.size, sizeOf(st.root))
+}
+
+func height(root *Node[int, int]) int {
+	if root == nil {
+		return 0
+	}
+	return max(height(root.lchild), height(root.rchild)) + 1
+}
+
+// 取得元素后修改树，前驱和后继按key查找，不受影响
+func TestElementAfterModify(t *testing.T) {
+	st := New[int, int](intcmp)
+	for key := 10; key <= 50; key += 10 {
+		st.Insert(key, key)
+	}
+	e := st.Find(30)
+	st.Insert(5, 5)
+	assert.Equal(t, e.Next().Key(), 40)
+	assert.Equal(t, e.Prev().Key(), 20)
+	st.Remove(20)
+	st.Remove(40)
+	assert.Equal(t, e.Next().Key(), 50)
+	assert.Equal(t, e.Prev().Key(), 10)
+	assert.Equal(t, e.Next().Next(), nil)
+	assert.Equal(t, e.Prev().Prev().Key(), 5)
+}
+
+func BenchmarkInsert(b *testing.B) {
+	testsizes := []int{100000, 500000, 1000000, 2000000}
+	testNames := []string{"10w", "50w", "100w", "200w"}
+	for i, v := range testsizes {
+		size := v
+		name := testNames[i]
+		b.Run(fmt.Sprintf("map-%s", name), func(b *testing.B) {
+			tr := make(map[int]interface{})
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				for j := 0; j < size; j++ {
+					tr[j] = nil
+				}
+			}
+		})
+		b.Run(fmt.Sprintf("splaytree-%s", name), func(b *testing.B) {
+			tr := New[int, interface{}](intcmp)
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				for j := 0; j < size; j++ {
+					tr.Insert(j, nil)
+				}
+			}
+		})
+	}
+}
+
+func BenchmarkRemove(b *testing.B) {
+	testsizes := []int{100000, 500000, 1000000, 2000000}
+	testNames := []string{"10w", "50w", "100w", "200w"}
+	for i, v := range testsizes {
+		size := v
+		name := testNames[i]
+		b.Run(fmt.Sprintf("map-%s", name), func(b *testing.B) {
+			tr := make(map[int]interface{})
+			for j := 0; j < size; j++ {
+				tr[j] = nil
+			}
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				for j := 0; j < size; j++ {
+					delete(tr, j)
+				}
+			}
+		})
+		b.Run(fmt.Sprintf("splaytree-%s", name), func(b *testing.B) {
+			tr := New[int, interface{}](intcmp)
+			for j := 0; j < size; j++ {
+				tr.Insert(j, nil)
+			}
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				for j := 0; j < size; j++ {
+					tr.Remove(j)
+				}
+			}
+		})
+	}
+}
+
+func BenchmarkGet(b *testing.B) {
+	testsizes := []int{100000, 500000, 1000000, 2000000}
+	testNames := []string{"10w", "50w", "100w", "200w"}
+	for i, v := range testsizes {
+		size := v
+		name := testNames[i]
+		b.Run(fmt.Sprintf("map-%s", name), func(b *testing.B) {
+			tr := make(map[int]interface{})
+			for j := 0; j < size; j++ {
+				tr[j] = nil
+			}
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				for j := 0; j < size; j++ {
+					_, _ = tr[j]
+				}
+			}
+		})
+		b.Run(fmt.Sprintf("splaytree-%s", name), func(b *testing.B) {
+			tr := New[int, interface{}](intcmp)
+			for j := 0; j < size; j++ {
+				tr.Insert(j, nil)
+			}
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				for j := 0; j < size; j++ {
+					_, _ = tr.Get(j)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,59 @@
+// Copyright (c) 2025 Tian ChunXing. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package treap
+
+import "github.com/mrtcx/plusdata/tree"
+
+type element[K, V any] struct {
+	tp   *treap[K, V]
+	node *Node[K, V]
+}
+
+func (e *element[K, V]) Key() K {
+	return e.node.key
+}
+
+func (e *element[K, V]) Value() V {
+	return e.node.value
+}
+
+func (e *element[K, V]) SetValue(value V) {
+	e.node.value = value
+}
+
+// Next 按key查找后继，取得元素后树被修改也不受影响
+func (e *element[K, V]) Next() tree.Element[K, V] {
+	return e.tp.Next(e.node.key)
+}
+
+func (e *element[K, V]) Prev() tree.Element[K, V] {
+	return e.tp.Prev(e.node.key)
+}
+
+func (tp *treap[K, V]) Find(key K) tree.Element[K, V] {
+	node := tp.findNode(key)
+	if node == nil {
+		return nil
+	}
+	return &element[K, V]{tp: tp, node: node}
+}
+
+func (tp *treap[K, V]) Left() tree.Element[K, V] {
+	return tp.Select(0)
+}
+
+func (tp *treap[K, V]) Right() tree.Element[K, V] {
+	return tp.Select(tp.size - 1)
+}
+
+// Prev 最后一个小于key的元素
+func (tp *treap[K, V]) Prev(key K) tree.Element[K, V] {
+	return tp.Select(tp.rank(key, false) - 1)
+}
+
+// Next 第一个大于key的元素
+func (tp *treap[K, V]) Next(key K) tree.Element[K, V] {
+	return tp.Select(tp.rank(key, true))
+}
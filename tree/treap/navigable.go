@@ -0,0 +1,62 @@
+// Copyright (c) 2025 Tian ChunXing. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package treap
+
+import "github.com/mrtcx/plusdata/tree"
+
+var _ tree.Navigable[int, int] = (*treap[int, int])(nil)
+
+// Floor 最后一个<=key的元素
+func (tp *treap[K, V]) Floor(key K) tree.Element[K, V] {
+	return tp.Select(tp.rank(key, true) - 1)
+}
+
+// Ceiling 第一个>=key的元素
+func (tp *treap[K, V]) Ceiling(key K) tree.Element[K, V] {
+	return tp.Select(tp.rank(key, false))
+}
+
+func (tp *treap[K, V]) Lower(key K) tree.Element[K, V] {
+	return tp.Prev(key)
+}
+
+func (tp *treap[K, V]) Higher(key K) tree.Element[K, V] {
+	return tp.Next(key)
+}
+
+func (tp *treap[K, V]) PollFirst() (K, V, bool) {
+	return tp.poll(0)
+}
+
+func (tp *treap[K, V]) PollLast() (K, V, bool) {
+	return tp.poll(tp.size - 1)
+}
+
+func (tp *treap[K, V]) poll(k int) (K, V, bool) {
+	node := tp.selectNode(k)
+	if node == nil {
+		var zeroK K
+		var zeroV V
+		return zeroK, zeroV, false
+	}
+	key, value := node.key, node.value
+	tp.Remove(key)
+	return key, value, true
+}
+
+// HeadMap key<to的元素的视图，视图中的修改直接作用于树
+func (tp *treap[K, V]) HeadMap(to K) tree.Navigable[K, V] {
+	return tree.NewHeadMap[K, V](tp, tp.cmp, to)
+}
+
+// TailMap key>=from的元素的视图
+func (tp *treap[K, V]) TailMap(from K) tree.Navigable[K, V] {
+	return tree.NewTailMap[K, V](tp, tp.cmp, from)
+}
+
+// SubMap 区间[from, to)内的元素的视图，bound可指定区间开闭
+func (tp *treap[K, V]) SubMap(from, to K, bound ...tree.Bound) tree.Navigable[K, V] {
+	return tree.NewSubMap[K, V](tp, tp.cmp, from, to, bound...)
+}
@@ -0,0 +1,52 @@
+// Copyright (c) 2025 Tian ChunXing. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package treap
+
+import "github.com/mrtcx/plusdata/tree"
+
+// Rank 返回树中小于key的元素个数，即key在顺序遍历中的下标
+func (tp *treap[K, V]) Rank(key K) int {
+	return tp.rank(key, false)
+}
+
+// 小于key(upper为true时小于等于key)的元素个数
+func (tp *treap[K, V]) rank(key K, upper bool) int {
+	rank := 0
+	for root := tp.root; root != nil; {
+		less := tp.cmp(key, root.key)
+		if less < 0 || less == 0 && !upper {
+			root = root.lchild
+		} else {
+			rank += sizeOf(root.lchild) + 1
+			root = root.rchild
+		}
+	}
+	return rank
+}
+
+// Select 返回顺序遍历中下标为k的元素(从0开始)，k越界时返回nil
+func (tp *treap[K, V]) Select(k int) tree.Element[K, V] {
+	node := tp.selectNode(k)
+	if node == nil {
+		return nil
+	}
+	return &element[K, V]{tp: tp, node: node}
+}
+
+func (tp *treap[K, V]) selectNode(k int) *Node[K, V] {
+	if k < 0 || k >= tp.size {
+		return nil
+	}
+	root := tp.root
+	for k != sizeOf(root.lchild) {
+		if k < sizeOf(root.lchild) {
+			root = root.lchild
+		} else {
+			k -= sizeOf(root.lchild) + 1
+			root = root.rchild
+		}
+	}
+	return root
+}
@@ -0,0 +1,29 @@
+// Copyright (c) 2025 Tian ChunXing. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package treap
+
+import "github.com/mrtcx/plusdata/tree"
+
+// Split 按key把树分成key<key的left和key>=key的right，节点移动到两棵新树中，原树变为空树，期望O(logN)
+func (tp *treap[K, V]) Split(key K) (left, right *treap[K, V]) {
+	l, r := tp.split(tp.root, key, false)
+	left, right = New[K, V](tp.cmp), New[K, V](tp.cmp)
+	left.root, left.size = l, sizeOf(l)
+	right.root, right.size = r, sizeOf(r)
+	tp.Clean()
+	return left, right
+}
+
+// Join 把right的全部节点合并到left中并返回left，right变为空树，期望O(logN)。
+// left中的key需要都小于right中的key，否则返回tree.ErrNotSorted，两棵树不变
+func Join[K, V any](left, right *treap[K, V]) (*treap[K, V], error) {
+	if !left.Empty() && !right.Empty() && left.cmp(left.selectNode(left.size-1).key, right.selectNode(0).key) >= 0 {
+		return left, tree.ErrNotSorted
+	}
+	left.root = left.merge(left.root, right.root)
+	left.size += right.size
+	right.Clean()
+	return left, nil
+}
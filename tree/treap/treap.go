@@ -0,0 +1,154 @@
+// Copyright (c) 2025 Tian ChunXing. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+// Package treap 树堆，key满足二叉搜索树的顺序，节点随机的优先级满足大根堆的顺序，期望高度O(logN)。
+// 插入和删除都由split和merge完成，不需要旋转
+package treap
+
+import (
+	"math/rand"
+
+	"github.com/mrtcx/plusdata/codec"
+	"github.com/mrtcx/plusdata/tree"
+)
+
+var _ tree.Tree[int, int] = (*treap[int, int])(nil)
+
+type treap[K, V any] struct {
+	root *Node[K, V]
+	cmp  tree.Comparator[K]
+	size int
+	kc   codec.Codec[K]
+	vc   codec.Codec[V]
+}
+
+type Node[K, V any] struct {
+	key            K
+	value          V
+	priority       uint32 //随机优先级，父节点不小于子节点
+	lchild, rchild *Node[K, V]
+	size           int //子树中节点的数量
+}
+
+func New[K, V any](cmp tree.Comparator[K]) *treap[K, V] {
+	return &treap[K, V]{cmp: cmp}
+}
+
+func (tp *treap[K, V]) newNode(key K, value V) *Node[K, V] {
+	return &Node[K, V]{key: key, value: value, priority: rand.Uint32(), size: 1}
+}
+
+func (tp *treap[K, V]) Clean() {
+	tp.root, tp.size = nil, 0
+}
+
+func (tp *treap[K, V]) Size() int {
+	return tp.size
+}
+
+func (tp *treap[K, V]) Empty() bool {
+	return tp.size == 0
+}
+
+// Insert key不存在时按key分成两棵树，与新节点依次合并
+func (tp *treap[K, V]) Insert(key K, value V) {
+	if node := tp.findNode(key); node != nil {
+		node.value = value
+		return
+	}
+	l, r := tp.split(tp.root, key, false)
+	tp.root = tp.merge(tp.merge(l, tp.newNode(key, value)), r)
+	tp.size++
+}
+
+// Remove 找到节点后用左右子树合并的结果代替它
+func (tp *treap[K, V]) Remove(key K) {
+	tp.root = tp.remove(tp.root, key)
+}
+
+func (tp *treap[K, V]) remove(root *Node[K, V], key K) *Node[K, V] {
+	if root == nil {
+		return nil
+	}
+	less := tp.cmp(key, root.key)
+	if less == 0 {
+		tp.size--
+		return tp.merge(root.lchild, root.rchild)
+	} else if less < 0 {
+		root.lchild = tp.remove(root.lchild, key)
+	} else {
+		root.rchild = tp.remove(root.rchild, key)
+	}
+	update(root)
+	return root
+}
+
+// 把子树分成小于key(upper为true时小于等于key)的l和其余的r
+func (tp *treap[K, V]) split(root *Node[K, V], key K, upper bool) (l, r *Node[K, V]) {
+	if root == nil {
+		return nil, nil
+	}
+	less := tp.cmp(key, root.key)
+	if less < 0 || less == 0 && !upper {
+		l, root.lchild = tp.split(root.lchild, key, upper)
+		update(root)
+		return l, root
+	}
+	root.rchild, r = tp.split(root.rchild, key, upper)
+	update(root)
+	return root, r
+}
+
+// 合并l和r，l中的key都小于r中的key，优先级大的节点作为根
+func (tp *treap[K, V]) merge(l, r *Node[K, V]) *Node[K, V] {
+	if l == nil {
+		return r
+	} else if r == nil {
+		return l
+	}
+	if l.priority > r.priority {
+		l.rchild = tp.merge(l.rchild, r)
+		update(l)
+		return l
+	}
+	r.lchild = tp.merge(l, r.lchild)
+	update(r)
+	return r
+}
+
+func (tp *treap[K, V]) Get(key K) (V, bool) {
+	node := tp.findNode(key)
+	if node == nil {
+		var zero V
+		return zero, false
+	}
+	return node.value, true
+}
+
+// 查找key所在的节点
+func (tp *treap[K, V]) findNode(key K) *Node[K, V] {
+	root := tp.root
+	for root != nil {
+		less := tp.cmp(key, root.key)
+		if less == 0 {
+			return root
+		} else if less < 0 {
+			root = root.lchild
+		} else {
+			root = root.rchild
+		}
+	}
+	return nil
+}
+
+func sizeOf[K, V any](node *Node[K, V]) int {
+	if node == nil {
+		return 0
+	}
+	return node.size
+}
+
+func update[K, V any](root *Node[K, V]) {
+	root.size = sizeOf(root.lchild) + sizeOf(root.rchild) + 1
+}
@@ -0,0 +1,215 @@
+// Copyright (c) 2025 Tian ChunXing. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package treap
+
+import (
+	"fmt"
+	"math/rand"
+	"testing"
+
+	"github.com/mrtcx/plusdata/internal/assert"
+	"github.com/mrtcx/plusdata/tree"
+)
+
+var intcmp = tree.OrderedComparator[int]
+
+func TestInsertRemove(t *testing.T) {
+	nums := []int{1, 2, 8, 1024}
+	for _, num := range nums {
+		tnum := num
+		t.Run(fmt.Sprintf("[num:%d]", tnum), func(t *testing.T) {
+			tp := New[int, int](intcmp)
+			exist := map[int]int{}
+			for i := 0; i < 4*tnum; i++ {
+				key := rand.Intn(tnum)
+				if rand.Intn(3) == 0 {
+					tp.Remove(key)
+					delete(exist, key)
+				} else {
+					tp.Insert(key, i)
+					exist[key] = i
+				}
+				if tnum < 1024 || i%64 == 0 {
+					checkTreap(t, tp)
+				}
+			}
+			checkTreap(t, tp)
+			assert.Equal(t, tp.Size(), len(exist))
+			for key := -1; key <= tnum; key++ {
+				value, ok := tp.Get(key)
+				_, exists := exist[key]
+				assert.Equal(t, ok, exists)
+				assert.Equal(t, value, exist[key])
+			}
+		})
+	}
+}
+
+func TestSplitJoin(t *testing.T) {
+	for _, num := range []int{0, 1, 2, 8, 1024} {
+		tp := New[int, int](intcmp)
+		for _, key := range rand.Perm(num) {
+			tp.Insert(key, key)
+		}
+		key := rand.Intn(num+2) - 1
+		left, right := tp.Split(key)
+		assert.Equal(t, tp.Size(), 0)
+		checkTreap(t, left)
+		checkTreap(t, right)
+		assert.Equal(t, left.Size(), min(max(key, 0), num))
+		assert.Equal(t, left.Size()+right.Size(), num)
+		if left.Size() > 0 && right.Size() > 0 {
+			assert.Equal(t, left.Right().Key()+1, right.Left().Key())
+			_, err := Join(right, left)
+			assert.Equal(t, err, tree.ErrNotSorted)
+		}
+
+		joined, err := Join(left, right)
+		assert.Equal(t, err, nil)
+		assert.Equal(t, right.Size(), 0)
+		checkTreap(t, joined)
+		assert.Equal(t, joined.Size(), num)
+		for i := 0; i < num; i++ {
+			assert.Equal(t, joined.Select(i).Key(), i)
+		}
+	}
+}
+
+// 检查key的顺序、优先级的堆序和子树大小
+func checkTreap(t *testing.T, tp *treap[int, int]) {
+	var check func(root *Node[int, int], lo, hi *int)
+	check = func(root *Node[int, int], lo, hi *int) {
+		if root == nil {
+			return
+		}
+		if lo != nil {
+			assert.Greater(t, root.key, *lo)
+		}
+		if hi != nil {
+			assert.Greater(t, *hi, root.key)
+		}
+		for _, child := range []*Node[int, int]{root.lchild, root.rchild} {
+			if child != nil {
+				assert.Equal(t, root.priority >= child.priority, true)
+			}
+		}
+		check(root.lchild, lo, &root.key)
+		check(root.rchild, &root.key, hi)
+		assert.Equal(t, root.size, sizeOf(root.lchild)+sizeOf(root.rchild)+1)
+	}
+	check(tp.root, nil, nil)
+	assert.Equal(t, tp.size, sizeOf(tp.root))
+}
+
+// 取得元素后修改树，前驱和后继按key查找，不受影响
+func TestElementAfterModify(t *testing.T) {
+	tp := New[int, int](intcmp)
+	for key := 10; key <= 50; key += 10 {
+		tp.Insert(key, key)
+	}
+	e := tp.Find(30)
+	tp.Insert(5, 5)
+	assert.Equal(t, e.Next().Key(), 40)
+	assert.Equal(t, e.Prev().Key(), 20)
+	tp.Remove(20)
+	tp.Remove(40)
+	assert.Equal(t, e.Next().Key(), 50)
+	assert.Equal(t, e.Prev().Key(), 10)
+	assert.Equal(t, e.Next().Next(), nil)
+	assert.Equal(t, e.Prev().Prev().Key(), 5)
+}
+
+func BenchmarkInsert(b *testing.B) {
+	testsizes := []int{100000, 500000, 1000000, 2000000}
+	testNames := []string{"10w", "50w", "100w", "200w"}
+	for i, v := range testsizes {
+		size := v
+		name := testNames[i]
+		b.Run(fmt.Sprintf("map-%s", name), func(b *testing.B) {
+			tr := make(map[int]interface{})
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				for j := 0; j < size; j++ {
+					tr[j] = nil
+				}
+			}
+		})
+		b.Run(fmt.Sprintf("treap-%s", name), func(b *testing.B) {
+			tr := New[int, interface{}](intcmp)
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				for j := 0; j < size; j++ {
+					tr.Insert(j, nil)
+				}
+			}
+		})
+	}
+}
+
+func BenchmarkRemove(b *testing.B) {
+	testsizes := []int{100000, 500000, 1000000, 2000000}
+	testNames := []string{"10w", "50w", "100w", "200w"}
+	for i, v := range testsizes {
+		size := v
+		name := testNames[i]
+		b.Run(fmt.Sprintf("map-%s", name), func(b *testing.B) {
+			tr := make(map[int]interface{})
+			for j := 0; j < size; j++ {
+				tr[j] = nil
+			}
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				for j := 0; j < size; j++ {
+					delete(tr, j)
+				}
+			}
+		})
+		b.Run(fmt.Sprintf("treap-%s", name), func(b *testing.B) {
+			tr := New[int, interface{}](intcmp)
+			for j := 0; j < size; j++ {
+				tr.Insert(j, nil)
+			}
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				for j := 0; j < size; j++ {
+					tr.Remove(j)
+				}
+			}
+		})
+	}
+}
+
+func BenchmarkGet(b *testing.B) {
+	testsizes := []int{100000, 500000, 1000000, 2000000}
+	testNames := []string{"10w", "50w", "100w", "200w"}
+	for i, v := range testsizes {
+		size := v
+		name := testNames[i]
+		b.Run(fmt.Sprintf("map-%s", name), func(b *testing.B) {
+			tr := make(map[int]interface{})
+			for j := 0; j < size; j++ {
+				tr[j] = nil
+			}
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				for j := 0; j < size; j++ {
+					_, _ = tr[j]
+				}
+			}
+		})
+		b.Run(fmt.Sprintf("treap-%s", name), func(b *testing.B) {
+			tr := New[int, interface{}](intcmp)
+			for j := 0; j < size; j++ {
+				tr.Insert(j, nil)
+			}
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				for j := 0; j < size; j++ {
+					_, _ = tr.Get(j)
+				}
+			}
+		})
+	}
+}
@@ -5,37 +5,126 @@
 package tree
 
 import (
+	"cmp"
+	"errors"
 	"strings"
 )
 
-type Comparator func(a, b interface{}) int
+var (
+	ErrNotSorted      = errors.New("tree: keys are not strictly increasing")
+	ErrLengthMismatch = errors.New("tree: keys and values have different lengths")
+	ErrFillFactor     = errors.New("tree: fill factor must be in (0, 1]")
+)
+
+// Comparator 比较函数, a<b返回负数, a==b返回0, a>b返回正数
+type Comparator[K any] func(a, b K) int
+
+// OrderedComparator 基于cmp.Ordered的默认比较函数, 如tree.OrderedComparator[int]
+func OrderedComparator[K cmp.Ordered](a, b K) int {
+	return cmp.Compare(a, b)
+}
 
-var IntComparator = func(left, right interface{}) int {
+// IntComparator interface{}版本的int比较函数, 配合Tree[any, any]使用
+//
+// Deprecated: 使用OrderedComparator[int]
+var IntComparator AnyComparator = func(left, right interface{}) int {
 	return left.(int) - right.(int)
 }
 
-var StringComparator = func(left, right interface{}) int {
+// StringComparator interface{}版本的string比较函数, 配合Tree[any, any]使用
+//
+// Deprecated: 使用OrderedComparator[string]
+var StringComparator AnyComparator = func(left, right interface{}) int {
 	return strings.Compare(left.(string), right.(string))
 }
 
-type Tree interface {
+// AnyTree、AnyElement、AnyComparator interface{}版本的树、元素和比较函数，对应泛型之前的Tree、Element、Comparator，
+// 配合IntComparator、StringComparator和各实现包中的NewAny使用
+type (
+	AnyTree       = Tree[any, any]
+	AnyElement    = Element[any, any]
+	AnyComparator = Comparator[any]
+)
+
+type Tree[K, V any] interface {
 	Size() int
 	Empty() bool
 	Clean()
-	Insert(key interface{}, value interface{})
-	Remove(key interface{})
-	Get(key interface{}) (interface{}, bool)
-	Find(key interface{}) Element
-	Left() Element
-	Right() Element
-	Prev(key interface{}) Element
-	Next(key interface{}) Element
-}
-
-type Element interface {
-	Key() interface{}
-	Value() interface{}
-	SetValue(value interface{})
-	Next() Element
-	Prev() Element
+	Insert(key K, value V)
+	Remove(key K)
+	Get(key K) (V, bool)
+	Find(key K) Element[K, V]
+	Left() Element[K, V]
+	Right() Element[K, V]
+	Prev(key K) Element[K, V]
+	Next(key K) Element[K, V]
+	AscendRange(lo, hi K, fn func(key K, value V) bool, bound ...Bound)
+	DescendRange(lo, hi K, fn func(key K, value V) bool, bound ...Bound)
+	AscendGreaterOrEqual(lo K, fn func(key K, value V) bool)
+	DescendLessOrEqual(hi K, fn func(key K, value V) bool)
+	Rank(key K) int
+	Select(k int) Element[K, V]
+}
+
+type Element[K, V any] interface {
+	Key() K
+	Value() V
+	SetValue(value V)
+	Next() Element[K, V]
+	Prev() Element[K, V]
+}
+
+// MultiTree 允许重复key的树(多重映射)，相同key的元素按插入的先后顺序排列;
+// 存在重复key时，Insert、Remove、Get、Find作用于其中一个元素
+type MultiTree[K, V any] interface {
+	Tree[K, V]
+	InsertDup(key K, value V)                       //插入，不覆盖key相同的元素
+	RemoveOne(key K, match func(value V) bool) bool //删除第一个key相同且match返回true的元素
+	RemoveAll(key K) int                            //删除key相同的全部元素，返回删除的个数
+	Count(key K) int                                //key相同的元素个数
+	EqualRange(key K) (first, last Element[K, V])   //key相同的第一个和最后一个元素，不存在时返回nil
+}
+
+// Iterator 有状态的迭代器, 保存当前位置到根的路径, Next和Prev均摊O(1), 不需要每一步都从根重新查找
+// (注意：迭代的过程中，不要对树做添加和删除操作)
+type Iterator[K, V any] interface {
+	Seek(key K) bool //定位到第一个key>=参数的元素
+	First() bool     //定位到最左端的元素
+	Last() bool      //定位到最右端的元素
+	Next() bool
+	Prev() bool
+	Valid() bool //越过两端后不再有效，需要重新Seek、First或Last
+	Key() K
+	Value() V
+}
+
+// Iterable 支持有状态迭代器的树
+type Iterable[K, V any] interface {
+	Iterator() Iterator[K, V]
+}
+
+// Bound 区间[lo, hi]两端的开闭, 不指定时为左闭右开ClosedOpen
+type Bound uint8
+
+const (
+	ClosedOpen Bound = iota //[lo, hi)
+	Closed                  //[lo, hi]
+	OpenClosed              //(lo, hi]
+	Open                    //(lo, hi)
+)
+
+// RangeBound 取可选参数中的区间开闭, 默认为ClosedOpen
+func RangeBound(bound []Bound) Bound {
+	if len(bound) == 0 {
+		return ClosedOpen
+	}
+	return bound[0]
+}
+
+func (b Bound) LoInclusive() bool {
+	return b == ClosedOpen || b == Closed
+}
+
+func (b Bound) HiInclusive() bool {
+	return b == Closed || b == OpenClosed
 }
@@ -0,0 +1,49 @@
+// Copyright (c) 2025 Tian ChunXing. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package tree_test
+
+import (
+	"testing"
+
+	"github.com/mrtcx/plusdata/internal/assert"
+	"github.com/mrtcx/plusdata/tree"
+	"github.com/mrtcx/plusdata/tree/avltree"
+	"github.com/mrtcx/plusdata/tree/bplustree"
+	"github.com/mrtcx/plusdata/tree/btree"
+	"github.com/mrtcx/plusdata/tree/rbtree"
+	"github.com/mrtcx/plusdata/tree/skiplist"
+)
+
+// interface{}版本的用法与泛型之前相同
+var anyTrees = map[string]func(cmp tree.AnyComparator) tree.AnyTree{
+	"avltree":   func(cmp tree.AnyComparator) tree.AnyTree { return avltree.NewAny(cmp) },
+	"rbtree":    func(cmp tree.AnyComparator) tree.AnyTree { return rbtree.NewAny(cmp) },
+	"skiplist":  func(cmp tree.AnyComparator) tree.AnyTree { return skiplist.NewAny(cmp) },
+	"btree":     func(cmp tree.AnyComparator) tree.AnyTree { return btree.NewAny(cmp, 4) },
+	"bplustree": func(cmp tree.AnyComparator) tree.AnyTree { return bplustree.NewAny(cmp, 4) },
+}
+
+func TestAnyTree(t *testing.T) {
+	for name, newTree := range anyTrees {
+		tname, tr := name, newTree(tree.IntComparator)
+		t.Run(tname, func(t *testing.T) {
+			for i := 0; i < 64; i++ {
+				tr.Insert(i, i*2)
+			}
+			value, ok := tr.Get(8)
+			assert.Equal(t, ok, true)
+			assert.Equal(t, value.(int), 16)
+			var e tree.AnyElement = tr.Find(8)
+			assert.Equal(t, e.Next().Key().(int), 9)
+			tr.Remove(9)
+			assert.Equal(t, e.Next().Key().(int), 10)
+			assert.Equal(t, tr.Size(), 63)
+		})
+	}
+	tr := rbtree.NewAny(tree.StringComparator)
+	tr.Insert("b", 2)
+	tr.Insert("a", 1)
+	assert.Equal(t, tr.Left().Key().(string), "a")
+}
@@ -0,0 +1,59 @@
+// Copyright (c) 2025 Tian ChunXing. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package wal
+
+import "github.com/mrtcx/plusdata/tree"
+
+// element 包装被包装树的元素，SetValue也要先写日志
+type element[K, V any] struct {
+	tree.Element[K, V]
+	wt *walTree[K, V]
+}
+
+func (wt *walTree[K, V]) wrap(e tree.Element[K, V]) tree.Element[K, V] {
+	if e == nil {
+		return nil
+	}
+	return &element[K, V]{Element: e, wt: wt}
+}
+
+func (e *element[K, V]) SetValue(value V) {
+	if e.wt.logOp(opInsert, e.Key(), value, true) {
+		e.Element.SetValue(value)
+		e.wt.autoCheckpoint()
+	}
+}
+
+func (e *element[K, V]) Next() tree.Element[K, V] {
+	return e.wt.wrap(e.Element.Next())
+}
+
+func (e *element[K, V]) Prev() tree.Element[K, V] {
+	return e.wt.wrap(e.Element.Prev())
+}
+
+func (wt *walTree[K, V]) Find(key K) tree.Element[K, V] {
+	return wt.wrap(wt.Tree.Find(key))
+}
+
+func (wt *walTree[K, V]) Left() tree.Element[K, V] {
+	return wt.wrap(wt.Tree.Left())
+}
+
+func (wt *walTree[K, V]) Right() tree.Element[K, V] {
+	return wt.wrap(wt.Tree.Right())
+}
+
+func (wt *walTree[K, V]) Prev(key K) tree.Element[K, V] {
+	return wt.wrap(wt.Tree.Prev(key))
+}
+
+func (wt *walTree[K, V]) Next(key K) tree.Element[K, V] {
+	return wt.wrap(wt.Tree.Next(key))
+}
+
+func (wt *walTree[K, V]) Select(k int) tree.Element[K, V] {
+	return wt.wrap(wt.Tree.Select(k))
+}
@@ -0,0 +1,99 @@
+// Copyright (c) 2025 Tian ChunXing. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package wal
+
+import (
+	"encoding/binary"
+	"hash/crc32"
+	"io"
+	"os"
+)
+
+const (
+	opInsert byte = iota + 1
+	opRemove
+	opClean
+)
+
+const _recordHeader = 8
+
+var crcTable = crc32.MakeTable(crc32.Castagnoli)
+
+// 日志记录:  length(uint32) | crc32c(uint32) | op | key | value
+// length和crc32c都是对op开始的内容计算
+func encodeRecord(buf []byte, op byte, payload func([]byte) []byte) []byte {
+	buf = append(buf, make([]byte, _recordHeader)...)
+	buf = append(buf, op)
+	buf = payload(buf)
+	body := buf[_recordHeader:]
+	binary.LittleEndian.PutUint32(buf, uint32(len(body)))
+	binary.LittleEndian.PutUint32(buf[4:], crc32.Checksum(body, crcTable))
+	return buf
+}
+
+// 重放日志中完整的记录，遇到不完整或者校验失败的记录时认为是崩溃时写了一半，
+// 从这条记录开始截掉，之后从文件末尾继续追加
+func (wt *walTree[K, V]) replay(log *os.File) error {
+	data, err := io.ReadAll(log)
+	if err != nil {
+		return err
+	}
+	off := 0
+	for len(data)-off >= _recordHeader {
+		length := int(binary.LittleEndian.Uint32(data[off:]))
+		sum := binary.LittleEndian.Uint32(data[off+4:])
+		if length == 0 || length > len(data)-off-_recordHeader {
+			break
+		}
+		body := data[off+_recordHeader : off+_recordHeader+length]
+		if crc32.Checksum(body, crcTable) != sum {
+			break
+		}
+		// 校验通过但解码失败，说明codec和写日志时不一致
+		if !wt.apply(body) {
+			return ErrCorrupt
+		}
+		off += _recordHeader + length
+	}
+	if off < len(data) {
+		if err := log.Truncate(int64(off)); err != nil {
+			return err
+		}
+	}
+	if _, err := log.Seek(int64(off), io.SeekStart); err != nil {
+		return err
+	}
+	wt.logSize = int64(off)
+	return nil
+}
+
+func (wt *walTree[K, V]) apply(body []byte) bool {
+	op, body := body[0], body[1:]
+	if op == opClean {
+		wt.Tree.Clean()
+		return len(body) == 0
+	}
+	key, n := wt.kc.Decode(body)
+	if n <= 0 {
+		return false
+	}
+	body = body[n:]
+	switch op {
+	case opInsert:
+		value, n := wt.vc.Decode(body)
+		if n <= 0 || n != len(body) {
+			return false
+		}
+		wt.Tree.Insert(key, value)
+	case opRemove:
+		if len(body) != 0 {
+			return false
+		}
+		wt.Tree.Remove(key)
+	default:
+		return false
+	}
+	return true
+}
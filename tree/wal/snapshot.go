@@ -0,0 +1,107 @@
+// Copyright (c) 2025 Tian ChunXing. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package wal
+
+import (
+	"encoding/binary"
+	"hash/crc32"
+	"os"
+	"path/filepath"
+
+	"github.com/mrtcx/plusdata/tree"
+)
+
+const (
+	_magic   = "PDWALSNP"
+	_version = 1
+)
+
+// 快照:  magic | version(uint32) | n(uvarint) | (key, value) * n | crc32c(uint32)
+// 先写临时文件再rename，快照文件要么是旧的，要么是完整的新快照
+func (wt *walTree[K, V]) writeSnapshot() error {
+	buf := append([]byte(_magic), 0, 0, 0, 0)
+	binary.LittleEndian.PutUint32(buf[len(_magic):], _version)
+	buf = binary.AppendUvarint(buf, uint64(wt.Size()))
+	for e := wt.Tree.Left(); e != nil; e = e.Next() {
+		buf = wt.kc.Append(buf, e.Key())
+		buf = wt.vc.Append(buf, e.Value())
+	}
+	buf = binary.LittleEndian.AppendUint32(buf, crc32.Checksum(buf, crcTable))
+
+	tmp := wt.path + ".snap.tmp"
+	f, err := os.Create(tmp)
+	if err != nil {
+		return err
+	}
+	if _, err := f.Write(buf); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Sync(); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Close(); err != nil {
+		return err
+	}
+	if err := os.Rename(tmp, wt.path+".snap"); err != nil {
+		return err
+	}
+	return syncDir(filepath.Dir(wt.path))
+}
+
+func syncDir(dir string) error {
+	d, err := os.Open(dir)
+	if err != nil {
+		return err
+	}
+	defer d.Close()
+	return d.Sync()
+}
+
+func (wt *walTree[K, V]) loadSnapshot() error {
+	buf, err := os.ReadFile(wt.path + ".snap")
+	if os.IsNotExist(err) {
+		return nil
+	} else if err != nil {
+		return err
+	}
+	if len(buf) < len(_magic)+8 || string(buf[:len(_magic)]) != _magic {
+		return ErrCorrupt
+	}
+	sum := binary.LittleEndian.Uint32(buf[len(buf)-4:])
+	buf = buf[:len(buf)-4]
+	if crc32.Checksum(buf, crcTable) != sum || binary.LittleEndian.Uint32(buf[len(_magic):]) != _version {
+		return ErrCorrupt
+	}
+	buf = buf[len(_magic)+4:]
+	cnt, n := binary.Uvarint(buf)
+	if n <= 0 {
+		return ErrCorrupt
+	}
+	buf = buf[n:]
+	// 被包装的树是多重树时，快照中key重复的元素按原有顺序全部保留
+	insert := wt.Tree.Insert
+	if mt, ok := wt.Tree.(tree.MultiTree[K, V]); ok {
+		insert = mt.InsertDup
+	}
+	for i := uint64(0); i < cnt; i++ {
+		key, n := wt.kc.Decode(buf)
+		if n <= 0 {
+			return ErrCorrupt
+		}
+		buf = buf[n:]
+		value, n := wt.vc.Decode(buf)
+		if n <= 0 {
+			return ErrCorrupt
+		}
+		buf = buf[n:]
+		insert(key, value)
+	}
+	if len(buf) != 0 {
+		return ErrCorrupt
+	}
+	return nil
+}
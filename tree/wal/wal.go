@@ -0,0 +1,165 @@
+// Copyright (c) 2025 Tian ChunXing. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+// Package wal 为任意tree.Tree提供预写日志和崩溃恢复
+//
+// Insert、Remove、Clean先追加到日志文件path，写入成功后才修改树；
+// Checkpoint把整棵树写入快照文件path+".snap"并清空日志。
+// Open时先加载快照，再重放日志，日志末尾写了一半的记录会被截掉。
+package wal
+
+import (
+	"errors"
+	"io"
+	"os"
+
+	"github.com/mrtcx/plusdata/codec"
+	"github.com/mrtcx/plusdata/tree"
+)
+
+var ErrCorrupt = errors.New("wal: log or snapshot is corrupt")
+
+var _ tree.Tree[int, int] = (*walTree[int, int])(nil)
+
+type Options struct {
+	Sync    bool  // 每条记录写入后调用fsync，为false时只保证进程崩溃不丢数据
+	LogSize int64 // 日志超过LogSize字节时自动Checkpoint，为0时不自动Checkpoint
+}
+
+// walTree 读操作直接交给被包装的树，写操作先写日志
+type walTree[K, V any] struct {
+	tree.Tree[K, V]
+	path    string
+	opts    Options
+	kc      codec.Codec[K]
+	vc      codec.Codec[V]
+	log     *os.File
+	w       io.Writer // 默认为log，测试时替换为注入错误的writer
+	logSize int64
+	buf     []byte
+	err     error
+}
+
+// Open 清空t，从path+".snap"的快照和path的日志中恢复t，之后的修改都会记录到日志中
+func Open[K, V any](path string, t tree.Tree[K, V], kc codec.Codec[K], vc codec.Codec[V], opts Options) (*walTree[K, V], error) {
+	wt := &walTree[K, V]{Tree: t, path: path, opts: opts, kc: kc, vc: vc}
+	t.Clean()
+	if err := wt.loadSnapshot(); err != nil {
+		return nil, err
+	}
+	log, err := os.OpenFile(path, os.O_RDWR|os.O_CREATE, 0644)
+	if err != nil {
+		return nil, err
+	}
+	if err := wt.replay(log); err != nil {
+		log.Close()
+		return nil, err
+	}
+	wt.log, wt.w = log, log
+	return wt, nil
+}
+
+// Err 返回写日志时遇到的第一个错误，出错后所有的修改都不再生效
+func (wt *walTree[K, V]) Err() error {
+	return wt.err
+}
+
+func (wt *walTree[K, V]) Insert(key K, value V) {
+	if wt.logOp(opInsert, key, value, true) {
+		wt.Tree.Insert(key, value)
+		wt.autoCheckpoint()
+	}
+}
+
+func (wt *walTree[K, V]) Remove(key K) {
+	var zero V
+	if wt.logOp(opRemove, key, zero, false) {
+		wt.Tree.Remove(key)
+		wt.autoCheckpoint()
+	}
+}
+
+func (wt *walTree[K, V]) Clean() {
+	var key K
+	var value V
+	if wt.logOp(opClean, key, value, false) {
+		wt.Tree.Clean()
+		wt.autoCheckpoint()
+	}
+}
+
+// 写入一条日志记录，成功时返回true
+func (wt *walTree[K, V]) logOp(op byte, key K, value V, hasValue bool) bool {
+	if wt.err != nil {
+		return false
+	}
+	wt.buf = encodeRecord(wt.buf[:0], op, func(buf []byte) []byte {
+		if op == opClean {
+			return buf
+		}
+		buf = wt.kc.Append(buf, key)
+		if hasValue {
+			buf = wt.vc.Append(buf, value)
+		}
+		return buf
+	})
+	if _, err := wt.w.Write(wt.buf); err != nil {
+		wt.err = err
+		return false
+	}
+	if wt.opts.Sync {
+		if err := wt.log.Sync(); err != nil {
+			wt.err = err
+			return false
+		}
+	}
+	wt.logSize += int64(len(wt.buf))
+	return true
+}
+
+// 修改树之后调用，日志超过Options.LogSize时Checkpoint
+func (wt *walTree[K, V]) autoCheckpoint() {
+	if wt.opts.LogSize > 0 && wt.logSize >= wt.opts.LogSize {
+		wt.Checkpoint()
+	}
+}
+
+// Checkpoint 把整棵树写入快照文件后清空日志
+// 写快照和清空日志之间崩溃时，恢复会在新快照上重放一遍旧日志，
+// Insert、Remove、Clean按顺序重放的结果只取决于每个key最后一次操作，所以结果不变
+func (wt *walTree[K, V]) Checkpoint() error {
+	if wt.err != nil {
+		return wt.err
+	}
+	if err := wt.writeSnapshot(); err != nil {
+		wt.err = err
+		return err
+	}
+	if err := wt.log.Truncate(0); err != nil {
+		wt.err = err
+		return err
+	}
+	if _, err := wt.log.Seek(0, io.SeekStart); err != nil {
+		wt.err = err
+		return err
+	}
+	if err := wt.log.Sync(); err != nil {
+		wt.err = err
+		return err
+	}
+	wt.logSize = 0
+	return nil
+}
+
+// Close 同步并关闭日志文件，不会Checkpoint
+func (wt *walTree[K, V]) Close() error {
+	err := wt.err
+	if serr := wt.log.Sync(); err == nil {
+		err = serr
+	}
+	if cerr := wt.log.Close(); err == nil {
+		err = cerr
+	}
+	return err
+}
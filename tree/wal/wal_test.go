@@ -0,0 +1,296 @@
+// Copyright (c) 2025 Tian ChunXing. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package wal
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"math/rand"
+	"os"
+	"path/filepath"
+	"sort"
+	"testing"
+
+	"github.com/mrtcx/plusdata/codec"
+	"github.com/mrtcx/plusdata/internal/assert"
+	"github.com/mrtcx/plusdata/tree"
+	"github.com/mrtcx/plusdata/tree/avltree"
+	"github.com/mrtcx/plusdata/tree/bplustree"
+	"github.com/mrtcx/plusdata/tree/btree"
+	"github.com/mrtcx/plusdata/tree/rbtree"
+	"github.com/mrtcx/plusdata/tree/scapegoat"
+	"github.com/mrtcx/plusdata/tree/skiplist"
+	"github.com/mrtcx/plusdata/tree/splaytree"
+	"github.com/mrtcx/plusdata/tree/treap"
+)
+
+var intcmp = tree.OrderedComparator[int]
+
+var trees = map[string]func() tree.Tree[int, int]{
+	"avltree":   func() tree.Tree[int, int] { return avltree.New[int, int](intcmp) },
+	"rbtree":    func() tree.Tree[int, int] { return rbtree.New[int, int](intcmp) },
+	"skiplist":  func() tree.Tree[int, int] { return skiplist.New[int, int](intcmp) },
+	"btree":     func() tree.Tree[int, int] { return btree.New[int, int](intcmp, 4) },
+	"bplustree": func() tree.Tree[int, int] { return bplustree.New[int, int](intcmp, 4) },
+	"treap":     func() tree.Tree[int, int] { return treap.New[int, int](intcmp) },
+	"splaytree": func() tree.Tree[int, int] { return splaytree.New[int, int](intcmp) },
+	"scapegoat": func() tree.Tree[int, int] { return scapegoat.New[int, int](intcmp) },
+}
+
+func open(t *testing.T, path string, tr tree.Tree[int, int], opts Options) *walTree[int, int] {
+	wt, err := Open[int, int](path, tr, codec.IntCodec{}, codec.IntCodec{}, opts)
+	assert.Equal(t, err, nil)
+	return wt
+}
+
+// 随机修改，每次修改后返回model的拷贝
+func randomOps(wt *walTree[int, int], model map[int]int, num int, after func(map[int]int)) {
+	for i := 0; i < num; i++ {
+		k := rand.Intn(num / 2)
+		switch r := rand.Intn(20); {
+		case r == 0:
+			wt.Clean()
+			for k := range model {
+				delete(model, k)
+			}
+		case r < 6:
+			wt.Remove(k)
+			delete(model, k)
+		case r < 8:
+			if e := wt.Find(k); e != nil {
+				e.SetValue(-i)
+				model[k] = -i
+			}
+		default:
+			wt.Insert(k, i)
+			model[k] = i
+		}
+		if after != nil {
+			cp := make(map[int]int, len(model))
+			for k, v := range model {
+				cp[k] = v
+			}
+			after(cp)
+		}
+	}
+}
+
+func expectTree(t *testing.T, tr tree.Tree[int, int], model map[int]int) {
+	keys := make([]int, 0, len(model))
+	for k := range model {
+		keys = append(keys, k)
+	}
+	sort.Ints(keys)
+	got, want := []string{}, []string{}
+	for e := tr.Left(); e != nil; e = e.Next() {
+		got = append(got, fmt.Sprint(e.Key(), ":", e.Value()))
+	}
+	for _, k := range keys {
+		want = append(want, fmt.Sprint(k, ":", model[k]))
+	}
+	assert.Equal(t, fmt.Sprint(got), fmt.Sprint(want))
+	assert.Equal(t, tr.Size(), len(model))
+}
+
+func TestRecover(t *testing.T) {
+	for name, newTree := range trees {
+		tnewTree := newTree
+		t.Run(name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "wal")
+			model := map[int]int{}
+			wt := open(t, path, tnewTree(), Options{})
+			randomOps(wt, model, 1000, nil)
+			assert.Equal(t, wt.Close(), nil)
+
+			wt = open(t, path, tnewTree(), Options{})
+			expectTree(t, wt, model)
+			assert.Equal(t, wt.Checkpoint(), nil)
+			randomOps(wt, model, 1000, nil)
+			assert.Equal(t, wt.Close(), nil)
+
+			// 快照和日志可以恢复到另一种树中
+			wt = open(t, path, rbtree.New[int, int](intcmp), Options{Sync: true})
+			expectTree(t, wt, model)
+			assert.Equal(t, wt.Close(), nil)
+		})
+	}
+}
+
+// 被包装的多重树中key重复的元素，Checkpoint后重新Open按原有顺序全部保留
+func TestSnapshotDup(t *testing.T) {
+	for name, newTree := range map[string]func() tree.MultiTree[int, int]{
+		"rbtree":    func() tree.MultiTree[int, int] { return rbtree.New[int, int](intcmp) },
+		"skiplist":  func() tree.MultiTree[int, int] { return skiplist.New[int, int](intcmp) },
+		"bplustree": func() tree.MultiTree[int, int] { return bplustree.New[int, int](intcmp, 4) },
+	} {
+		tnewTree := newTree
+		t.Run(name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "wal")
+			mt := tnewTree()
+			wt := open(t, path, mt, Options{})
+			for i := 0; i < 100; i++ {
+				mt.InsertDup(i%10, i)
+			}
+			want := dumpPairs(mt)
+			assert.Equal(t, wt.Checkpoint(), nil)
+			assert.Equal(t, wt.Close(), nil)
+
+			mt = tnewTree()
+			wt = open(t, path, mt, Options{})
+			assert.Equal(t, mt.Size(), 100)
+			assert.Equal(t, mt.Count(3), 10)
+			assert.Equal(t, dumpPairs(mt), want)
+			assert.Equal(t, wt.Close(), nil)
+		})
+	}
+}
+
+func dumpPairs(tr tree.Tree[int, int]) string {
+	pairs := []string{}
+	for e := tr.Left(); e != nil; e = e.Next() {
+		pairs = append(pairs, fmt.Sprint(e.Key(), ":", e.Value()))
+	}
+	return fmt.Sprint(pairs)
+}
+
+// 在日志的每个字节处截断，恢复后应该等于截断处之前最后一条完整记录时的状态
+func TestTornTail(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "wal")
+	wt := open(t, path, rbtree.New[int, int](intcmp), Options{})
+	states := []map[int]int{{}}
+	ends := []int64{0}
+	randomOps(wt, map[int]int{}, 200, func(model map[int]int) {
+		states = append(states, model)
+		ends = append(ends, wt.logSize)
+	})
+	assert.Equal(t, wt.Close(), nil)
+	data, err := os.ReadFile(path)
+	assert.Equal(t, err, nil)
+	assert.Equal(t, int64(len(data)), ends[len(ends)-1])
+
+	cutPath := filepath.Join(dir, "cut")
+	last := 0
+	for cut := 0; cut <= len(data); cut++ {
+		for last+1 < len(ends) && ends[last+1] <= int64(cut) {
+			last++
+		}
+		assert.Equal(t, os.WriteFile(cutPath, data[:cut], 0644), nil)
+		wt := open(t, cutPath, skiplist.New[int, int](intcmp), Options{})
+		expectTree(t, wt, states[last])
+		info, err := os.Stat(cutPath)
+		assert.Equal(t, err, nil)
+		assert.Equal(t, info.Size(), ends[last])
+		assert.Equal(t, wt.Close(), nil)
+	}
+
+	// 截断后继续追加，再次恢复时新记录有效
+	assert.Equal(t, os.WriteFile(cutPath, data[:len(data)-3], 0644), nil)
+	wt = open(t, cutPath, skiplist.New[int, int](intcmp), Options{})
+	last = len(ends) - 1
+	for ends[last] > int64(len(data)-3) {
+		last--
+	}
+	model := states[last]
+	wt.Insert(-1, -1)
+	model[-1] = -1
+	assert.Equal(t, wt.Close(), nil)
+	wt = open(t, cutPath, skiplist.New[int, int](intcmp), Options{})
+	expectTree(t, wt, model)
+	assert.Equal(t, wt.Close(), nil)
+}
+
+func TestCorruptRecord(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "wal")
+	wt := open(t, path, rbtree.New[int, int](intcmp), Options{})
+	model := map[int]int{}
+	for i := 0; i < 10; i++ {
+		wt.Insert(i, i)
+		model[i] = i
+	}
+	good := wt.logSize
+	for i := 10; i < 20; i++ {
+		wt.Insert(i, i)
+	}
+	assert.Equal(t, wt.Close(), nil)
+
+	data, err := os.ReadFile(path)
+	assert.Equal(t, err, nil)
+	data[good+_recordHeader+2] ^= 0xff
+	assert.Equal(t, os.WriteFile(path, data, 0644), nil)
+	wt = open(t, path, rbtree.New[int, int](intcmp), Options{})
+	defer wt.Close()
+	expectTree(t, wt, model)
+	assert.Equal(t, wt.logSize, good)
+}
+
+type faultWriter struct {
+	w io.Writer
+	n int
+}
+
+var errInjected = errors.New("injected write error")
+
+// 写入n个字节后返回错误，模拟写到一半时磁盘出错
+func (fw *faultWriter) Write(p []byte) (int, error) {
+	if len(p) <= fw.n {
+		fw.n -= len(p)
+		return fw.w.Write(p)
+	}
+	n, _ := fw.w.Write(p[:fw.n])
+	fw.n = 0
+	return n, errInjected
+}
+
+func TestWriteFault(t *testing.T) {
+	for _, limit := range []int{0, 1, 7, 8, 9, 100, 101, 333} {
+		tlimit := limit
+		t.Run(fmt.Sprint(tlimit), func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "wal")
+			wt := open(t, path, avltree.New[int, int](intcmp), Options{})
+			wt.w = &faultWriter{w: wt.log, n: tlimit}
+			model := map[int]int{}
+			for i := 0; wt.Err() == nil; i++ {
+				wt.Insert(i, i)
+				if wt.Err() == nil {
+					model[i] = i
+				}
+			}
+			assert.Equal(t, wt.Err(), errInjected)
+			// 出错后的修改都不生效
+			wt.Insert(-1, -1)
+			wt.Remove(0)
+			expectTree(t, wt, model)
+			assert.Equal(t, wt.Close(), errInjected)
+
+			wt = open(t, path, avltree.New[int, int](intcmp), Options{})
+			defer wt.Close()
+			expectTree(t, wt, model)
+		})
+	}
+}
+
+func TestAutoCheckpoint(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "wal")
+	wt := open(t, path, bplustree.New[int, int](intcmp, 8), Options{LogSize: 512})
+	model := map[int]int{}
+	randomOps(wt, model, 500, nil)
+	assert.Equal(t, wt.logSize < 512, true)
+	_, err := os.Stat(path + ".snap")
+	assert.Equal(t, err, nil)
+	assert.Equal(t, wt.Close(), nil)
+
+	wt = open(t, path, bplustree.New[int, int](intcmp, 8), Options{})
+	expectTree(t, wt, model)
+	assert.Equal(t, wt.Close(), nil)
+
+	data, err := os.ReadFile(path + ".snap")
+	assert.Equal(t, err, nil)
+	data[len(data)/2] ^= 0xff
+	assert.Equal(t, os.WriteFile(path+".snap", data, 0644), nil)
+	_, err = Open[int, int](path, rbtree.New[int, int](intcmp), codec.IntCodec{}, codec.IntCodec{}, Options{})
+	assert.Equal(t, err, ErrCorrupt)
+}